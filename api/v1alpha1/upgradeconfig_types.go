@@ -1,6 +1,8 @@
 package v1alpha1
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -85,8 +87,50 @@ type UpgradeHistory struct {
 	WorkerStartTime *metav1.Time `json:"workerStartTime,omitempty"`
 
 	WorkerCompleteTime *metav1.Time `json:"workerCompleteTime,omitempty"`
+
+	// HealthChecks records the latest result of each health check run for this upgrade
+	// +kubebuilder:validation:Optional
+	HealthChecks HealthCheckReports `json:"healthChecks,omitempty"`
 }
 
+// HealthCheckResult is a Go string type.
+type HealthCheckResult string
+
+const (
+	// HealthCheckPassed defines a health check that passed.
+	HealthCheckPassed HealthCheckResult = "Passed"
+	// HealthCheckFailed defines a health check that failed.
+	HealthCheckFailed HealthCheckResult = "Failed"
+)
+
+// HealthCheckReport records the result of a health check run
+type HealthCheckReport struct {
+	// Name of the health check
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum={"Passed","Failed"}
+	// Result of the latest run of the health check
+	Result HealthCheckResult `json:"result"`
+	// Objects that caused the health check to fail
+	// +kubebuilder:validation:Optional
+	AffectedObjects []string `json:"affectedObjects,omitempty"`
+	// Human readable message describing the failure
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+	// Indicates the monitoring stack was unavailable and the health check was evaluated
+	// through the Kubernetes API without alerts
+	// +kubebuilder:validation:Optional
+	MonitoringUnavailable bool `json:"monitoringUnavailable,omitempty"`
+	// First time the health check was seen with its current result
+	// +kubebuilder:validation:Optional
+	FirstSeen *metav1.Time `json:"firstSeen,omitempty"`
+	// Last time the health check was seen with its current result
+	// +kubebuilder:validation:Optional
+	LastSeen *metav1.Time `json:"lastSeen,omitempty"`
+}
+
+// HealthCheckReports is a set of HealthCheckReport instances.
+type HealthCheckReports []HealthCheckReport
+
 // UpgradeConditionType is a Go string type.
 type UpgradeConditionType string
 
@@ -342,6 +386,75 @@ func (conditions *Conditions) RemoveCondition(t UpgradeConditionType) bool {
 	return false
 }
 
+// IsFailed returns whether the health check failed.
+func (r HealthCheckReport) IsFailed() bool {
+	return r.Result == HealthCheckFailed
+}
+
+// GetReport searches the set of reports for the health check with the given
+// name and returns it. If the health check is not found, GetReport returns nil.
+func (reports HealthCheckReports) GetReport(name string) *HealthCheckReport {
+	for _, report := range reports {
+		if report.Name == name {
+			return &report
+		}
+	}
+	return nil
+}
+
+// SetReport adds (or updates) the set of reports with the given report.
+// FirstSeen is retained for as long as the health check keeps the same result.
+func (reports *HealthCheckReports) SetReport(newReport HealthCheckReport) {
+	now := &metav1.Time{Time: time.Now()}
+	newReport.FirstSeen = now
+	newReport.LastSeen = now
+	for i, report := range *reports {
+		if report.Name == newReport.Name {
+			if report.Result == newReport.Result && report.FirstSeen != nil {
+				newReport.FirstSeen = report.FirstSeen
+			}
+			(*reports)[i] = newReport
+			return
+		}
+	}
+	*reports = append(*reports, newReport)
+}
+
+// RemoveReport removes the health check with the given name from the set of reports.
+func (reports *HealthCheckReports) RemoveReport(name string) {
+	for i, report := range *reports {
+		if report.Name == name {
+			*reports = append((*reports)[:i], (*reports)[i+1:]...)
+			return
+		}
+	}
+}
+
+// GetFailed returns the reports of the health checks that failed.
+func (reports HealthCheckReports) GetFailed() HealthCheckReports {
+	failed := HealthCheckReports{}
+	for _, report := range reports {
+		if report.IsFailed() {
+			failed = append(failed, report)
+		}
+	}
+	return failed
+}
+
+// FailureSummary renders the failed health checks in the form used by
+// health check notifications, e.g. "NodeUnschedulableHealthcheckFailed:(node-a,node-b)"
+func (reports HealthCheckReports) FailureSummary() string {
+	failures := []string{}
+	for _, report := range reports.GetFailed() {
+		failure := report.Name + "HealthcheckFailed"
+		if len(report.AffectedObjects) > 0 {
+			failure = fmt.Sprintf("%s:(%s)", failure, strings.Join(report.AffectedObjects, ","))
+		}
+		failures = append(failures, failure)
+	}
+	return strings.Join(failures, ",")
+}
+
 // GetHistory returns UpgradeHistory
 func (histories UpgradeHistories) GetHistory(version string) *UpgradeHistory {
 	for _, history := range histories {
@@ -29,6 +29,55 @@ func (in Conditions) DeepCopy() Conditions {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckReport) DeepCopyInto(out *HealthCheckReport) {
+	*out = *in
+	if in.AffectedObjects != nil {
+		in, out := &in.AffectedObjects, &out.AffectedObjects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FirstSeen != nil {
+		in, out := &in.FirstSeen, &out.FirstSeen
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSeen != nil {
+		in, out := &in.LastSeen, &out.LastSeen
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckReport.
+func (in *HealthCheckReport) DeepCopy() *HealthCheckReport {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in HealthCheckReports) DeepCopyInto(out *HealthCheckReports) {
+	{
+		in := &in
+		*out = make(HealthCheckReports, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckReports.
+func (in HealthCheckReports) DeepCopy() HealthCheckReports {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckReports)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Update) DeepCopyInto(out *Update) {
 	*out = *in
@@ -198,6 +247,13 @@ func (in *UpgradeHistory) DeepCopyInto(out *UpgradeHistory) {
 		in, out := &in.WorkerCompleteTime, &out.WorkerCompleteTime
 		*out = (*in).DeepCopy()
 	}
+	if in.HealthChecks != nil {
+		in, out := &in.HealthChecks, &out.HealthChecks
+		*out = make(HealthCheckReports, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeHistory.
@@ -24,6 +24,8 @@ const (
 	ConfigField string = "config.yaml"
 	// EnvRoutes is used to determine if routes should be used during development
 	EnvRoutes string = "ROUTES"
+	// EnvExternalThanosURL is the URL of a Thanos query endpoint used when the cluster monitoring stack cannot be queried
+	EnvExternalThanosURL string = "EXTERNAL_THANOS_URL"
 
 	EnableOLMSkipRange = "true"
 )
@@ -61,3 +63,8 @@ func SetUserAgent() string {
 func UseRoutes() bool {
 	return os.Getenv(EnvRoutes) == "true"
 }
+
+// ExternalThanosURL returns the URL of the external Thanos query endpoint, if one is configured
+func ExternalThanosURL() string {
+	return os.Getenv(EnvExternalThanosURL)
+}
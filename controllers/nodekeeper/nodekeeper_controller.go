@@ -13,10 +13,12 @@ import (
 	"github.com/openshift/managed-upgrade-operator/pkg/drain"
 	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/pkg/pagerduty"
 	"github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -36,7 +38,9 @@ type ReconcileNodeKeeper struct {
 	MetricsClientBuilder        metrics.MetricsBuilder
 	DrainstrategyBuilder        drain.NodeDrainStrategyBuilder
 	UpgradeConfigManagerBuilder upgradeconfigmanager.UpgradeConfigManagerBuilder
+	IncidentManagerBuilder      pagerduty.IncidentManagerBuilder
 	Scheme                      *runtime.Scheme
+	Recorder                    record.EventRecorder
 }
 
 // Reconcile Note:
@@ -67,6 +71,15 @@ func (r *ReconcileNodeKeeper) Reconcile(ctx context.Context, request reconcile.R
 		return reconcile.Result{}, nil
 	}
 
+	target := config.CMTarget{}
+	cmTarget, err := target.NewCMTarget()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	cfm := r.ConfigManagerBuilder.New(r.Client, cmTarget)
+
+	incidents := pagerduty.NewLazyIncidentManager(r.IncidentManagerBuilder, r.Client, cfm)
+
 	// Fetch the Node instance
 	node := &corev1.Node{}
 	err = r.Client.Get(context.TODO(), request.NamespacedName, node)
@@ -80,6 +93,7 @@ func (r *ReconcileNodeKeeper) Reconcile(ctx context.Context, request reconcile.R
 			}
 			reqLogger.Info(fmt.Sprintf("Node %s deleted, resetting NodeDrainFailed metric", request.Name))
 			metricsClient.ResetMetricNodeDrainFailed(request.Name)
+			resolveDrainIncident(incidents, uc.Spec.Desired.Version, request.Name, reqLogger)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -93,16 +107,10 @@ func (r *ReconcileNodeKeeper) Reconcile(ctx context.Context, request reconcile.R
 	}
 	if !result.IsCordoned {
 		metricsClient.ResetMetricNodeDrainFailed(node.Name)
+		resolveDrainIncident(incidents, uc.Spec.Desired.Version, node.Name, reqLogger)
 		return reconcile.Result{}, nil
 	}
 
-	target := config.CMTarget{}
-	cmTarget, err := target.NewCMTarget()
-	if err != nil {
-		return reconcile.Result{}, err
-	}
-
-	cfm := r.ConfigManagerBuilder.New(r.Client, cmTarget)
 	cfg := &nodeKeeperConfig{}
 	err = cfm.Into(cfg)
 	if err != nil {
@@ -110,7 +118,7 @@ func (r *ReconcileNodeKeeper) Reconcile(ctx context.Context, request reconcile.R
 	}
 
 	if !cfg.NodeDrain.DisableDrainStrategies {
-		drainStrategy, err := r.DrainstrategyBuilder.NewNodeDrainStrategy(r.Client, reqLogger, uc, &cfg.NodeDrain)
+		drainStrategy, err := r.DrainstrategyBuilder.NewNodeDrainStrategy(r.Client, r.Recorder, reqLogger, uc, &cfg.NodeDrain)
 		if err != nil {
 			reqLogger.Error(err, "Error while executing drain.")
 			return reconcile.Result{}, err
@@ -128,9 +136,9 @@ func (r *ReconcileNodeKeeper) Reconcile(ctx context.Context, request reconcile.R
 		if err != nil {
 			return reconcile.Result{}, err
 		}
-		r.NodeDrainResult(node, reqLogger, hasFailed, metricsClient)
+		r.NodeDrainResult(node, reqLogger, hasFailed, metricsClient, incidents, uc.Spec.Desired.Version)
 	} else {
-		drainStrategy, err := r.DrainstrategyBuilder.NewDefaultNodeDrainStrategy(r.Client, reqLogger, uc, &cfg.NodeDrain)
+		drainStrategy, err := r.DrainstrategyBuilder.NewDefaultNodeDrainStrategy(r.Client, r.Recorder, reqLogger, uc, &cfg.NodeDrain)
 		if err != nil {
 			reqLogger.Error(err, "Error while executing drain.")
 			return reconcile.Result{}, err
@@ -139,7 +147,7 @@ func (r *ReconcileNodeKeeper) Reconcile(ctx context.Context, request reconcile.R
 		if err != nil {
 			return reconcile.Result{}, err
 		}
-		r.NodeDrainResult(node, reqLogger, hasFailed, metricsClient)
+		r.NodeDrainResult(node, reqLogger, hasFailed, metricsClient, incidents, uc.Spec.Desired.Version)
 	}
 
 	return reconcile.Result{RequeueAfter: time.Minute * 1}, nil
@@ -154,7 +162,7 @@ func (r *ReconcileNodeKeeper) SetupWithManager(mgr ctrl.Manager) error {
 }
 
 // Check the NodeDrainResult
-func (r *ReconcileNodeKeeper) NodeDrainResult(node *corev1.Node, reqLogger logr.Logger, hasFailed bool, metricsClient metrics.Metrics) {
+func (r *ReconcileNodeKeeper) NodeDrainResult(node *corev1.Node, reqLogger logr.Logger, hasFailed bool, metricsClient metrics.Metrics, incidents pagerduty.IncidentManager, version string) {
 
 	if hasFailed {
 		// If the node.DeletionTimestamp is set NodeDrainFailed metric needs to be reset
@@ -162,14 +170,29 @@ func (r *ReconcileNodeKeeper) NodeDrainResult(node *corev1.Node, reqLogger logr.
 			reqLogger.Info(fmt.Sprintf("DeletionTimestamp set for the node %s. Re-setting NodeDrainFailed metric",
 				node.Name))
 			metricsClient.ResetMetricNodeDrainFailed(node.Name)
+			resolveDrainIncident(incidents, version, node.Name, reqLogger)
 		} else {
 			reqLogger.Info(fmt.Sprintf("Node drain timed out %s. Alerting.", node.Name))
 			// Set metric only for the node going through upgrade
 			if r.Machinery.IsNodeUpgrading(node) {
 				metricsClient.UpdateMetricNodeDrainFailed(node.Name)
+				err := incidents.Trigger(pagerduty.IncidentNodeDrainFailed, version, node.Name, fmt.Sprintf("Node %s failed to drain during the upgrade to %s", node.Name, version))
+				if err != nil {
+					reqLogger.Error(err, "Failed to trigger the PagerDuty incident of the node drain failure", "node", node.Name)
+				}
 			}
 		}
 	} else {
 		metricsClient.ResetMetricNodeDrainFailed(node.Name)
+		resolveDrainIncident(incidents, version, node.Name, reqLogger)
+	}
+}
+
+// resolveDrainIncident resolves the PagerDuty incident of the node drain failure. Failures are
+// only logged so that they do not hold the drain back.
+func resolveDrainIncident(incidents pagerduty.IncidentManager, version string, nodeName string, reqLogger logr.Logger) {
+	err := incidents.Resolve(pagerduty.IncidentNodeDrainFailed, version, nodeName)
+	if err != nil {
+		reqLogger.Error(err, "Failed to resolve the PagerDuty incident of the node drain failure", "node", nodeName)
 	}
 }
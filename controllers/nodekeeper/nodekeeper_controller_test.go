@@ -2,6 +2,7 @@ package nodekeeper
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
@@ -19,6 +21,8 @@ import (
 	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
 	mockMachinery "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
 	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/pagerduty"
+	mockPagerDuty "github.com/openshift/managed-upgrade-operator/pkg/pagerduty/mocks"
 	mockUCMgr "github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager/mocks"
 	"github.com/openshift/managed-upgrade-operator/util/mocks"
 	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
@@ -42,6 +46,8 @@ var _ = Describe("NodeKeeperController", func() {
 		mockDrainStrategy               *mockDrain.MockNodeDrainStrategy
 		mockUpgradeConfigManager        *mockUCMgr.MockUpgradeConfigManager
 		mockUpgradeConfigManagerBuilder *mockUCMgr.MockUpgradeConfigManagerBuilder
+		mockIncidentManagerBuilder      *mockPagerDuty.MockIncidentManagerBuilder
+		mockIncidentManager             *mockPagerDuty.MockIncidentManager
 		testNodeName                    types.NamespacedName
 		upgradeConfigName               types.NamespacedName
 		config                          nodeKeeperConfig
@@ -60,6 +66,8 @@ var _ = Describe("NodeKeeperController", func() {
 		mockDrainStrategy = mockDrain.NewMockNodeDrainStrategy(mockCtrl)
 		mockUpgradeConfigManagerBuilder = mockUCMgr.NewMockUpgradeConfigManagerBuilder(mockCtrl)
 		mockUpgradeConfigManager = mockUCMgr.NewMockUpgradeConfigManager(mockCtrl)
+		mockIncidentManagerBuilder = mockPagerDuty.NewMockIncidentManagerBuilder(mockCtrl)
+		mockIncidentManager = mockPagerDuty.NewMockIncidentManager(mockCtrl)
 		testNodeName = types.NamespacedName{
 			Name: "test-node-1",
 		}
@@ -77,7 +85,9 @@ var _ = Describe("NodeKeeperController", func() {
 			mockMetricsBuilder,
 			mockDrainStrategyBuilder,
 			mockUpgradeConfigManagerBuilder,
+			mockIncidentManagerBuilder,
 			runtime.NewScheme(),
+			record.NewFakeRecorder(10),
 		}
 	})
 
@@ -132,15 +142,17 @@ var _ = Describe("NodeKeeperController", func() {
 					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
 					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
 					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()).Times(1),
 					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}}),
 					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
-					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 					mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, config),
-					mockDrainStrategyBuilder.EXPECT().NewDefaultNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockDrainStrategy, nil),
+					mockDrainStrategyBuilder.EXPECT().NewDefaultNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockDrainStrategy, nil),
 					mockDrainStrategy.EXPECT().HasFailed(gomock.Any(), gomock.Any()).Return(true, nil),
 					mockMachineryClient.EXPECT().IsNodeUpgrading(gomock.Any()).Return(true),
 					mockMetricsClient.EXPECT().UpdateMetricNodeDrainFailed(gomock.Any()).Times(1),
+					mockIncidentManagerBuilder.EXPECT().New(gomock.Any(), mockConfigManager).Return(mockIncidentManager, nil),
+					mockIncidentManager.EXPECT().Trigger(pagerduty.IncidentNodeDrainFailed, uc.Spec.Desired.Version, gomock.Any(), gomock.Any()),
 					mockMetricsClient.EXPECT().ResetMetricNodeDrainFailed(gomock.Any()).Times(0),
 				)
 				result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
@@ -165,16 +177,18 @@ var _ = Describe("NodeKeeperController", func() {
 					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
 					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
 					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()).Times(1),
 					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}}),
 					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
-					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 					mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, config),
-					mockDrainStrategyBuilder.EXPECT().NewNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockDrainStrategy, nil),
+					mockDrainStrategyBuilder.EXPECT().NewNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockDrainStrategy, nil),
 					mockDrainStrategy.EXPECT().Execute(gomock.Any(), gomock.Any()).Return([]*drain.DrainStrategyResult{}, nil),
 					mockDrainStrategy.EXPECT().HasFailed(gomock.Any(), gomock.Any()).Return(true, nil),
 					mockMachineryClient.EXPECT().IsNodeUpgrading(gomock.Any()).Return(true),
 					mockMetricsClient.EXPECT().UpdateMetricNodeDrainFailed(gomock.Any()).Times(1),
+					mockIncidentManagerBuilder.EXPECT().New(gomock.Any(), mockConfigManager).Return(mockIncidentManager, nil),
+					mockIncidentManager.EXPECT().Trigger(pagerduty.IncidentNodeDrainFailed, uc.Spec.Desired.Version, gomock.Any(), gomock.Any()),
 					mockMetricsClient.EXPECT().ResetMetricNodeDrainFailed(gomock.Any()).Times(0),
 				)
 				result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
@@ -186,16 +200,35 @@ var _ = Describe("NodeKeeperController", func() {
 					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
 					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
 					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()).Times(1),
 					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: false}),
 					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
 					mockMetricsClient.EXPECT().ResetMetricNodeDrainFailed(gomock.Any()).Times(1),
+					mockIncidentManagerBuilder.EXPECT().New(gomock.Any(), mockConfigManager).Return(mockIncidentManager, nil),
+					mockIncidentManager.EXPECT().Resolve(pagerduty.IncidentNodeDrainFailed, uc.Spec.Desired.Version, gomock.Any()),
 					mockMetricsClient.EXPECT().UpdateMetricNodeDrainFailed(gomock.Any()).Times(0),
 				)
 				result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(result.RequeueAfter).To(BeZero())
 			})
+			It("should not fail the reconcile when the PagerDuty configuration can't be loaded", func() {
+				gomock.InOrder(
+					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()).Times(1),
+					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: false}),
+					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
+					mockMetricsClient.EXPECT().ResetMetricNodeDrainFailed(gomock.Any()).Times(1),
+					mockIncidentManagerBuilder.EXPECT().New(gomock.Any(), mockConfigManager).Return(nil, fmt.Errorf("fake error")),
+				)
+				result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+			})
 		})
 		Context("Metric reset", func() {
 			var uc upgradev1alpha1.UpgradeConfig
@@ -219,15 +252,17 @@ var _ = Describe("NodeKeeperController", func() {
 					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
 					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
 					mockMachineryClient.EXPECT().IsUpgrading(mockKubeClient, "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 					mockKubeClient.EXPECT().Get(context.TODO(), testNodeName, gomock.Any()).SetArg(2, node),
 					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}}),
 					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
-					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 					mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, config),
-					mockDrainStrategyBuilder.EXPECT().NewNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockDrainStrategy, nil),
+					mockDrainStrategyBuilder.EXPECT().NewNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockDrainStrategy, nil),
 					mockDrainStrategy.EXPECT().Execute(gomock.Any(), gomock.Any()).Return([]*drain.DrainStrategyResult{}, nil),
 					mockDrainStrategy.EXPECT().HasFailed(gomock.Any(), gomock.Any()).Return(true, nil),
 					mockMetricsClient.EXPECT().ResetMetricNodeDrainFailed(gomock.Any()).Times(1),
+					mockIncidentManagerBuilder.EXPECT().New(gomock.Any(), mockConfigManager).Return(mockIncidentManager, nil),
+					mockIncidentManager.EXPECT().Resolve(pagerduty.IncidentNodeDrainFailed, uc.Spec.Desired.Version, testNodeName.Name),
 					mockMachineryClient.EXPECT().IsNodeUpgrading(gomock.Any()).Times(0),
 					mockMetricsClient.EXPECT().UpdateMetricNodeDrainFailed(gomock.Any()).Times(0),
 				)
@@ -241,9 +276,12 @@ var _ = Describe("NodeKeeperController", func() {
 					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
 					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
 					mockMachineryClient.EXPECT().IsUpgrading(mockKubeClient, "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 					mockKubeClient.EXPECT().Get(context.TODO(), testNodeName, gomock.Any()).Return(notFoundErr),
 					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
 					mockMetricsClient.EXPECT().ResetMetricNodeDrainFailed(testNodeName.Name).Times(1),
+					mockIncidentManagerBuilder.EXPECT().New(gomock.Any(), mockConfigManager).Return(mockIncidentManager, nil),
+					mockIncidentManager.EXPECT().Resolve(pagerduty.IncidentNodeDrainFailed, uc.Spec.Desired.Version, testNodeName.Name),
 				)
 				result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
 				Expect(err).NotTo(HaveOccurred())
@@ -3,7 +3,6 @@ package upgradeconfig
 import (
 	"context"
 	"fmt"
-	"regexp"
 	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -22,9 +21,11 @@ import (
 	ucmgr "github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
 	cub "github.com/openshift/managed-upgrade-operator/pkg/upgraders"
 	"github.com/openshift/managed-upgrade-operator/pkg/validation"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -51,8 +52,12 @@ type ReconcileUpgradeConfig struct {
 	EventManagerBuilder    eventmanager.EventManagerBuilder
 	UcMgrBuilder           ucmgr.UpgradeConfigManagerBuilder
 	DvoClientBuilder       dvo.DvoClientBuilder
+	Recorder               record.EventRecorder
 }
 
+// EventReasonPhaseChanged is the reason of the Events recorded on the UpgradeConfig when its phase changes
+const EventReasonPhaseChanged = "UpgradePhaseChanged"
+
 // Reconcile reads that state of the cluster for a UpgradeConfig object and makes changes based on the state read
 // and what is in the UpgradeConfig.Spec
 // Note:
@@ -69,7 +74,7 @@ func (r *ReconcileUpgradeConfig) Reconcile(ctx context.Context, request reconcil
 	}
 
 	// Initialise event manager
-	eventClient, err := r.EventManagerBuilder.NewManager(r.Client)
+	eventClient, err := r.EventManagerBuilder.NewManager(r.Client, r.Recorder)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -143,7 +148,7 @@ func (r *ReconcileUpgradeConfig) Reconcile(ctx context.Context, request reconcil
 		return reconcile.Result{}, err
 	}
 
-	upgrader, err := r.ClusterUpgraderBuilder.NewClient(r.Client, cfm, metricsClient, eventClient, instance.Spec.Type)
+	upgrader, err := r.ClusterUpgraderBuilder.NewClient(r.Client, cfm, metricsClient, eventClient, r.Recorder, instance.Spec.Type)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -175,6 +180,11 @@ func (r *ReconcileUpgradeConfig) Reconcile(ctx context.Context, request reconcil
 			if err != nil || !result {
 				reqLogger.Error(err, "Pre HealthCheck failed on scheduling upgrade")
 			}
+			// The health check records its reports in the instance history, re-read it
+			// so that they are kept when moving to the pending phase
+			if h := instance.Status.History.GetHistory(instance.Spec.Desired.Version); h != nil {
+				history = h
+			}
 		} else {
 			reqLogger.Info("Skipping PreHealthCheck")
 		}
@@ -185,6 +195,7 @@ func (r *ReconcileUpgradeConfig) Reconcile(ctx context.Context, request reconcil
 		if err != nil {
 			return reconcile.Result{}, err
 		}
+		r.recordPhaseChange(instance, upgradev1alpha1.UpgradePhaseNew, upgradev1alpha1.UpgradePhasePending)
 
 		return reconcile.Result{RequeueAfter: time.Minute * 1}, nil
 
@@ -242,6 +253,7 @@ func (r *ReconcileUpgradeConfig) Reconcile(ctx context.Context, request reconcil
 				if err != nil {
 					return reconcile.Result{}, err
 				}
+				r.recordPhaseChange(instance, upgradev1alpha1.UpgradePhasePending, upgradev1alpha1.UpgradePhaseNew)
 				return reconcile.Result{}, nil
 			}
 
@@ -255,6 +267,7 @@ func (r *ReconcileUpgradeConfig) Reconcile(ctx context.Context, request reconcil
 			if err != nil {
 				return reconcile.Result{}, err
 			}
+			r.recordPhaseChange(instance, upgradev1alpha1.UpgradePhasePending, upgradev1alpha1.UpgradePhaseUpgrading)
 
 			reqLogger.Info(fmt.Sprintf("Cluster is commencing %s upgrade.", instance.Spec.Type), "time", now)
 			return r.upgradeCluster(upgrader, instance, reqLogger)
@@ -267,6 +280,12 @@ func (r *ReconcileUpgradeConfig) Reconcile(ctx context.Context, request reconcil
 			return reconcile.Result{}, err
 		}
 
+		// Remind of the upcoming upgrade. A reminder that can't be sent doesn't hold the upgrade back.
+		err = eventClient.NotifyReminder()
+		if err != nil {
+			reqLogger.Error(err, "Failed to send the upcoming upgrade reminder")
+		}
+
 		// If we approach the time of the upgrade before the next reconcile,
 		// reconcile closer to that point
 		if schedulerResult.TimeUntilUpgrade.Seconds() > 0 &&
@@ -300,6 +319,7 @@ func (r *ReconcileUpgradeConfig) upgradeCluster(upgrader cub.ClusterUpgrader, uc
 	me = multierror.Append(err, me)
 
 	history := uc.Status.History.GetHistory(uc.Spec.Desired.Version)
+	previousPhase := history.Phase
 	history.Phase = phase
 	if phase == upgradev1alpha1.UpgradePhaseUpgraded {
 		history.CompleteTime = &metav1.Time{Time: time.Now()}
@@ -307,10 +327,22 @@ func (r *ReconcileUpgradeConfig) upgradeCluster(upgrader cub.ClusterUpgrader, uc
 	uc.Status.History.SetHistory(*history)
 	err = r.Client.Status().Update(context.TODO(), uc)
 	me = multierror.Append(err, me)
+	if err == nil && previousPhase != phase {
+		r.recordPhaseChange(uc, previousPhase, phase)
+	}
 
 	return reconcile.Result{RequeueAfter: 1 * time.Minute}, me.ErrorOrNil()
 }
 
+// recordPhaseChange records an Event on the UpgradeConfig about the change of its phase
+func (r *ReconcileUpgradeConfig) recordPhaseChange(uc *upgradev1alpha1.UpgradeConfig, from upgradev1alpha1.UpgradePhase, to upgradev1alpha1.UpgradePhase) {
+	eventType := corev1.EventTypeNormal
+	if to == upgradev1alpha1.UpgradePhaseFailed {
+		eventType = corev1.EventTypeWarning
+	}
+	r.Recorder.Eventf(uc, eventType, EventReasonPhaseChanged, "Upgrade to %s changed phase from %s to %s", uc.Spec.Desired.Version, from, to)
+}
+
 // reportUpgradeMetrics updates prometheus with statistics from the latest upgrade
 func reportUpgradeMetrics(metricsClient metrics.Metrics, name string, precedingVersion string, version string, upgradeStart time.Time, upgradeEnd time.Time) error {
 	upgradeAlerts, err := metricsClient.AlertsFromUpgrade(upgradeStart, upgradeEnd)
@@ -318,7 +350,7 @@ func reportUpgradeMetrics(metricsClient metrics.Metrics, name string, precedingV
 		return err
 	}
 
-	minorUpgrade, err := getMinorUpgrade(precedingVersion, version)
+	minorUpgrade, err := clusterversion.GetMinorUpgrade(precedingVersion, version)
 	if err != nil {
 		return fmt.Errorf("failed to figure out if it is a minor upgrade: %v", err)
 	}
@@ -327,24 +359,6 @@ func reportUpgradeMetrics(metricsClient metrics.Metrics, name string, precedingV
 	return nil
 }
 
-func getMinorUpgrade(precedingVersion, version string) (string, error) {
-	minorRegex, err := regexp.Compile(`[0-9]+\.([0-9]+)\..*`)
-	if err != nil {
-		return "unknown", fmt.Errorf("failed to compile regex: %v", err)
-	}
-	versionMinorRes := minorRegex.FindStringSubmatch(version)
-	precedingVersionMinorRes := minorRegex.FindStringSubmatch(precedingVersion)
-	if len(versionMinorRes) < 2 || len(precedingVersionMinorRes) < 2 {
-		return "unknown", nil
-	}
-
-	if versionMinorRes[1] != precedingVersionMinorRes[1] {
-		return "y", nil
-	}
-
-	return "z", nil
-}
-
 // ManagedUpgradePredicate is used for managing predicates of the UpgradeConfig
 func ManagedUpgradePredicate() predicate.Predicate {
 	return predicate.Funcs{
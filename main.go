@@ -67,6 +67,7 @@ import (
 	"github.com/openshift/managed-upgrade-operator/pkg/k8sutil"
 	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/pkg/pagerduty"
 	"github.com/openshift/managed-upgrade-operator/pkg/scheduler"
 	"github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
 	cub "github.com/openshift/managed-upgrade-operator/pkg/upgraders"
@@ -76,7 +77,9 @@ import (
 
 	opmetrics "github.com/openshift/operator-custom-metrics/pkg/metrics"
 
+	apiserverv1 "github.com/openshift/api/apiserver/v1"
 	configv1 "github.com/openshift/api/config/v1"
+	consolev1 "github.com/openshift/api/console/v1"
 	machineapi "github.com/openshift/api/machine/v1beta1"
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
@@ -103,7 +106,9 @@ func init() {
 	utilruntime.Must(monitoringv1.AddToScheme(scheme))
 	utilruntime.Must(routev1.Install(scheme))
 	utilruntime.Must(configv1.Install(scheme))
+	utilruntime.Must(consolev1.Install(scheme))
 	utilruntime.Must(machineapi.Install(scheme))
+	utilruntime.Must(apiserverv1.Install(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -194,6 +199,7 @@ func main() {
 		EventManagerBuilder:    eventmanager.NewBuilder(),
 		UcMgrBuilder:           upgradeconfigmanager.NewBuilder(),
 		DvoClientBuilder:       dvo.NewBuilder(),
+		Recorder:               mgr.GetEventRecorderFor("managed-upgrade-operator"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "UpgradeConfig")
 		os.Exit(1)
@@ -208,6 +214,8 @@ func main() {
 		MetricsClientBuilder:        metrics.NewBuilder(),
 		DrainstrategyBuilder:        drain.NewBuilder(),
 		UpgradeConfigManagerBuilder: upgradeconfigmanager.NewBuilder(),
+		IncidentManagerBuilder:      pagerduty.NewBuilder(),
+		Recorder:                    mgr.GetEventRecorderFor("managed-upgrade-operator"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NodeKeeper")
 		os.Exit(1)
@@ -297,6 +305,17 @@ func main() {
 	log.Info("Starting UpgradeConfig manager")
 	go ucMgr.StartSync(stopCh)
 
+	// The notification outbox is a manager runnable so that only the leader sends the queued notifications
+	outboxClient, err := client.New(cfg, client.Options{Scheme: mgr.GetScheme()})
+	if err != nil {
+		log.Error(err, "unable to create notification outbox client")
+		os.Exit(1)
+	}
+	if err := mgr.Add(eventmanager.NewOutboxProcessor(outboxClient, eventmanager.NewBuilder(), mgr.GetEventRecorderFor("managed-upgrade-operator"))); err != nil {
+		setupLog.Error(err, "unable to add the notification outbox")
+		os.Exit(1)
+	}
+
 	setupLog.Info("starting manager")
 	if err := mgr.Start(stopCh); err != nil {
 		setupLog.Error(err, "problem running manager")
@@ -470,4 +470,22 @@ var _ = Describe("ClusterVersion client and utils", func() {
 
 	})
 
+	Context("When getting the minor upgrade", func() {
+		It("Returns y for a minor version upgrade", func() {
+			minorUpgrade, err := GetMinorUpgrade("4.15.10", "4.16.2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(minorUpgrade).To(Equal("y"))
+		})
+		It("Returns z for a patch version upgrade", func() {
+			minorUpgrade, err := GetMinorUpgrade("4.16.1", "4.16.2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(minorUpgrade).To(Equal("z"))
+		})
+		It("Returns unknown when a version cannot be parsed", func() {
+			minorUpgrade, err := GetMinorUpgrade("unknown", "4.16.2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(minorUpgrade).To(Equal("unknown"))
+		})
+	})
+
 })
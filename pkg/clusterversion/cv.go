@@ -3,6 +3,7 @@ package clusterversion
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"sort"
 
 	"github.com/go-logr/logr"
@@ -254,6 +255,26 @@ func GetCurrentVersion(clusterVersion *configv1.ClusterVersion) (string, error)
 	return gotVersion, nil
 }
 
+// GetMinorUpgrade returns "y" if upgrading between the versions changes the minor version,
+// "z" if it does not and "unknown" if either version cannot be parsed
+func GetMinorUpgrade(precedingVersion, version string) (string, error) {
+	minorRegex, err := regexp.Compile(`[0-9]+\.([0-9]+)\..*`)
+	if err != nil {
+		return "unknown", fmt.Errorf("failed to compile regex: %v", err)
+	}
+	versionMinorRes := minorRegex.FindStringSubmatch(version)
+	precedingVersionMinorRes := minorRegex.FindStringSubmatch(precedingVersion)
+	if len(versionMinorRes) < 2 || len(precedingVersionMinorRes) < 2 {
+		return "unknown", nil
+	}
+
+	if versionMinorRes[1] != precedingVersionMinorRes[1] {
+		return "y", nil
+	}
+
+	return "z", nil
+}
+
 // GetCurrentVersionMinusOne strings a latest version -1 as a string and error
 func GetCurrentVersionMinusOne(clusterVersion *configv1.ClusterVersion) (string, error) {
 	var gotVersionMinusOne string
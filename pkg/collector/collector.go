@@ -38,6 +38,10 @@ const (
 	helpPostClusterHealthCheck                 = "Unix Timestamp indicating time of post cluster health check"
 	helpSendCompletedNotificationTimestamp     = "Unix Timestamp indicating time of complete upgrade notification event"
 
+	// .status.history[].healthChecks[]
+	helpHealthCheckFailed          = "Int indicating whether a pre-upgrade health check is failing"
+	helpHealthCheckAffectedObjects = "Number of objects causing a pre-upgrade health check to fail"
+
 	// Error handling for failed scrapes
 	helpCollectorFailed = "An error occurred during scape of metrics"
 )
@@ -69,6 +73,10 @@ type ManagedOSMetrics struct {
 	removeMaintWindow         *prometheus.Desc
 	postClusterHealthCheck    *prometheus.Desc
 	sendCompletedNotification *prometheus.Desc
+
+	// .status.history[].healthChecks[]
+	healthCheckFailed          *prometheus.Desc
+	healthCheckAffectedObjects *prometheus.Desc
 }
 
 // UpgradeCollector is implementing prometheus.Collector interface.
@@ -255,6 +263,24 @@ func bootstrapMetrics() *ManagedOSMetrics {
 				keyDesiredVersion,
 				keyCondition,
 			}, nil),
+		healthCheckFailed: prometheus.NewDesc(
+			prometheus.BuildFQName(MetricsNamespace, subSystemHealthCheck, "failed"),
+			helpHealthCheckFailed,
+			[]string{
+				keyUpgradeConfigName,
+				keyVersion,
+				keyDesiredVersion,
+				keyHealthCheck,
+			}, nil),
+		healthCheckAffectedObjects: prometheus.NewDesc(
+			prometheus.BuildFQName(MetricsNamespace, subSystemHealthCheck, "affected_objects"),
+			helpHealthCheckAffectedObjects,
+			[]string{
+				keyUpgradeConfigName,
+				keyVersion,
+				keyDesiredVersion,
+				keyHealthCheck,
+			}, nil),
 	}
 }
 
@@ -283,6 +309,10 @@ func (uc *UpgradeCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- uc.managedMetrics.removeMaintWindow
 	ch <- uc.managedMetrics.postClusterHealthCheck
 	ch <- uc.managedMetrics.sendCompletedNotification
+
+	// .status.history[].healthChecks[]
+	ch <- uc.managedMetrics.healthCheckFailed
+	ch <- uc.managedMetrics.healthCheckAffectedObjects
 }
 
 // Collect is method required to implement the prometheus.Collector(prometheus/client_golang/prometheus/collector.go) interface.
@@ -374,9 +404,39 @@ func (uc *UpgradeCollector) collectUpgradeConditions(ch chan<- prometheus.Metric
 			collectCondition(&c, uc.managedMetrics.sendCompletedNotification, upgradeConfig, cvVersion, ch)
 		}
 	}
+
+	uc.collectHealthChecks(upgradeConfig, h, cvVersion, ch)
 	return nil
 }
 
+// collectHealthChecks writes the outcome of each recorded pre-upgrade health check
+func (uc *UpgradeCollector) collectHealthChecks(ucfg *upgradev1alpha1.UpgradeConfig, h *upgradev1alpha1.UpgradeHistory, cvV string, ch chan<- prometheus.Metric) {
+	for _, r := range h.HealthChecks {
+		failed := float64(0)
+		if r.Result == upgradev1alpha1.HealthCheckFailed {
+			failed = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			uc.managedMetrics.healthCheckFailed,
+			prometheus.GaugeValue,
+			failed,
+			ucfg.Name,
+			cvV,
+			ucfg.Spec.Desired.Version,
+			r.Name,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			uc.managedMetrics.healthCheckAffectedObjects,
+			prometheus.GaugeValue,
+			float64(len(r.AffectedObjects)),
+			ucfg.Name,
+			cvV,
+			ucfg.Spec.Desired.Version,
+			r.Name,
+		)
+	}
+}
+
 func collectCondition(c *upgradev1alpha1.UpgradeCondition, promDesc *prometheus.Desc, ucfg *upgradev1alpha1.UpgradeConfig, cvV string, ch chan<- prometheus.Metric) {
 	switch c.Status {
 	case corev1.ConditionTrue:
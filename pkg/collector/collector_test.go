@@ -2,6 +2,7 @@ package collector
 
 import (
 	"os"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -133,6 +134,29 @@ var _ = Describe("Upgrade Conditions Collector", func() {
 					Expect(err).To(BeNil())
 					Expect(source_version).To(Equal(TEST_UPGRADE_VERSION))
 				})
+				It("collects metrics for recorded health checks", func() {
+					upgradeConfig.Status.History[0].HealthChecks = upgradev1alpha1.HealthCheckReports{
+						{Name: "PDB", Result: upgradev1alpha1.HealthCheckFailed, AffectedObjects: []string{"ns/a", "ns/b"}},
+						{Name: "ClusterOperators", Result: upgradev1alpha1.HealthCheckPassed},
+					}
+					gomock.InOrder(
+						mockUpgradeConfigManager.EXPECT().Get().Return(&upgradeConfig, nil),
+						mockCVClient.EXPECT().GetClusterVersion().Return(&cv, nil),
+					)
+					expected := `
+# HELP managed_upgrade_healthcheck_affected_objects Number of objects causing a pre-upgrade health check to fail
+# TYPE managed_upgrade_healthcheck_affected_objects gauge
+managed_upgrade_healthcheck_affected_objects{desired_version="4.4.4",healthcheck="ClusterOperators",upgradeconfig_name="test-upgrade-config",version="new version"} 0
+managed_upgrade_healthcheck_affected_objects{desired_version="4.4.4",healthcheck="PDB",upgradeconfig_name="test-upgrade-config",version="new version"} 2
+# HELP managed_upgrade_healthcheck_failed Int indicating whether a pre-upgrade health check is failing
+# TYPE managed_upgrade_healthcheck_failed gauge
+managed_upgrade_healthcheck_failed{desired_version="4.4.4",healthcheck="ClusterOperators",upgradeconfig_name="test-upgrade-config",version="new version"} 0
+managed_upgrade_healthcheck_failed{desired_version="4.4.4",healthcheck="PDB",upgradeconfig_name="test-upgrade-config",version="new version"} 1
+`
+					err := promtestutil.CollectAndCompare(upgradeCollector, strings.NewReader(expected),
+						"managed_upgrade_healthcheck_failed", "managed_upgrade_healthcheck_affected_objects")
+					Expect(err).To(BeNil())
+				})
 			})
 		})
 	})
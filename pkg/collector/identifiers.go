@@ -2,10 +2,11 @@ package collector
 
 // generics for metric construction
 const (
-	MetricsNamespace   = "managed_upgrade"
-	subSystemUpgrade   = "upgrade"
-	subSystemCollector = "collector"
-	subSystemCondition = "condition"
+	MetricsNamespace     = "managed_upgrade"
+	subSystemUpgrade     = "upgrade"
+	subSystemCollector   = "collector"
+	subSystemCondition   = "condition"
+	subSystemHealthCheck = "healthcheck"
 )
 
 // keys for labels
@@ -15,4 +16,5 @@ const (
 	keyVersion           = "version"
 	keyDesiredVersion    = "desired_version"
 	keyCondition         = "condition"
+	keyHealthCheck       = "healthcheck"
 )
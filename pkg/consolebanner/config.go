@@ -0,0 +1,86 @@
+package consolebanner
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	consolev1 "github.com/openshift/api/console/v1"
+)
+
+// defaultText is the text of the banner when none is configured
+const defaultText = "Cluster maintenance in progress: upgrading to {{ .Version }} ({{ .Stage }})." +
+	"{{ with .ExpectedCompletion }} Expected to complete by {{ . }}.{{ end }}" +
+	" Workloads may be restarted while worker nodes are drained."
+
+// Config is the configuration of the console banner shown while the cluster is upgrading
+type Config struct {
+	// Disabled stops the banner from being shown
+	Disabled bool `yaml:"disabled"`
+	// Text is the template of the banner text, rendered with BannerData
+	Text string `yaml:"text"`
+	// Color is the CSS color of the banner text
+	Color string `yaml:"color"`
+	// BackgroundColor is the CSS color of the banner background
+	BackgroundColor string `yaml:"backgroundColor"`
+	// Location is where the banner is shown, one of BannerTop, BannerBottom or BannerTopBottom
+	Location consolev1.ConsoleNotificationLocation `yaml:"location"`
+}
+
+// BannerData is the data the banner text is rendered with
+type BannerData struct {
+	// Version is the version the cluster is upgrading to
+	Version string
+	// Stage describes the stage the upgrade is at
+	Stage string
+	// ExpectedCompletion is the time the worker maintenance is expected to end, if known
+	ExpectedCompletion string
+}
+
+// IsValid returns an error if the text template can't be rendered or the location is unknown
+func (cfg *Config) IsValid() error {
+	switch cfg.Location {
+	case "", consolev1.BannerTop, consolev1.BannerBottom, consolev1.BannerTopBottom:
+	default:
+		return fmt.Errorf("config consoleBanner location %q is invalid (Requires one of BannerTop, BannerBottom, BannerTopBottom)", cfg.Location)
+	}
+	tmpl, err := cfg.getTemplate()
+	if err != nil {
+		return err
+	}
+	_, err = render(tmpl, BannerData{Version: "4.14.2", Stage: "upgrading worker nodes", ExpectedCompletion: "Mon, 02 Jan 2006 15:04:05 UTC"})
+	if err != nil {
+		return fmt.Errorf("config consoleBanner text is invalid: %v", err)
+	}
+	return nil
+}
+
+// GetLocation returns where the banner is shown
+func (cfg *Config) GetLocation() consolev1.ConsoleNotificationLocation {
+	if cfg.Location == "" {
+		return consolev1.BannerTop
+	}
+	return cfg.Location
+}
+
+// getTemplate returns the parsed template of the banner text
+func (cfg *Config) getTemplate() (*template.Template, error) {
+	text := cfg.Text
+	if text == "" {
+		text = defaultText
+	}
+	tmpl, err := template.New("consoleBanner").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("config consoleBanner text is invalid: %v", err)
+	}
+	return tmpl, nil
+}
+
+// render renders the banner text
+func render(tmpl *template.Template, data BannerData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,126 @@
+package consolebanner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	consolev1 "github.com/openshift/api/console/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ConsoleNotificationName is the name of the ConsoleNotification shown while the cluster is upgrading
+	ConsoleNotificationName = "managed-upgrade-operator-upgrade"
+	// ExpectedCompletionAnnotation records the expected completion of the upgrade on the ConsoleNotification
+	// so that it is kept across reconciles
+	ExpectedCompletionAnnotation = "upgrade.managed.openshift.io/expected-completion"
+)
+
+// ConsoleBanner shows the progress of the upgrade to the cluster users in the web console
+//
+//go:generate mockgen -destination=mocks/consolebanner.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/consolebanner ConsoleBanner
+type ConsoleBanner interface {
+	Ensure(version, stage string, expectedCompletion *time.Time) error
+	Remove() error
+}
+
+// NewConsoleBanner returns a ConsoleBanner managing a ConsoleNotification
+func NewConsoleBanner(c client.Client, cfg *Config) ConsoleBanner {
+	return &consoleNotificationBanner{
+		client: c,
+		config: cfg,
+	}
+}
+
+type consoleNotificationBanner struct {
+	client client.Client
+	config *Config
+}
+
+// Ensure creates or updates the ConsoleNotification with the version and stage of the upgrade.
+// The expected completion previously recorded is kept when expectedCompletion is nil.
+// Clusters without the console API are skipped.
+func (b *consoleNotificationBanner) Ensure(version, stage string, expectedCompletion *time.Time) error {
+	if b.config.Disabled {
+		return b.Remove()
+	}
+
+	cn := &consolev1.ConsoleNotification{}
+	err := b.client.Get(context.TODO(), client.ObjectKey{Name: ConsoleNotificationName}, cn)
+	found := true
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("can't read console notification: %v", err)
+		}
+		found = false
+		cn = &consolev1.ConsoleNotification{
+			ObjectMeta: metav1.ObjectMeta{Name: ConsoleNotificationName},
+		}
+	}
+
+	annotations := map[string]string{}
+	for k, v := range cn.Annotations {
+		annotations[k] = v
+	}
+	if expectedCompletion != nil {
+		annotations[ExpectedCompletionAnnotation] = expectedCompletion.UTC().Format(time.RFC3339)
+	}
+	data := BannerData{Version: version, Stage: stage}
+	if value, ok := annotations[ExpectedCompletionAnnotation]; ok {
+		completion, err := time.Parse(time.RFC3339, value)
+		if err == nil {
+			data.ExpectedCompletion = completion.Format(time.RFC1123)
+		}
+	}
+
+	tmpl, err := b.config.getTemplate()
+	if err != nil {
+		return err
+	}
+	text, err := render(tmpl, data)
+	if err != nil {
+		return fmt.Errorf("can't render console banner text: %v", err)
+	}
+	spec := consolev1.ConsoleNotificationSpec{
+		Text:            text,
+		Location:        b.config.GetLocation(),
+		Color:           b.config.Color,
+		BackgroundColor: b.config.BackgroundColor,
+	}
+
+	if found {
+		if cn.Spec == spec && cn.Annotations[ExpectedCompletionAnnotation] == annotations[ExpectedCompletionAnnotation] {
+			return nil
+		}
+		cn.Spec = spec
+		cn.Annotations = annotations
+		err = b.client.Update(context.TODO(), cn)
+	} else {
+		cn.Spec = spec
+		cn.Annotations = annotations
+		err = b.client.Create(context.TODO(), cn)
+	}
+	if err != nil {
+		return fmt.Errorf("can't update console notification: %v", err)
+	}
+	return nil
+}
+
+// Remove deletes the ConsoleNotification if it exists
+func (b *consoleNotificationBanner) Remove() error {
+	cn := &consolev1.ConsoleNotification{
+		ObjectMeta: metav1.ObjectMeta{Name: ConsoleNotificationName},
+	}
+	err := b.client.Delete(context.TODO(), cn)
+	if err != nil && !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return fmt.Errorf("can't delete console notification: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package consolebanner
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestConsoleBanner(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ConsoleBanner Suite")
+}
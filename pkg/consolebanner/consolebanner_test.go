@@ -0,0 +1,148 @@
+package consolebanner
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	consolev1 "github.com/openshift/api/console/v1"
+	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+)
+
+var _ = Describe("Console banner", func() {
+	var (
+		mockCtrl       *gomock.Controller
+		mockKubeClient *mocks.MockClient
+		config         *Config
+		banner         ConsoleBanner
+		notFound       error
+		completion     time.Time
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		config = &Config{}
+		banner = NewConsoleBanner(mockKubeClient, config)
+		notFound = errors.NewNotFound(schema.GroupResource{Group: "console.openshift.io", Resource: "consolenotifications"}, ConsoleNotificationName)
+		completion = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When validating the configuration", func() {
+		It("accepts the defaults", func() {
+			Expect(config.IsValid()).To(Succeed())
+			Expect(config.GetLocation()).To(Equal(consolev1.BannerTop))
+		})
+
+		It("rejects an unknown location", func() {
+			config.Location = "Sidebar"
+			Expect(config.IsValid()).NotTo(Succeed())
+		})
+
+		It("rejects a text that can't be rendered", func() {
+			config.Text = "Upgrading to {{ .Release }}"
+			Expect(config.IsValid()).NotTo(Succeed())
+		})
+	})
+
+	Context("When ensuring the banner", func() {
+		It("creates it with the version, stage and expected completion", func() {
+			config.BackgroundColor = "#0088ce"
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(notFound),
+				mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, cn *consolev1.ConsoleNotification, _ ...interface{}) error {
+						Expect(cn.Name).To(Equal(ConsoleNotificationName))
+						Expect(cn.Annotations).To(HaveKeyWithValue(ExpectedCompletionAnnotation, "2026-01-01T12:00:00Z"))
+						Expect(cn.Spec.Text).To(ContainSubstring("upgrading to 4.14.2 (upgrading worker nodes)"))
+						Expect(cn.Spec.Text).To(ContainSubstring("Expected to complete by Thu, 01 Jan 2026 12:00:00 UTC"))
+						Expect(cn.Spec.Location).To(Equal(consolev1.BannerTop))
+						Expect(cn.Spec.BackgroundColor).To(Equal("#0088ce"))
+						return nil
+					}),
+			)
+			Expect(banner.Ensure("4.14.2", "upgrading worker nodes", &completion)).To(Succeed())
+		})
+
+		It("keeps the recorded expected completion when none is given", func() {
+			cn := consolev1.ConsoleNotification{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        ConsoleNotificationName,
+					Annotations: map[string]string{ExpectedCompletionAnnotation: "2026-01-01T12:00:00Z"},
+				},
+				Spec: consolev1.ConsoleNotificationSpec{Text: "outdated"},
+			}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, cn),
+				mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, cn *consolev1.ConsoleNotification, _ ...interface{}) error {
+						Expect(cn.Spec.Text).To(ContainSubstring("(verifying the cluster health)"))
+						Expect(cn.Spec.Text).To(ContainSubstring("Expected to complete by Thu, 01 Jan 2026 12:00:00 UTC"))
+						return nil
+					}),
+			)
+			Expect(banner.Ensure("4.14.2", "verifying the cluster health", nil)).To(Succeed())
+		})
+
+		It("does not update an unchanged banner", func() {
+			var created consolev1.ConsoleNotification
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(notFound),
+				mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, cn *consolev1.ConsoleNotification, _ ...interface{}) error {
+						created = *cn
+						return nil
+					}),
+			)
+			Expect(banner.Ensure("4.14.2", "upgrading the control plane", nil)).To(Succeed())
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, created)
+			Expect(banner.Ensure("4.14.2", "upgrading the control plane", nil)).To(Succeed())
+		})
+
+		It("removes the banner when it is disabled", func() {
+			config.Disabled = true
+			mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(notFound)
+			Expect(banner.Ensure("4.14.2", "upgrading worker nodes", nil)).To(Succeed())
+		})
+
+		It("skips clusters without the console API", func() {
+			noMatch := &meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: "console.openshift.io", Kind: "ConsoleNotification"}}
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(noMatch)
+			Expect(banner.Ensure("4.14.2", "upgrading worker nodes", nil)).To(Succeed())
+		})
+
+		It("returns an error when the banner can't be read", func() {
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error"))
+			Expect(banner.Ensure("4.14.2", "upgrading worker nodes", nil)).NotTo(Succeed())
+		})
+	})
+
+	Context("When removing the banner", func() {
+		It("deletes it", func() {
+			mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+			Expect(banner.Remove()).To(Succeed())
+		})
+
+		It("succeeds when it does not exist", func() {
+			mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(notFound)
+			Expect(banner.Remove()).To(Succeed())
+		})
+
+		It("returns an error when it can't be deleted", func() {
+			mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error"))
+			Expect(banner.Remove()).NotTo(Succeed())
+		})
+	})
+})
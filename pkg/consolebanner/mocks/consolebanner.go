@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/openshift/managed-upgrade-operator/pkg/consolebanner (interfaces: ConsoleBanner)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/consolebanner.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/consolebanner ConsoleBanner
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockConsoleBanner is a mock of ConsoleBanner interface.
+type MockConsoleBanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockConsoleBannerMockRecorder
+}
+
+// MockConsoleBannerMockRecorder is the mock recorder for MockConsoleBanner.
+type MockConsoleBannerMockRecorder struct {
+	mock *MockConsoleBanner
+}
+
+// NewMockConsoleBanner creates a new mock instance.
+func NewMockConsoleBanner(ctrl *gomock.Controller) *MockConsoleBanner {
+	mock := &MockConsoleBanner{ctrl: ctrl}
+	mock.recorder = &MockConsoleBannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConsoleBanner) EXPECT() *MockConsoleBannerMockRecorder {
+	return m.recorder
+}
+
+// Ensure mocks base method.
+func (m *MockConsoleBanner) Ensure(arg0, arg1 string, arg2 *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ensure", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ensure indicates an expected call of Ensure.
+func (mr *MockConsoleBannerMockRecorder) Ensure(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ensure", reflect.TypeOf((*MockConsoleBanner)(nil).Ensure), arg0, arg1, arg2)
+}
+
+// Remove mocks base method.
+func (m *MockConsoleBanner) Remove() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockConsoleBannerMockRecorder) Remove() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockConsoleBanner)(nil).Remove))
+}
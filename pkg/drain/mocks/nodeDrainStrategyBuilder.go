@@ -16,6 +16,7 @@ import (
 	v1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
 	drain "github.com/openshift/managed-upgrade-operator/pkg/drain"
 	gomock "go.uber.org/mock/gomock"
+	record "k8s.io/client-go/tools/record"
 	client "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -43,31 +44,31 @@ func (m *MockNodeDrainStrategyBuilder) EXPECT() *MockNodeDrainStrategyBuilderMoc
 }
 
 // NewDefaultNodeDrainStrategy mocks base method.
-func (m *MockNodeDrainStrategyBuilder) NewDefaultNodeDrainStrategy(arg0 client.Client, arg1 logr.Logger, arg2 *v1alpha1.UpgradeConfig, arg3 *drain.NodeDrain) (drain.NodeDrainStrategy, error) {
+func (m *MockNodeDrainStrategyBuilder) NewDefaultNodeDrainStrategy(arg0 client.Client, arg1 record.EventRecorder, arg2 logr.Logger, arg3 *v1alpha1.UpgradeConfig, arg4 *drain.NodeDrain) (drain.NodeDrainStrategy, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "NewDefaultNodeDrainStrategy", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "NewDefaultNodeDrainStrategy", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(drain.NodeDrainStrategy)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // NewDefaultNodeDrainStrategy indicates an expected call of NewDefaultNodeDrainStrategy.
-func (mr *MockNodeDrainStrategyBuilderMockRecorder) NewDefaultNodeDrainStrategy(arg0, arg1, arg2, arg3 any) *gomock.Call {
+func (mr *MockNodeDrainStrategyBuilderMockRecorder) NewDefaultNodeDrainStrategy(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewDefaultNodeDrainStrategy", reflect.TypeOf((*MockNodeDrainStrategyBuilder)(nil).NewDefaultNodeDrainStrategy), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewDefaultNodeDrainStrategy", reflect.TypeOf((*MockNodeDrainStrategyBuilder)(nil).NewDefaultNodeDrainStrategy), arg0, arg1, arg2, arg3, arg4)
 }
 
 // NewNodeDrainStrategy mocks base method.
-func (m *MockNodeDrainStrategyBuilder) NewNodeDrainStrategy(arg0 client.Client, arg1 logr.Logger, arg2 *v1alpha1.UpgradeConfig, arg3 *drain.NodeDrain) (drain.NodeDrainStrategy, error) {
+func (m *MockNodeDrainStrategyBuilder) NewNodeDrainStrategy(arg0 client.Client, arg1 record.EventRecorder, arg2 logr.Logger, arg3 *v1alpha1.UpgradeConfig, arg4 *drain.NodeDrain) (drain.NodeDrainStrategy, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "NewNodeDrainStrategy", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "NewNodeDrainStrategy", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(drain.NodeDrainStrategy)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // NewNodeDrainStrategy indicates an expected call of NewNodeDrainStrategy.
-func (mr *MockNodeDrainStrategyBuilderMockRecorder) NewNodeDrainStrategy(arg0, arg1, arg2, arg3 any) *gomock.Call {
+func (mr *MockNodeDrainStrategyBuilderMockRecorder) NewNodeDrainStrategy(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewNodeDrainStrategy", reflect.TypeOf((*MockNodeDrainStrategyBuilder)(nil).NewNodeDrainStrategy), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewNodeDrainStrategy", reflect.TypeOf((*MockNodeDrainStrategyBuilder)(nil).NewNodeDrainStrategy), arg0, arg1, arg2, arg3, arg4)
 }
@@ -9,11 +9,12 @@ import (
 	"github.com/hashicorp/go-multierror"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/eventmanager"
 	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
-	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
 	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
 )
 
@@ -25,28 +26,34 @@ var (
 	stuckTerminatingPodName        = "POD-STUCK-TERMINATING"
 )
 
+// EventReasonDrainStrategyExecuted is the reason of the Event recorded on a Node when a drain
+// strategy force-deletes its pods or removes their finalizers
+const EventReasonDrainStrategyExecuted = "DrainStrategyExecuted"
+
 // NewNodeDrainStrategy returns a new node drain stategy
-func NewNodeDrainStrategy(c client.Client, cfg *NodeDrain, ts []TimedDrainStrategy, uc *upgradev1alpha1.UpgradeConfig,
-	notifier notifier.Notifier, metricsClient metrics.Metrics) (NodeDrainStrategy, error) {
+func NewNodeDrainStrategy(c client.Client, recorder record.EventRecorder, cfg *NodeDrain, ts []TimedDrainStrategy, uc *upgradev1alpha1.UpgradeConfig,
+	notifier notifier.Notifier, eventManager eventmanager.EventManager) (NodeDrainStrategy, error) {
 	return &osdDrainStrategy{
 		c,
+		recorder,
 		machinery.NewMachinery(),
 		cfg,
 		ts,
 		uc,
 		notifier,
-		metricsClient,
+		eventManager,
 	}, nil
 }
 
 type osdDrainStrategy struct {
 	client               client.Client
+	recorder             record.EventRecorder
 	machinery            machinery.Machinery
 	cfg                  *NodeDrain
 	timedDrainStrategies []TimedDrainStrategy
 	uc                   *upgradev1alpha1.UpgradeConfig
 	notifier             notifier.Notifier
-	metricsClient        metrics.Metrics
+	eventManager         eventmanager.EventManager
 }
 
 func (ds *osdDrainStrategy) Execute(node *corev1.Node, logger logr.Logger) ([]*DrainStrategyResult, error) {
@@ -72,26 +79,21 @@ func (ds *osdDrainStrategy) Execute(node *corev1.Node, logger logr.Logger) ([]*D
 				me = multierror.Append(err, me)
 				if r.HasExecuted {
 					if dsName == pdbPodDeleteName {
-						// Check if a notification for it has been sent successfully - if so, nothing to do
-						isNotified, err := ds.metricsClient.IsMetricNotificationEventSentSet(ds.uc.Name,
-							string(notifier.MuoStateDelayed), ds.uc.Spec.Desired.Version)
-						if err != nil {
+						// The notification is sent once per upgrade, and queued in the notification
+						// outbox when it can't be sent, so that it doesn't hold the drain back
+						logger.Info("Sending upgrade delay message about node drain grace period")
+						msg := "Node drain grace period might be impacting cluster upgrade. " +
+							"Please refer to the article for further details https://access.redhat.com/solutions/7075425"
+						if err := ds.eventManager.NotifyResult(notifier.MuoStateDelayed, msg); err != nil {
 							logger.Error(err, "Failed to send the service log about upgrade delay due to node drain grace period")
-							return nil, fmt.Errorf("can't check cluster metric NotificationSent: %v", err)
 						}
-						if !isNotified {
-							logger.Info("Sending upgrade delay message about node drain grace period")
-							msg := "Node drain grace period might be impacting cluster upgrade. " +
-								"Please refer to the article for further details https://access.redhat.com/solutions/7075425"
-							err = ds.notifier.NotifyState(notifier.MuoStateDelayed, msg)
-							if err != nil {
-								logger.Error(err, "Failed to send the service log about upgrade delay due to node drain grace period")
-								return nil, err
-							}
-							// set the notrification send metric
-							ds.metricsClient.UpdateMetricNotificationEventSent(ds.uc.Name, string(notifier.MuoStateDelayed), ds.uc.Spec.Desired.Version)
+					}
+					ds.recorder.Eventf(node, corev1.EventTypeWarning, EventReasonDrainStrategyExecuted, "Executed drain strategy %s: %s", dsName, r.Message)
+					if drainNotifier, ok := ds.notifier.(notifier.DrainNotifier); ok {
+						err = drainNotifier.NotifyDrain(node.Name, dsName, r.Message)
+						if err != nil {
+							logger.Error(err, "Failed to notify the execution of the drain strategy")
 						}
-
 					}
 					res = append(res, &DrainStrategyResult{Message: fmt.Sprintf("Executed %s . Result: %s", drainStrategyMsg, r.Message)})
 				}
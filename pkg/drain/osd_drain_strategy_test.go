@@ -11,11 +11,13 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	emMocks "github.com/openshift/managed-upgrade-operator/pkg/eventmanager/mocks"
 	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
 	mockMachinery "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
-	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
 	mockNotifier "github.com/openshift/managed-upgrade-operator/pkg/notifier/mocks"
 	"github.com/openshift/managed-upgrade-operator/pkg/pod"
 	"github.com/openshift/managed-upgrade-operator/util/mocks"
@@ -49,7 +51,8 @@ var _ = Describe("OSD Drain Strategy", func() {
 		nodeDrainConfig     *NodeDrain
 		mockUpgradeConfig   *upgradev1alpha1.UpgradeConfig
 		mockNotifierClient  *mockNotifier.MockNotifier
-		mockMetricsClient   *mockMetrics.MockMetrics
+		mockEventManager    *emMocks.MockEventManager
+		mockRecorder        *record.FakeRecorder
 	)
 
 	Context("Node drain Time Based Strategy execution", func() {
@@ -63,7 +66,8 @@ var _ = Describe("OSD Drain Strategy", func() {
 			mockStrategyTwo = NewMockDrainStrategy(mockCtrl)
 			logger = logf.Log.WithName("drain strategy test logger")
 			mockNotifierClient = mockNotifier.NewMockNotifier(mockCtrl)
-			mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+			mockEventManager = emMocks.NewMockEventManager(mockCtrl)
+			mockRecorder = record.NewFakeRecorder(10)
 			mockUpgradeConfig = &upgradev1alpha1.UpgradeConfig{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      TEST_UPGRADECONFIG_CR,
@@ -86,12 +90,13 @@ var _ = Describe("OSD Drain Strategy", func() {
 		It("should not error if there are no Strategies", func() {
 			osdDrain = &osdDrainStrategy{
 				mockKubeClient,
+				mockRecorder,
 				mockMachineryClient,
 				&NodeDrain{},
 				[]TimedDrainStrategy{},
 				mockUpgradeConfig,
 				mockNotifierClient,
-				mockMetricsClient,
+				mockEventManager,
 			}
 			fiveMinsAgo := &metav1.Time{Time: time.Now().Add(-5 * time.Minute)}
 			gomock.InOrder(
@@ -105,12 +110,13 @@ var _ = Describe("OSD Drain Strategy", func() {
 		It("should execute a Time Based Drain Strategy after the assigned wait duration", func() {
 			osdDrain = &osdDrainStrategy{
 				mockKubeClient,
+				mockRecorder,
 				mockMachineryClient,
 				&NodeDrain{},
 				[]TimedDrainStrategy{mockTimedDrainOne},
 				mockUpgradeConfig,
 				mockNotifierClient,
-				mockMetricsClient,
+				mockEventManager,
 			}
 			fortyFiveMinsAgo := &metav1.Time{Time: time.Now().Add(-45 * time.Minute)}
 			gomock.InOrder(
@@ -125,15 +131,64 @@ var _ = Describe("OSD Drain Strategy", func() {
 			Expect(err).To(BeNil())
 			Expect(len(result)).To(Equal(1))
 		})
+		It("should send the PDB drain delay notification through the event manager", func() {
+			osdDrain = &osdDrainStrategy{
+				mockKubeClient,
+				mockRecorder,
+				mockMachineryClient,
+				&NodeDrain{},
+				[]TimedDrainStrategy{mockTimedDrainOne},
+				mockUpgradeConfig,
+				mockNotifierClient,
+				mockEventManager,
+			}
+			fortyFiveMinsAgo := &metav1.Time{Time: time.Now().Add(-45 * time.Minute)}
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: fortyFiveMinsAgo}),
+				mockTimedDrainOne.EXPECT().GetName().Return(pdbPodDeleteName),
+				mockTimedDrainOne.EXPECT().GetWaitDuration().Return(time.Minute*30).Times(2),
+				mockTimedDrainOne.EXPECT().GetStrategy().Return(mockStrategyOne),
+				mockStrategyOne.EXPECT().Execute(gomock.Any(), gomock.Any()).Times(1).Return(&DrainStrategyResult{Message: "", HasExecuted: true}, nil),
+				mockEventManager.EXPECT().NotifyResult(notifier.MuoStateDelayed, gomock.Any()).Return(nil),
+			)
+			result, err := osdDrain.Execute(&corev1.Node{}, logger)
+			Expect(err).To(BeNil())
+			Expect(len(result)).To(Equal(1))
+		})
+		It("should not fail the drain when the PDB drain delay notification can't be sent", func() {
+			osdDrain = &osdDrainStrategy{
+				mockKubeClient,
+				mockRecorder,
+				mockMachineryClient,
+				&NodeDrain{},
+				[]TimedDrainStrategy{mockTimedDrainOne},
+				mockUpgradeConfig,
+				mockNotifierClient,
+				mockEventManager,
+			}
+			fortyFiveMinsAgo := &metav1.Time{Time: time.Now().Add(-45 * time.Minute)}
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: fortyFiveMinsAgo}),
+				mockTimedDrainOne.EXPECT().GetName().Return(pdbPodDeleteName),
+				mockTimedDrainOne.EXPECT().GetWaitDuration().Return(time.Minute*30).Times(2),
+				mockTimedDrainOne.EXPECT().GetStrategy().Return(mockStrategyOne),
+				mockStrategyOne.EXPECT().Execute(gomock.Any(), gomock.Any()).Times(1).Return(&DrainStrategyResult{Message: "", HasExecuted: true}, nil),
+				mockEventManager.EXPECT().NotifyResult(notifier.MuoStateDelayed, gomock.Any()).Return(fmt.Errorf("fake error")),
+			)
+			result, err := osdDrain.Execute(&corev1.Node{}, logger)
+			Expect(err).To(BeNil())
+			Expect(len(result)).To(Equal(1))
+		})
 		It("should not execute a Time Based Drain Strategy before the assigned duration", func() {
 			osdDrain = &osdDrainStrategy{
 				mockKubeClient,
+				mockRecorder,
 				mockMachineryClient,
 				&NodeDrain{},
 				[]TimedDrainStrategy{mockTimedDrainOne},
 				mockUpgradeConfig,
 				mockNotifierClient,
-				mockMetricsClient,
+				mockEventManager,
 			}
 			fortyFiveMinsAgo := &metav1.Time{Time: time.Now().Add(-45 * time.Minute)}
 			gomock.InOrder(
@@ -151,12 +206,13 @@ var _ = Describe("OSD Drain Strategy", func() {
 		It("should only execute Time Based Drain Strategy at the correct time if multiple strategies exist", func() {
 			osdDrain = &osdDrainStrategy{
 				mockKubeClient,
+				mockRecorder,
 				mockMachineryClient,
 				&NodeDrain{},
 				[]TimedDrainStrategy{mockTimedDrainOne, mockTimedDrainTwo},
 				mockUpgradeConfig,
 				mockNotifierClient,
-				mockMetricsClient,
+				mockEventManager,
 			}
 			fortyFiveMinsAgo := &metav1.Time{Time: time.Now().Add(-45 * time.Minute)}
 			gomock.InOrder(
@@ -173,16 +229,19 @@ var _ = Describe("OSD Drain Strategy", func() {
 			Expect(result).To(Not(BeNil()))
 			Expect(err).To(BeNil())
 			Expect(len(result)).To(Equal(1))
+			Expect(mockRecorder.Events).To(HaveLen(1))
+			Expect(<-mockRecorder.Events).To(HavePrefix("Warning " + EventReasonDrainStrategyExecuted))
 		})
 		It("should return an error if the node drain time is nil", func() {
 			osdDrain = &osdDrainStrategy{
 				mockKubeClient,
+				mockRecorder,
 				mockMachineryClient,
 				&NodeDrain{},
 				[]TimedDrainStrategy{mockTimedDrainOne},
 				mockUpgradeConfig,
 				mockNotifierClient,
-				mockMetricsClient,
+				mockEventManager,
 			}
 			gomock.InOrder(
 				mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Times(1).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: nil}),
@@ -193,12 +252,13 @@ var _ = Describe("OSD Drain Strategy", func() {
 		It("should return an error if the node drain strategy fails", func() {
 			osdDrain = &osdDrainStrategy{
 				mockKubeClient,
+				mockRecorder,
 				mockMachineryClient,
 				&NodeDrain{},
 				[]TimedDrainStrategy{mockTimedDrainOne},
 				mockUpgradeConfig,
 				mockNotifierClient,
-				mockMetricsClient,
+				mockEventManager,
 			}
 			fortyFiveMinsAgo := &metav1.Time{Time: time.Now().Add(-45 * time.Minute)}
 			gomock.InOrder(
@@ -224,12 +284,13 @@ var _ = Describe("OSD Drain Strategy", func() {
 				}
 				osdDrain = &osdDrainStrategy{
 					mockKubeClient,
+					mockRecorder,
 					mockMachineryClient,
 					nodeDrainConfig,
 					[]TimedDrainStrategy{},
 					mockUpgradeConfig,
 					mockNotifierClient,
-					mockMetricsClient,
+					mockEventManager,
 				}
 			})
 			AfterEach(func() {
@@ -267,12 +328,13 @@ var _ = Describe("OSD Drain Strategy", func() {
 				}
 				osdDrain = &osdDrainStrategy{
 					mockKubeClient,
+					mockRecorder,
 					mockMachineryClient,
 					nodeDrainConfig,
 					[]TimedDrainStrategy{mockTimedDrainTwo, mockTimedDrainOne},
 					mockUpgradeConfig,
 					mockNotifierClient,
-					mockMetricsClient,
+					mockEventManager,
 				}
 			})
 			AfterEach(func() {
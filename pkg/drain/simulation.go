@@ -0,0 +1,288 @@
+package drain
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/pod"
+)
+
+// DrainedPodPredicates returns the predicates matching the pods that are evicted when the node is drained
+func DrainedPodPredicates(node *corev1.Node, ignoredNamespacePatterns []string) []pod.PodPredicate {
+	return []pod.PodPredicate{isOnNode(node), isNotDaemonSet, isAllowedNamespace(ignoredNamespacePatterns), isNotCompleted}
+}
+
+func isNotCompleted(p corev1.Pod) bool {
+	return p.Status.Phase != corev1.PodSucceeded && p.Status.Phase != corev1.PodFailed
+}
+
+// simulatedNode tracks the capacity left on a node while pods are placed on it
+type simulatedNode struct {
+	node      *corev1.Node
+	requested corev1.ResourceList
+	pods      []corev1.Pod
+}
+
+// SimulateNodeDrain simulates draining the node by placing each of the drained pods on the remaining
+// schedulable nodes. Placement honours resource requests, node selectors, required node affinity,
+// taints and required pod anti-affinity. The pods that cannot be placed on any node are returned.
+func SimulateNodeDrain(node *corev1.Node, nodes []corev1.Node, pods []corev1.Pod, ignoredNamespacePatterns []string) []corev1.Pod {
+	var targets []*simulatedNode
+	for i := range nodes {
+		n := &nodes[i]
+		if n.Name == node.Name || n.Spec.Unschedulable || !isNodeReady(n) {
+			continue
+		}
+		targets = append(targets, &simulatedNode{node: n, requested: corev1.ResourceList{}})
+	}
+	for _, p := range pods {
+		if !isNotCompleted(p) {
+			continue
+		}
+		for _, t := range targets {
+			if p.Spec.NodeName == t.node.Name {
+				t.add(p)
+			}
+		}
+	}
+
+	drained := pod.FilterPods(&corev1.PodList{Items: pods}, DrainedPodPredicates(node, ignoredNamespacePatterns)...)
+
+	var unschedulable []corev1.Pod
+	for _, p := range drained.Items {
+		placed := false
+		for _, t := range targets {
+			if t.fits(p, targets) {
+				t.add(p)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			unschedulable = append(unschedulable, p)
+		}
+	}
+	return unschedulable
+}
+
+func (n *simulatedNode) add(p corev1.Pod) {
+	for name, quantity := range podRequests(p) {
+		total := n.requested[name]
+		total.Add(quantity)
+		n.requested[name] = total
+	}
+	n.pods = append(n.pods, p)
+}
+
+func (n *simulatedNode) fits(p corev1.Pod, nodes []*simulatedNode) bool {
+	return n.hasCapacity(p) &&
+		matchesNodeSelector(p, n.node) &&
+		toleratesTaints(p, n.node) &&
+		!violatesAntiAffinity(p, n, nodes)
+}
+
+func (n *simulatedNode) hasCapacity(p corev1.Pod) bool {
+	if podCapacity, ok := n.node.Status.Allocatable[corev1.ResourcePods]; ok && int64(len(n.pods)) >= podCapacity.Value() {
+		return false
+	}
+	for name, quantity := range podRequests(p) {
+		allocatable, ok := n.node.Status.Allocatable[name]
+		if !ok {
+			return false
+		}
+		total := n.requested[name]
+		total.Add(quantity)
+		if total.Cmp(allocatable) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// podRequests returns the resources requested by the pod, which is the larger of the sum of
+// the container requests and the largest init container request
+func podRequests(p corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, c := range p.Spec.Containers {
+		for name, quantity := range c.Resources.Requests {
+			total := requests[name]
+			total.Add(quantity)
+			requests[name] = total
+		}
+	}
+	for _, c := range p.Spec.InitContainers {
+		for name, quantity := range c.Resources.Requests {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	for name, quantity := range p.Spec.Overhead {
+		total := requests[name]
+		total.Add(quantity)
+		requests[name] = total
+	}
+	for name, quantity := range requests {
+		if quantity.IsZero() {
+			delete(requests, name)
+		}
+	}
+	return requests
+}
+
+func matchesNodeSelector(p corev1.Pod, node *corev1.Node) bool {
+	if !labels.SelectorFromSet(p.Spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+	if p.Spec.Affinity == nil || p.Spec.Affinity.NodeAffinity == nil || p.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true
+	}
+	// Node selector terms are ORed
+	for _, term := range p.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		if matchesNodeSelectorTerm(term, node) {
+			return true
+		}
+	}
+	return false
+}
+
+var nodeSelectorOperators = map[corev1.NodeSelectorOperator]selection.Operator{
+	corev1.NodeSelectorOpIn:           selection.In,
+	corev1.NodeSelectorOpNotIn:        selection.NotIn,
+	corev1.NodeSelectorOpExists:       selection.Exists,
+	corev1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+	corev1.NodeSelectorOpGt:           selection.GreaterThan,
+	corev1.NodeSelectorOpLt:           selection.LessThan,
+}
+
+func matchesNodeSelectorTerm(term corev1.NodeSelectorTerm, node *corev1.Node) bool {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return false
+	}
+	selector := labels.NewSelector()
+	for _, expr := range term.MatchExpressions {
+		r, err := labels.NewRequirement(expr.Key, nodeSelectorOperators[expr.Operator], expr.Values)
+		if err != nil {
+			return false
+		}
+		selector = selector.Add(*r)
+	}
+	for _, field := range term.MatchFields {
+		// metadata.name is the only supported field selector for nodes
+		if field.Key != metav1.ObjectNameField {
+			return false
+		}
+		r, err := labels.NewRequirement(field.Key, nodeSelectorOperators[field.Operator], field.Values)
+		if err != nil || !r.Matches(labels.Set{metav1.ObjectNameField: node.Name}) {
+			return false
+		}
+	}
+	return selector.Matches(labels.Set(node.Labels))
+}
+
+func toleratesTaints(p corev1.Pod, node *corev1.Node) bool {
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect != corev1.TaintEffectNoSchedule && taint.Effect != corev1.TaintEffectNoExecute {
+			continue
+		}
+		tolerated := false
+		for _, toleration := range p.Spec.Tolerations {
+			if toleratesTaint(toleration, taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+func toleratesTaint(toleration corev1.Toleration, taint corev1.Taint) bool {
+	if toleration.Effect != "" && toleration.Effect != taint.Effect {
+		return false
+	}
+	// An empty key with the Exists operator tolerates every taint
+	if toleration.Key != "" && toleration.Key != taint.Key {
+		return false
+	}
+	switch toleration.Operator {
+	case corev1.TolerationOpExists:
+		return true
+	case "", corev1.TolerationOpEqual:
+		return toleration.Value == taint.Value
+	}
+	return false
+}
+
+// violatesAntiAffinity returns true if placing the pod on the node would co-locate it with a pod
+// matched by one of its required anti-affinity terms in the same topology domain, or with a pod
+// whose required anti-affinity terms match it
+func violatesAntiAffinity(p corev1.Pod, target *simulatedNode, nodes []*simulatedNode) bool {
+	for _, n := range nodes {
+		for _, existing := range n.pods {
+			if existing.Namespace == p.Namespace && existing.Name == p.Name {
+				continue
+			}
+			for _, term := range requiredAntiAffinityTerms(p) {
+				if inTopologyDomain(target, n, term.TopologyKey) && antiAffinityTermMatches(p, term, existing) {
+					return true
+				}
+			}
+			for _, term := range requiredAntiAffinityTerms(existing) {
+				if inTopologyDomain(target, n, term.TopologyKey) && antiAffinityTermMatches(existing, term, p) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// requiredAntiAffinityTerms returns the required anti-affinity terms of the pod
+func requiredAntiAffinityTerms(p corev1.Pod) []corev1.PodAffinityTerm {
+	if p.Spec.Affinity == nil || p.Spec.Affinity.PodAntiAffinity == nil {
+		return nil
+	}
+	return p.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+}
+
+// inTopologyDomain returns true if the node is in the topology domain of the target node
+func inTopologyDomain(target *simulatedNode, n *simulatedNode, topologyKey string) bool {
+	domain, ok := target.node.Labels[topologyKey]
+	if !ok {
+		return false
+	}
+	return n.node.Labels[topologyKey] == domain
+}
+
+// antiAffinityTermMatches returns true if the anti-affinity term of the owner pod matches the other pod
+func antiAffinityTermMatches(owner corev1.Pod, term corev1.PodAffinityTerm, other corev1.Pod) bool {
+	selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+	if err != nil {
+		return true
+	}
+	// A namespace selector is treated as matching every namespace
+	namespaces := term.Namespaces
+	if len(namespaces) == 0 && term.NamespaceSelector == nil {
+		namespaces = []string{owner.Namespace}
+	}
+	if len(namespaces) > 0 && !slices.Contains(namespaces, other.Namespace) {
+		return false
+	}
+	return selector.Matches(labels.Set(other.Labels))
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
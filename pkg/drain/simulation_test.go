@@ -0,0 +1,187 @@
+package drain
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Drain simulation", func() {
+
+	var (
+		nodes []corev1.Node
+		pods  []corev1.Pod
+	)
+
+	newNode := func(name string, cpu string, nodeLabels map[string]string) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse("8Gi"),
+					corev1.ResourcePods:   resource.MustParse("250"),
+				},
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+
+	newPod := func(name string, nodeName string, cpu string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace", Labels: map[string]string{"app": name}},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{
+					{
+						Name: "main",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+						},
+					},
+				},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+
+	names := func(pods []corev1.Pod) []string {
+		result := []string{}
+		for _, p := range pods {
+			result = append(result, p.Name)
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		nodes = []corev1.Node{
+			newNode("worker-a", "4", map[string]string{"zone": "a"}),
+			newNode("worker-b", "4", map[string]string{"zone": "b"}),
+		}
+		pods = []corev1.Pod{
+			newPod("drained", "worker-a", "1"),
+			newPod("existing", "worker-b", "2"),
+		}
+	})
+
+	Context("When the remaining nodes have enough capacity", func() {
+		It("reports no unschedulable pods", func() {
+			Expect(SimulateNodeDrain(&nodes[0], nodes, pods, nil)).To(BeEmpty())
+		})
+	})
+
+	Context("When the remaining nodes do not have enough capacity", func() {
+		It("reports the pods that do not fit", func() {
+			pods = append(pods, newPod("large", "worker-a", "2"))
+			Expect(names(SimulateNodeDrain(&nodes[0], nodes, pods, nil))).To(Equal([]string{"large"}))
+		})
+	})
+
+	Context("When the remaining nodes are cordoned or not ready", func() {
+		It("does not place pods on them", func() {
+			nodes[1].Spec.Unschedulable = true
+			Expect(names(SimulateNodeDrain(&nodes[0], nodes, pods, nil))).To(Equal([]string{"drained"}))
+		})
+		It("does not place pods on not ready nodes", func() {
+			nodes[1].Status.Conditions[0].Status = corev1.ConditionFalse
+			Expect(names(SimulateNodeDrain(&nodes[0], nodes, pods, nil))).To(Equal([]string{"drained"}))
+		})
+	})
+
+	Context("When pods are not evicted by a drain", func() {
+		It("ignores DaemonSet, completed and ignored namespace pods", func() {
+			daemonSet := newPod("daemonset", "worker-a", "8")
+			daemonSet.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "ds"}}
+			completed := newPod("completed", "worker-a", "8")
+			completed.Status.Phase = corev1.PodSucceeded
+			ignored := newPod("ignored", "worker-a", "8")
+			ignored.Namespace = "ignored-namespace"
+			pods = append(pods, daemonSet, completed, ignored)
+			Expect(SimulateNodeDrain(&nodes[0], nodes, pods, []string{"ignored-.*"})).To(BeEmpty())
+		})
+	})
+
+	Context("When a pod has a node selector", func() {
+		It("reports the pod when no other node matches", func() {
+			pods[0].Spec.NodeSelector = map[string]string{"zone": "a"}
+			Expect(names(SimulateNodeDrain(&nodes[0], nodes, pods, nil))).To(Equal([]string{"drained"}))
+		})
+		It("reports the pod when no other node matches the required node affinity", func() {
+			pods[0].Spec.Affinity = &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "zone", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"b"}}}},
+						},
+					},
+				},
+			}
+			Expect(names(SimulateNodeDrain(&nodes[0], nodes, pods, nil))).To(Equal([]string{"drained"}))
+		})
+	})
+
+	Context("When the remaining nodes are tainted", func() {
+		BeforeEach(func() {
+			nodes[1].Spec.Taints = []corev1.Taint{{Key: "dedicated", Value: "infra", Effect: corev1.TaintEffectNoSchedule}}
+		})
+		It("reports pods that do not tolerate the taint", func() {
+			Expect(names(SimulateNodeDrain(&nodes[0], nodes, pods, nil))).To(Equal([]string{"drained"}))
+		})
+		It("places pods that tolerate the taint", func() {
+			pods[0].Spec.Tolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "infra", Effect: corev1.TaintEffectNoSchedule}}
+			Expect(SimulateNodeDrain(&nodes[0], nodes, pods, nil)).To(BeEmpty())
+		})
+	})
+
+	Context("When a pod has required anti-affinity", func() {
+		It("reports the pod when its replica already runs on the remaining nodes", func() {
+			replica := newPod("replica", "worker-b", "1")
+			replica.Labels = map[string]string{"app": "drained"}
+			pods = append(pods, replica)
+			pods[0].Spec.Affinity = &corev1.Affinity{
+				PodAntiAffinity: &corev1.PodAntiAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+						{
+							LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "drained"}},
+							TopologyKey:   "kubernetes.io/hostname",
+						},
+					},
+				},
+			}
+			nodes[0].Labels["kubernetes.io/hostname"] = "worker-a"
+			nodes[1].Labels["kubernetes.io/hostname"] = "worker-b"
+			Expect(names(SimulateNodeDrain(&nodes[0], nodes, pods, nil))).To(Equal([]string{"drained"}))
+		})
+
+		It("reports the pod when a pod on the remaining nodes has required anti-affinity to it", func() {
+			pods[1].Spec.Affinity = &corev1.Affinity{
+				PodAntiAffinity: &corev1.PodAntiAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+						{
+							LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "drained"}},
+							TopologyKey:   "zone",
+						},
+					},
+				},
+			}
+			Expect(names(SimulateNodeDrain(&nodes[0], nodes, pods, nil))).To(Equal([]string{"drained"}))
+		})
+
+		It("places the pod when the anti-affinity of the pods on the remaining nodes does not match it", func() {
+			pods[1].Spec.Affinity = &corev1.Affinity{
+				PodAntiAffinity: &corev1.PodAntiAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+						{
+							LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}},
+							TopologyKey:   "zone",
+						},
+					},
+				},
+			}
+			Expect(SimulateNodeDrain(&nodes[0], nodes, pods, nil)).To(BeEmpty())
+		})
+	})
+})
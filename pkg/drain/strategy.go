@@ -7,13 +7,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/go-logr/logr"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
 	"github.com/openshift/managed-upgrade-operator/pkg/configmanager"
-	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/pkg/eventmanager"
 	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
 	"github.com/openshift/managed-upgrade-operator/pkg/pod"
 	"github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
@@ -23,8 +24,8 @@ import (
 //
 //go:generate mockgen -destination=mocks/nodeDrainStrategyBuilder.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/drain NodeDrainStrategyBuilder
 type NodeDrainStrategyBuilder interface {
-	NewNodeDrainStrategy(c client.Client, logger logr.Logger, uc *upgradev1alpha1.UpgradeConfig, cfg *NodeDrain) (NodeDrainStrategy, error)
-	NewDefaultNodeDrainStrategy(c client.Client, logger logr.Logger, uc *upgradev1alpha1.UpgradeConfig, cfg *NodeDrain) (NodeDrainStrategy, error)
+	NewNodeDrainStrategy(c client.Client, recorder record.EventRecorder, logger logr.Logger, uc *upgradev1alpha1.UpgradeConfig, cfg *NodeDrain) (NodeDrainStrategy, error)
+	NewDefaultNodeDrainStrategy(c client.Client, recorder record.EventRecorder, logger logr.Logger, uc *upgradev1alpha1.UpgradeConfig, cfg *NodeDrain) (NodeDrainStrategy, error)
 }
 
 // NodeDrainStrategy enables implementation for a NodeDrainStrategy
@@ -70,7 +71,7 @@ func newTimedStrategy(name string, description string, waitDuration time.Duratio
 }
 
 // NewNodeDrainStrategy returns a NodeDrainStrategy
-func (dsb *drainStrategyBuilder) NewNodeDrainStrategy(c client.Client, logger logr.Logger, uc *upgradev1alpha1.UpgradeConfig, cfg *NodeDrain) (NodeDrainStrategy, error) {
+func (dsb *drainStrategyBuilder) NewNodeDrainStrategy(c client.Client, recorder record.EventRecorder, logger logr.Logger, uc *upgradev1alpha1.UpgradeConfig, cfg *NodeDrain) (NodeDrainStrategy, error) {
 	pdbList := &policyv1.PodDisruptionBudgetList{}
 	err := c.List(context.TODO(), pdbList)
 	if err != nil {
@@ -83,12 +84,11 @@ func (dsb *drainStrategyBuilder) NewNodeDrainStrategy(c client.Client, logger lo
 	cmBuilder := configmanager.NewBuilder()
 	ucb := upgradeconfigmanager.NewBuilder()
 	// Notification Client Build
-	notifier, err := notifier.NewBuilder().New(c, cmBuilder, ucb)
+	notifier, err := notifier.NewBuilder().New(c, cmBuilder, ucb, recorder)
 	if err != nil {
 		return nil, err
 	}
-	// Metrics Client Build
-	metricsClient, err := metrics.NewBuilder().NewClient(c)
+	eventManager, err := eventmanager.NewBuilder().NewManager(c, recorder)
 	if err != nil {
 		return nil, err
 	}
@@ -121,28 +121,27 @@ func (dsb *drainStrategyBuilder) NewNodeDrainStrategy(c client.Client, logger lo
 		}),
 	}
 
-	return NewNodeDrainStrategy(c, cfg, ts, uc, notifier, metricsClient)
+	return NewNodeDrainStrategy(c, recorder, cfg, ts, uc, notifier, eventManager)
 }
 
 // NewDefaultNodeDrainStrategy returns a NodeDrainStrategy without any timed strategy
-func (dsb *drainStrategyBuilder) NewDefaultNodeDrainStrategy(c client.Client, logger logr.Logger, uc *upgradev1alpha1.UpgradeConfig, cfg *NodeDrain) (NodeDrainStrategy, error) {
+func (dsb *drainStrategyBuilder) NewDefaultNodeDrainStrategy(c client.Client, recorder record.EventRecorder, logger logr.Logger, uc *upgradev1alpha1.UpgradeConfig, cfg *NodeDrain) (NodeDrainStrategy, error) {
 
 	cmBuilder := configmanager.NewBuilder()
 	ucb := upgradeconfigmanager.NewBuilder()
 	// Notification Client Build
-	notifier, err := notifier.NewBuilder().New(c, cmBuilder, ucb)
+	notifier, err := notifier.NewBuilder().New(c, cmBuilder, ucb, recorder)
 	if err != nil {
 		return nil, err
 	}
-	// Metrics Client Build
-	metricsClient, err := metrics.NewBuilder().NewClient(c)
+	eventManager, err := eventmanager.NewBuilder().NewManager(c, recorder)
 	if err != nil {
 		return nil, err
 	}
 
 	ts := []TimedDrainStrategy{}
 
-	return NewNodeDrainStrategy(c, cfg, ts, uc, notifier, metricsClient)
+	return NewNodeDrainStrategy(c, recorder, cfg, ts, uc, notifier, eventManager)
 }
 
 // DrainStrategyResult holds fields illustrating a drain strategies result
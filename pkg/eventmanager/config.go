@@ -0,0 +1,223 @@
+package eventmanager
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/config"
+	"github.com/openshift/managed-upgrade-operator/pkg/configmanager"
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
+)
+
+// NotificationTemplatesConfig holds the NotificationTemplates field for its notification text configuration
+type NotificationTemplatesConfig struct {
+	// NotificationTemplates override the description of the notifications per notified state
+	NotificationTemplates map[notifier.MuoState]string `yaml:"notificationTemplates"`
+}
+
+// UpgradeRemindersConfig holds the UpgradeReminders field for its upcoming upgrade reminders configuration
+type UpgradeRemindersConfig struct {
+	UpgradeReminders UpgradeReminders `yaml:"upgradeReminders"`
+}
+
+// UpgradeReminders holds the configuration of the reminders sent while an upgrade is pending
+type UpgradeReminders struct {
+	// Offsets before the upgrade time a reminder is sent at, as durations e.g. 72h
+	Offsets []string `yaml:"offsets"`
+}
+
+// IsValid returns a nil error when the UpgradeRemindersConfig is valid
+func (cfg *UpgradeRemindersConfig) IsValid() error {
+	_, err := cfg.GetOffsets()
+	return err
+}
+
+// GetOffsets returns the reminder offsets, largest first
+func (cfg *UpgradeRemindersConfig) GetOffsets() ([]time.Duration, error) {
+	offsets := []time.Duration{}
+	for _, o := range cfg.UpgradeReminders.Offsets {
+		offset, err := time.ParseDuration(o)
+		if err != nil {
+			return nil, fmt.Errorf("upgrade reminder offset %q is invalid: %v", o, err)
+		}
+		if offset <= 0 {
+			return nil, fmt.Errorf("upgrade reminder offset %q must be positive", o)
+		}
+		offsets = append(offsets, offset)
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] > offsets[j] })
+	return offsets, nil
+}
+
+// DescriptionData is the data the notification templates are rendered with
+type DescriptionData struct {
+	// UpgradeConfig is the UpgradeConfig being upgraded to
+	UpgradeConfig *v1alpha1.UpgradeConfig
+	// History is the history of the upgrade to the desired version, if any
+	History *v1alpha1.UpgradeHistory
+	// Version is the desired version of the upgrade
+	Version string
+	// ClusterID is the ID of the cluster
+	ClusterID string
+	// HealthChecks is the latest result of each health check run for the upgrade
+	HealthChecks v1alpha1.HealthCheckReports
+	// FailingHealthChecks lists the failing health checks of the health check notifications
+	FailingHealthChecks string
+	// Description is the description the notification has without template
+	Description string
+}
+
+// templateFuncs are the functions available to the notification templates
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// invalidTemplatesError reports the notification templates that are invalid
+type invalidTemplatesError struct {
+	errs *multierror.Error
+}
+
+func (e *invalidTemplatesError) Error() string {
+	return e.errs.Error()
+}
+
+// IsValid returns a nil error when the NotificationTemplatesConfig is valid. The templates
+// are rendered with sample data so that references to unknown fields are reported at load.
+func (cfg *NotificationTemplatesConfig) IsValid() error {
+	_, err := cfg.GetTemplates()
+	return err
+}
+
+// GetTemplates returns the parsed notification templates. The invalid templates are left out
+// of the returned templates and reported by the returned error.
+func (cfg *NotificationTemplatesConfig) GetTemplates() (map[notifier.MuoState]*template.Template, error) {
+	templates := map[notifier.MuoState]*template.Template{}
+	var invalid *multierror.Error
+	for state, text := range cfg.NotificationTemplates {
+		if !state.IsValid() {
+			invalid = multierror.Append(invalid, fmt.Errorf("notification template for unknown state %q", state))
+			continue
+		}
+		tmpl, err := template.New(string(state)).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+		if err != nil {
+			invalid = multierror.Append(invalid, fmt.Errorf("notification template for %s is invalid: %v", state, err))
+			continue
+		}
+		if _, err := renderDescription(tmpl, sampleDescriptionData()); err != nil {
+			invalid = multierror.Append(invalid, fmt.Errorf("notification template for %s is invalid: %v", state, err))
+			continue
+		}
+		templates[state] = tmpl
+	}
+	if invalid != nil {
+		return templates, &invalidTemplatesError{invalid}
+	}
+	return templates, nil
+}
+
+// renderDescription renders a notification template
+func renderDescription(tmpl *template.Template, data DescriptionData) (string, error) {
+	description := &strings.Builder{}
+	err := tmpl.Execute(description, data)
+	if err != nil {
+		return "", err
+	}
+	return description.String(), nil
+}
+
+// sampleDescriptionData returns the data the templates are validated with
+func sampleDescriptionData() DescriptionData {
+	now := metav1.Time{Time: time.Now()}
+	history := v1alpha1.UpgradeHistory{
+		Version:          "4.14.2",
+		PrecedingVersion: "4.14.1",
+		Phase:            v1alpha1.UpgradePhaseUpgrading,
+		StartTime:        &now,
+		Conditions: v1alpha1.Conditions{
+			{
+				Type:      v1alpha1.UpgradePreHealthCheck,
+				Status:    "False",
+				Reason:    "PreHealthCheck not done",
+				Message:   "Critical alerts are firing",
+				StartTime: &now,
+			},
+		},
+		HealthChecks: v1alpha1.HealthCheckReports{
+			{
+				Name:            "CriticalAlerts",
+				Result:          v1alpha1.HealthCheckFailed,
+				AffectedObjects: []string{"KubeAPIErrorBudgetBurn"},
+				Message:         "Critical alerts are firing",
+				FirstSeen:       &now,
+				LastSeen:        &now,
+			},
+		},
+	}
+	uc := &v1alpha1.UpgradeConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "managed-upgrade-config"},
+		Spec: v1alpha1.UpgradeConfigSpec{
+			Desired:   v1alpha1.Update{Version: "4.14.2", Channel: "stable-4.14"},
+			UpgradeAt: now.Format(time.RFC3339),
+			Type:      v1alpha1.OSD,
+		},
+		Status: v1alpha1.UpgradeConfigStatus{History: v1alpha1.UpgradeHistories{history}},
+	}
+	return DescriptionData{
+		UpgradeConfig:       uc,
+		History:             &uc.Status.History[0],
+		Version:             "4.14.2",
+		ClusterID:           "00000000-0000-0000-0000-000000000000",
+		HealthChecks:        history.HealthChecks,
+		FailingHealthChecks: "CriticalAlerts",
+		Description:         "Cluster is currently being upgraded to version 4.14.2",
+	}
+}
+
+// Read notification templates configuration
+func readTemplatesConfig(client client.Client, cfb configmanager.ConfigManagerBuilder) (*NotificationTemplatesConfig, error) {
+	cfg := &NotificationTemplatesConfig{}
+
+	target := config.CMTarget{}
+	cmTarget, err := target.NewCMTarget()
+	if err != nil {
+		return cfg, err
+	}
+
+	cfm := cfb.New(client, cmTarget)
+	err = cfm.Into(cfg)
+	// The invalid templates are dropped alone when the templates are parsed
+	var invalid *invalidTemplatesError
+	if errors.As(err, &invalid) {
+		return cfg, nil
+	}
+	return cfg, err
+}
+
+// Read upgrade reminders configuration
+func readRemindersConfig(client client.Client, cfb configmanager.ConfigManagerBuilder) (*UpgradeRemindersConfig, error) {
+	cfg := &UpgradeRemindersConfig{}
+
+	target := config.CMTarget{}
+	cmTarget, err := target.NewCMTarget()
+	if err != nil {
+		return cfg, err
+	}
+
+	cfm := cfb.New(client, cmTarget)
+	err = cfm.Into(cfg)
+	if err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.IsValid()
+}
@@ -0,0 +1,88 @@
+package eventmanager
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
+)
+
+var _ = Describe("Notification templates config", func() {
+	var cfg NotificationTemplatesConfig
+
+	BeforeEach(func() {
+		cfg = NotificationTemplatesConfig{}
+	})
+
+	It("is valid without templates", func() {
+		Expect(cfg.IsValid()).To(Succeed())
+	})
+
+	It("accepts templates using the description data", func() {
+		cfg.NotificationTemplates = map[notifier.MuoState]string{
+			notifier.MuoStateFailed: `Upgrade of {{ .ClusterID }} to {{ .UpgradeConfig.Spec.Desired.Version }} failed in phase {{ .History.Phase }}.` +
+				`{{ range .HealthChecks }} {{ .Name }} {{ .Result }}{{ end }} {{ .FailingHealthChecks | lower }}`,
+		}
+		Expect(cfg.IsValid()).To(Succeed())
+	})
+
+	It("rejects templates of unknown states", func() {
+		cfg.NotificationTemplates = map[notifier.MuoState]string{"StateExploded": "boom"}
+		Expect(cfg.IsValid()).NotTo(Succeed())
+	})
+
+	It("rejects templates that do not parse", func() {
+		cfg.NotificationTemplates = map[notifier.MuoState]string{notifier.MuoStateStarted: "{{ .Version }"}
+		Expect(cfg.IsValid()).NotTo(Succeed())
+	})
+
+	It("rejects templates referencing unknown fields", func() {
+		cfg.NotificationTemplates = map[notifier.MuoState]string{notifier.MuoStateStarted: "{{ .ClusterName }}"}
+		Expect(cfg.IsValid()).NotTo(Succeed())
+	})
+
+	It("drops the invalid templates only", func() {
+		cfg.NotificationTemplates = map[notifier.MuoState]string{
+			notifier.MuoStateStarted:   "{{ .ClusterName }}",
+			notifier.MuoStateCompleted: "{{ .Description }}",
+		}
+		templates, err := cfg.GetTemplates()
+		Expect(err).To(HaveOccurred())
+		Expect(templates).To(HaveLen(1))
+		Expect(templates).To(HaveKey(notifier.MuoStateCompleted))
+	})
+})
+
+var _ = Describe("Upgrade reminders config", func() {
+	var cfg UpgradeRemindersConfig
+
+	BeforeEach(func() {
+		cfg = UpgradeRemindersConfig{}
+	})
+
+	It("is valid without reminders", func() {
+		Expect(cfg.IsValid()).To(Succeed())
+		offsets, err := cfg.GetOffsets()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(offsets).To(BeEmpty())
+	})
+
+	It("returns the offsets largest first", func() {
+		cfg.UpgradeReminders.Offsets = []string{"1h", "72h", "24h"}
+		offsets, err := cfg.GetOffsets()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(offsets).To(Equal([]time.Duration{72 * time.Hour, 24 * time.Hour, time.Hour}))
+	})
+
+	It("rejects offsets that are not durations", func() {
+		cfg.UpgradeReminders.Offsets = []string{"3 days"}
+		Expect(cfg.IsValid()).NotTo(Succeed())
+	})
+
+	It("rejects offsets that are not positive", func() {
+		cfg.UpgradeReminders.Offsets = []string{"0s"}
+		Expect(cfg.IsValid()).NotTo(Succeed())
+	})
+})
@@ -2,15 +2,23 @@ package eventmanager
 
 import (
 	"fmt"
+	"strings"
+	"text/template"
+	"time"
 
 	"github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
 	"github.com/openshift/managed-upgrade-operator/pkg/configmanager"
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
 	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
 	"github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+var log = logf.Log.WithName("eventmanager")
+
 const (
 	// Failed and Skipped descriptions
 
@@ -50,6 +58,10 @@ const (
 	UPGRADE_CONTROL_PLANE_FINISHED_DESC = "Cluster upgrade to version %s has finished control plane upgrade. This is an informational notification and no action is required"
 	// UPGRADE_WORKER_PLANE_FINISHED_DESC describes the worker plane upgrade finished
 	UPGRADE_WORKER_PLANE_FINISHED_DESC = "Cluster upgrade to version %s has finished worker plane upgrade. This is an informational notification and no action is required."
+	// UPGRADE_REMINDER_DESC describes the upcoming upgrade
+	UPGRADE_REMINDER_DESC = "Cluster upgrade to version %s is scheduled to start in %s, at %s. This is an informational notification and no action is required"
+	// UPGRADE_REMINDER_HEALTHCHECK_DESC describes the upcoming upgrade and the pre-upgrade health check failures
+	UPGRADE_REMINDER_HEALTHCHECK_DESC = "Cluster upgrade to version %s is scheduled to start in %s, at %s. The latest pre-upgrade health check has identified the following points which may impact the upgrade process: %s. Please take actions to review and fix the issues before the upgrade begins to have seamless upgrade experience"
 )
 
 // EventManager enables implementation of an EventManager
@@ -58,13 +70,15 @@ const (
 type EventManager interface {
 	Notify(state notifier.MuoState) error
 	NotifyResult(state notifier.MuoState, result string) error
+	NotifyReminder() error
+	SendQueued() error
 }
 
 // EventManagerBuilder enables implementation of an EventManagerBuilder
 //
 //go:generate mockgen -destination=mocks/eventmanager_builder.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/eventmanager EventManagerBuilder
 type EventManagerBuilder interface {
-	NewManager(client.Client) (EventManager, error)
+	NewManager(client.Client, record.EventRecorder) (EventManager, error)
 }
 
 // NewBuilder returns an eventManagerBuilder
@@ -80,9 +94,18 @@ type eventManager struct {
 	metrics              metrics.Metrics
 	upgradeConfigManager upgradeconfigmanager.UpgradeConfigManager
 	configManagerBuilder configmanager.ConfigManagerBuilder
+	store                notifier.NotificationStore
+	// Queues the notifications that can't be sent
+	outbox notifier.NotificationOutbox
+	// Templates overriding the notification descriptions per state
+	templates map[notifier.MuoState]*template.Template
+	// Retrieves the cluster ID for the templates
+	cvClient cv.ClusterVersion
+	// Offsets before the upgrade time the upcoming upgrade is reminded at, largest first
+	reminders []time.Duration
 }
 
-func (emb *eventManagerBuilder) NewManager(client client.Client) (EventManager, error) {
+func (emb *eventManagerBuilder) NewManager(client client.Client, recorder record.EventRecorder) (EventManager, error) {
 	cmBuilder := configmanager.NewBuilder()
 	ucb := upgradeconfigmanager.NewBuilder()
 	ucm, err := upgradeconfigmanager.NewBuilder().NewManager(client)
@@ -93,20 +116,57 @@ func (emb *eventManagerBuilder) NewManager(client client.Client) (EventManager,
 	if err != nil {
 		return nil, err
 	}
-	notifier, err := notifier.NewBuilder().New(client, cmBuilder, ucb)
+	templatesCfg, err := readTemplatesConfig(client, cmBuilder)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := templatesCfg.GetTemplates()
+	if err != nil {
+		log.Error(err, "dropping the invalid notification templates, the default descriptions are sent for their states")
+	}
+	remindersCfg, err := readRemindersConfig(client, cmBuilder)
 	if err != nil {
 		return nil, err
 	}
+	reminders, err := remindersCfg.GetOffsets()
+	if err != nil {
+		return nil, err
+	}
+	store := notifier.NewNotificationStore(client)
+	outbox := notifier.NewNotificationOutbox(client)
+	n, err := notifier.NewBuilder().New(client, cmBuilder, ucb, recorder)
+	if err != nil {
+		return nil, err
+	}
+	updateNotifierMetrics(metricsClient, n)
 
 	return &eventManager{
 		client:               client,
 		upgradeConfigManager: ucm,
 		metrics:              metricsClient,
-		notifier:             notifier,
+		notifier:             n,
 		configManagerBuilder: cmBuilder,
+		store:                store,
+		outbox:               outbox,
+		templates:            templates,
+		cvClient:             cv.NewBuilder().New(client),
+		reminders:            reminders,
 	}, nil
 }
 
+// updateNotifierMetrics reports the optional notifiers left out as they can't be configured
+func updateNotifierMetrics(metricsClient metrics.Metrics, n notifier.Notifier) {
+	disabled := map[notifier.NotifierDestination]bool{}
+	if routed, ok := n.(notifier.RoutedNotifier); ok {
+		for _, destination := range routed.DisabledDestinations() {
+			disabled[destination] = true
+		}
+	}
+	for _, destination := range notifier.OptionalDestinations {
+		metricsClient.UpdateMetricNotifierDisabled(string(destination), disabled[destination])
+	}
+}
+
 func (s *eventManager) Notify(state notifier.MuoState) error {
 	// Get the current UpgradeConfig
 	uc, err := s.upgradeConfigManager.Get()
@@ -118,11 +178,13 @@ func (s *eventManager) Notify(state notifier.MuoState) error {
 	}
 
 	// Check if a notification for it has been sent successfully - if so, nothing to do
-	isNotified, err := s.metrics.IsMetricNotificationEventSentSet(uc.Name, string(state), uc.Spec.Desired.Version)
+	isNotified, err := s.store.IsSent(uc.Spec.Desired.Version, state)
 	if err != nil {
-		return fmt.Errorf("can't check cluster metric NotificationSent: %v", err)
+		return fmt.Errorf("can't check notification store: %v", err)
 	}
 	if isNotified {
+		// Keep the metric in line with the store, which outlives operator restarts
+		s.metrics.UpdateMetricNotificationEventSent(uc.Name, string(state), uc.Spec.Desired.Version)
 		return nil
 	}
 
@@ -150,19 +212,13 @@ func (s *eventManager) Notify(state notifier.MuoState) error {
 	default:
 		return fmt.Errorf("state %v not yet implemented", state)
 	}
+	description = s.templateDescription(state, uc, description, "")
 
-	// Send the notification
-	err = s.notifier.NotifyState(state, description)
-	if err != nil {
-		s.metrics.UpdatemetricUpgradeNotificationFailed(uc.Name, string(state))
-		return fmt.Errorf("can't send notification '%s': %v", state, err)
-	}
-	s.metrics.UpdatemetricUpgradeNotificationSucceeded(uc.Name, string(state))
-	s.metrics.UpdateMetricNotificationEventSent(uc.Name, string(state), uc.Spec.Desired.Version)
-
-	return nil
+	return s.send(uc, state, description)
 }
 
+// NotifyResult notifies the state with the result that caused it, which is the failing health checks
+// of the health check states or the description of the delay of the delayed state
 func (s *eventManager) NotifyResult(state notifier.MuoState, result string) error {
 	// Get the current UpgradeConfig
 	uc, err := s.upgradeConfigManager.Get()
@@ -174,35 +230,294 @@ func (s *eventManager) NotifyResult(state notifier.MuoState, result string) erro
 	}
 
 	// Check if a notification for it has been sent successfully - if so, nothing to do
-	isNotified, err := s.metrics.IsMetricNotificationEventSentSet(uc.Name, string(state), uc.Spec.Desired.Version)
+	isNotified, err := s.store.IsSent(uc.Spec.Desired.Version, state)
 	if err != nil {
-		return fmt.Errorf("can't check cluster metric NotificationSent: %v", err)
+		return fmt.Errorf("can't check notification store: %v", err)
 	}
 	if isNotified {
+		// Keep the metric in line with the store, which outlives operator restarts
+		s.metrics.UpdateMetricNotificationEventSent(uc.Name, string(state), uc.Spec.Desired.Version)
 		return nil
 	}
 
 	// Customize the state description
-	var description string
+	var description, failingHealthChecks string
 	switch state {
 	case notifier.MuoStateHealthCheckSL:
 		description = fmt.Sprintf(UPGRADE_HEALTHCHECK_DELAY_DESC, uc.Spec.Desired.Version, result)
+		failingHealthChecks = result
 	case notifier.MuoStatePreHealthCheckSL:
 		description = fmt.Sprintf(UPGRADE_PREHEALTHCHECK_WARNING_DESC, uc.Spec.Desired.Version, result)
+		failingHealthChecks = result
+	case notifier.MuoStateDelayed:
+		// The result describes the cause of the delay
+		description = result
 	default:
 		return fmt.Errorf("state %v not yet implemented", state)
 	}
+	description = s.templateDescription(state, uc, description, failingHealthChecks)
 
-	// Send the notification
-	err = s.notifier.NotifyState(state, description)
+	return s.send(uc, state, description)
+}
+
+// NotifyReminder reminds of the upcoming upgrade once each configured offset before the upgrade
+// time is reached. Only the reminder of the latest offset reached is sent, so that a reminder
+// is not sent for each offset already past when the upgrade is scheduled close to its time.
+func (s *eventManager) NotifyReminder() error {
+	if len(s.reminders) == 0 {
+		return nil
+	}
+
+	// Get the current UpgradeConfig
+	uc, err := s.upgradeConfigManager.Get()
 	if err != nil {
-		return fmt.Errorf("can't send notification '%s': %v", state, err)
+		if err == upgradeconfigmanager.ErrUpgradeConfigNotFound {
+			return nil
+		}
+		return fmt.Errorf("unable to find UpgradeConfig: %v", err)
+	}
+	upgradeAt, err := time.Parse(time.RFC3339, uc.Spec.UpgradeAt)
+	if err != nil {
+		return fmt.Errorf("can't parse upgradeAt time %q: %v", uc.Spec.UpgradeAt, err)
+	}
+	timeUntilUpgrade := time.Until(upgradeAt)
+	if timeUntilUpgrade <= 0 {
+		return nil
 	}
-	s.metrics.UpdateMetricNotificationEventSent(uc.Name, string(state), uc.Spec.Desired.Version)
 
+	// Find the latest offset reached, if any
+	var offset time.Duration
+	for _, o := range s.reminders {
+		if o >= timeUntilUpgrade {
+			offset = o
+		}
+	}
+	if offset == 0 {
+		return nil
+	}
+
+	// Check if a reminder has been sent since the offset was reached - if so, nothing to do
+	state := notifier.MuoStateUpgradeReminderSL
+	sentAt, err := s.store.SentAt(uc.Spec.Desired.Version, state)
+	if err != nil {
+		return fmt.Errorf("can't check notification store: %v", err)
+	}
+	if sentAt != nil && !sentAt.Before(upgradeAt.Add(-offset)) {
+		return nil
+	}
+
+	description, failingHealthChecks := createReminderDescription(uc, upgradeAt, timeUntilUpgrade)
+	description = s.templateDescription(state, uc, description, failingHealthChecks)
+	return s.send(uc, state, description)
+}
+
+// send sends the notification of the state to each destination it is routed to. The notification
+// is queued in the outbox for a destination instead if it can't be sent to it, or if earlier
+// notifications of the upgrade are still queued for it so that they are sent in order, and the
+// upgrade carries on while the outbox sends it again. The notification is recorded as sent once
+// each destination has received it or queued it, so that it is not sent again to the others.
+func (s *eventManager) send(uc *v1alpha1.UpgradeConfig, state notifier.MuoState, description string) error {
+	version := uc.Spec.Desired.Version
+	allSent := true
+	for _, destination := range s.destinations(state) {
+		queued, err := s.outbox.HasQueued(version, destination)
+		if err != nil {
+			return fmt.Errorf("can't check notification outbox: %v", err)
+		}
+		if !queued {
+			err = s.notifyDestination(destination, state, description)
+			if err == nil {
+				continue
+			}
+			s.metrics.UpdatemetricUpgradeNotificationFailed(uc.Name, string(state))
+			log.Error(err, fmt.Sprintf("can't send notification '%s'%s, queuing it to be sent again", state, toDestination(destination)))
+		}
+
+		qerr := s.outbox.Enqueue(version, state, destination, description, err)
+		if qerr != nil {
+			return fmt.Errorf("can't queue notification '%s'%s: %v", state, toDestination(destination), qerr)
+		}
+		allSent = false
+	}
+
+	if allSent {
+		s.setSucceeded(uc.Name, version, state)
+	}
+	err := s.store.SetSent(version, state)
+	if err != nil {
+		return fmt.Errorf("can't record notification '%s': %v", state, err)
+	}
 	return nil
 }
 
+// destinations returns the destinations the state is routed to. A notifier that does not route
+// the notifications is a single unnamed destination.
+func (s *eventManager) destinations(state notifier.MuoState) []notifier.NotifierDestination {
+	if routed, ok := s.notifier.(notifier.RoutedNotifier); ok {
+		return routed.Destinations(state)
+	}
+	return []notifier.NotifierDestination{""}
+}
+
+// notifyDestination sends the notification of the state to the destination, or to every
+// destination it is routed to if the destination is unnamed
+func (s *eventManager) notifyDestination(destination notifier.NotifierDestination, state notifier.MuoState, description string) error {
+	if routed, ok := s.notifier.(notifier.RoutedNotifier); ok && destination != "" {
+		return routed.NotifyDestination(destination, state, description)
+	}
+	return s.notifier.NotifyState(state, description)
+}
+
+// isRouted returns true if the state is still routed to the destination of a queued notification
+func (s *eventManager) isRouted(destination notifier.NotifierDestination, state notifier.MuoState) bool {
+	if destination == "" {
+		return true
+	}
+	for _, d := range s.destinations(state) {
+		if d == destination {
+			return true
+		}
+	}
+	return false
+}
+
+// toDestination names the destination of a notification in logs and errors
+func toDestination(destination notifier.NotifierDestination) string {
+	if destination == "" {
+		return ""
+	}
+	return " to " + string(destination)
+}
+
+// setSucceeded updates the metrics of a notification that has been sent
+func (s *eventManager) setSucceeded(ucName string, version string, state notifier.MuoState) {
+	s.metrics.UpdatemetricUpgradeNotificationSucceeded(ucName, string(state))
+	s.metrics.UpdateMetricNotificationEventSent(ucName, string(state), version)
+}
+
+// SendQueued sends the notifications of the outbox that are due, oldest first. A notification that
+// fails to be sent again to a destination holds the later ones of the destination back, so that each
+// destination receives them in order. Notifications of other versions than the desired one, or to
+// destinations the state is no longer routed to, are dropped and reported by the outbox metrics, as
+// the notifiers describe the desired upgrade.
+func (s *eventManager) SendQueued() error {
+	entries, err := s.outbox.List()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		s.metrics.UpdateMetricNotificationOutbox(0, 0)
+		return nil
+	}
+
+	uc, err := s.upgradeConfigManager.Get()
+	if err != nil {
+		if err == upgradeconfigmanager.ErrUpgradeConfigNotFound {
+			s.updateOutboxMetric(entries)
+			return nil
+		}
+		return fmt.Errorf("unable to find UpgradeConfig: %v", err)
+	}
+
+	now := time.Now()
+	// Destinations whose notifications are held back, the unnamed destination holding every one
+	held := map[notifier.NotifierDestination]bool{}
+	remaining := []notifier.OutboxEntry{}
+	for _, entry := range entries {
+		if entry.Version != uc.Spec.Desired.Version || !s.isRouted(entry.Destination, entry.State) {
+			log.Error(fmt.Errorf("%s", entry.LastError), fmt.Sprintf("dropping queued notification '%s'%s of version %s after %d attempts", entry.State, toDestination(entry.Destination), entry.Version, entry.Attempts))
+			s.metrics.UpdateMetricNotificationOutboxDropped(string(entry.State), entry.Version, string(entry.Destination))
+			if err := s.outbox.Remove(entry); err != nil {
+				return err
+			}
+			continue
+		}
+		if isHeld(held, entry.Destination) || !entry.IsDue(now) {
+			held[entry.Destination] = true
+			remaining = append(remaining, entry)
+			continue
+		}
+
+		err = s.notifyDestination(entry.Destination, entry.State, entry.Description)
+		if err != nil {
+			s.metrics.UpdatemetricUpgradeNotificationFailed(uc.Name, string(entry.State))
+			log.Error(err, fmt.Sprintf("can't send queued notification '%s'%s, attempt %d", entry.State, toDestination(entry.Destination), entry.Attempts+1))
+			if err := s.outbox.Retry(entry, err); err != nil {
+				return err
+			}
+			held[entry.Destination] = true
+			remaining = append(remaining, entry)
+			continue
+		}
+		s.setSucceeded(uc.Name, entry.Version, entry.State)
+		if err := s.outbox.Remove(entry); err != nil {
+			return err
+		}
+	}
+	s.updateOutboxMetric(remaining)
+	return nil
+}
+
+// isHeld returns true if the queued notifications of the destination are held back. The unnamed
+// destination is every destination, so it is held back by any of them.
+func isHeld(held map[notifier.NotifierDestination]bool, destination notifier.NotifierDestination) bool {
+	if destination == "" {
+		return len(held) > 0
+	}
+	return held[""] || held[destination]
+}
+
+// updateOutboxMetric updates the outbox metric with the queued notifications, oldest first
+func (s *eventManager) updateOutboxMetric(entries []notifier.OutboxEntry) {
+	var oldestAge time.Duration
+	if len(entries) > 0 {
+		oldestAge = time.Since(entries[0].EnqueuedAt)
+	}
+	s.metrics.UpdateMetricNotificationOutbox(len(entries), oldestAge)
+}
+
+// templateDescription renders the template configured for the state, if any. The default
+// description is kept if the template can't be rendered, so that the notification is still sent.
+func (s *eventManager) templateDescription(state notifier.MuoState, uc *v1alpha1.UpgradeConfig, description string, failingHealthChecks string) string {
+	tmpl, ok := s.templates[state]
+	if !ok {
+		return description
+	}
+
+	data := DescriptionData{
+		UpgradeConfig:       uc,
+		History:             uc.Status.History.GetHistory(uc.Spec.Desired.Version),
+		Version:             uc.Spec.Desired.Version,
+		ClusterID:           s.cvClient.GetClusterId(),
+		FailingHealthChecks: failingHealthChecks,
+		Description:         description,
+	}
+	if data.History != nil {
+		data.HealthChecks = data.History.HealthChecks
+	}
+	templated, err := renderDescription(tmpl, data)
+	if err != nil {
+		log.Error(err, fmt.Sprintf("failed to render the notification template of %s, using the default description", state))
+		return description
+	}
+	return templated
+}
+
+// Generates a reminder notification description of the upcoming upgrade, with the failures of the
+// latest pre-upgrade health check if any
+func createReminderDescription(uc *v1alpha1.UpgradeConfig, upgradeAt time.Time, timeUntilUpgrade time.Duration) (string, string) {
+	version := uc.Spec.Desired.Version
+	in := strings.TrimSuffix(timeUntilUpgrade.Round(time.Minute).String(), "0s")
+	at := upgradeAt.UTC().Format(time.RFC1123)
+
+	history := uc.Status.History.GetHistory(version)
+	if history == nil || len(history.HealthChecks.GetFailed()) == 0 {
+		return fmt.Sprintf(UPGRADE_REMINDER_DESC, version, in, at), ""
+	}
+	failures := history.HealthChecks.FailureSummary()
+	return fmt.Sprintf(UPGRADE_REMINDER_HEALTHCHECK_DESC, version, in, at, failures), failures
+}
+
 // Generates a Failure notification description based on the UpgradeConfig's last failed state
 func createFailureDescription(uc *v1alpha1.UpgradeConfig) string {
 	// Default failure message
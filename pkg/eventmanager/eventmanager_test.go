@@ -3,10 +3,12 @@ package eventmanager
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"k8s.io/apimachinery/pkg/types"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	cvMocks "github.com/openshift/managed-upgrade-operator/pkg/clusterversion/mocks"
 	configMock "github.com/openshift/managed-upgrade-operator/pkg/configmanager/mocks"
 	metricsMock "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
 	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
@@ -25,6 +27,9 @@ const (
 	TEST_UPGRADECONFIG_CR   = "managed-upgrade-config"
 	TEST_UPGRADE_VERSION    = "4.4.4"
 	TEST_UPGRADE_TIME       = "2020-06-20T00:00:00Z"
+
+	// allDestinations is the destination of the notifiers that do not route the notifications
+	allDestinations = notifier.NotifierDestination("")
 )
 
 var _ = Describe("OCM Notifier", func() {
@@ -34,7 +39,12 @@ var _ = Describe("OCM Notifier", func() {
 		mockUpgradeConfigManager *ucMgrMock.MockUpgradeConfigManager
 		mockConfigManagerBuilder *configMock.MockConfigManagerBuilder
 		mockNotifier             *notifierMock.MockNotifier
+		mockRoutedNotifier       *notifierMock.MockRoutedNotifier
 		mockMetricsClient        *metricsMock.MockMetrics
+		mockStore                *notifierMock.MockNotificationStore
+		mockOutbox               *notifierMock.MockNotificationOutbox
+		mockCVClient             *cvMocks.MockClusterVersion
+		templatesCfg             *NotificationTemplatesConfig
 		manager                  *eventManager
 		upgradeConfigName        types.NamespacedName
 	)
@@ -46,16 +56,27 @@ var _ = Describe("OCM Notifier", func() {
 		mockUpgradeConfigManager = ucMgrMock.NewMockUpgradeConfigManager(mockCtrl)
 		mockConfigManagerBuilder = configMock.NewMockConfigManagerBuilder(mockCtrl)
 		mockNotifier = notifierMock.NewMockNotifier(mockCtrl)
+		mockRoutedNotifier = notifierMock.NewMockRoutedNotifier(mockCtrl)
 		mockMetricsClient = metricsMock.NewMockMetrics(mockCtrl)
+		mockStore = notifierMock.NewMockNotificationStore(mockCtrl)
+		mockOutbox = notifierMock.NewMockNotificationOutbox(mockCtrl)
+		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
+		templatesCfg = &NotificationTemplatesConfig{}
 	})
 
 	JustBeforeEach(func() {
+		templates, err := templatesCfg.GetTemplates()
+		Expect(err).NotTo(HaveOccurred())
 		manager = &eventManager{
 			client:               mockKubeClient,
 			upgradeConfigManager: mockUpgradeConfigManager,
 			notifier:             mockNotifier,
 			metrics:              mockMetricsClient,
 			configManagerBuilder: mockConfigManagerBuilder,
+			store:                mockStore,
+			outbox:               mockOutbox,
+			templates:            templates,
+			cvClient:             mockCVClient,
 		}
 	})
 
@@ -81,7 +102,8 @@ var _ = Describe("OCM Notifier", func() {
 			It("does no action", func() {
 				gomock.InOrder(
 					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
-					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(true, nil),
+					mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(true, nil),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
 				)
 				err := manager.Notify(testState)
 				Expect(err).To(BeNil())
@@ -91,10 +113,12 @@ var _ = Describe("OCM Notifier", func() {
 			It("sends a correct notification", func() {
 				gomock.InOrder(
 					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
-					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
 					mockNotifier.EXPECT().NotifyState(testState, gomock.Any()),
 					mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
 					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
 				)
 				err := manager.Notify(testState)
 				Expect(err).To(BeNil())
@@ -102,12 +126,91 @@ var _ = Describe("OCM Notifier", func() {
 		})
 		Context("when a notification can't be sent", func() {
 			var fakeError = fmt.Errorf("fake error")
-			It("returns an error", func() {
+			It("queues it in the outbox", func() {
 				gomock.InOrder(
 					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
-					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
 					mockNotifier.EXPECT().NotifyState(testState, gomock.Any()).Return(fakeError),
 					mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationFailed(TEST_UPGRADECONFIG_CR, string(testState)),
+					mockOutbox.EXPECT().Enqueue(TEST_UPGRADE_VERSION, testState, allDestinations, gomock.Any(), fakeError),
+					mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
+				)
+				err := manager.Notify(testState)
+				Expect(err).To(BeNil())
+			})
+			It("returns an error if it can't be queued", func() {
+				gomock.InOrder(
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
+					mockNotifier.EXPECT().NotifyState(testState, gomock.Any()).Return(fakeError),
+					mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationFailed(TEST_UPGRADECONFIG_CR, string(testState)),
+					mockOutbox.EXPECT().Enqueue(TEST_UPGRADE_VERSION, testState, allDestinations, gomock.Any(), fakeError).Return(fakeError),
+				)
+				err := manager.Notify(testState)
+				Expect(err).NotTo(BeNil())
+			})
+		})
+		Context("when earlier notifications are queued", func() {
+			It("queues the notification behind them", func() {
+				gomock.InOrder(
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(true, nil),
+					mockOutbox.EXPECT().Enqueue(TEST_UPGRADE_VERSION, testState, allDestinations, gomock.Any(), nil),
+					mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
+				)
+				err := manager.Notify(testState)
+				Expect(err).To(BeNil())
+			})
+		})
+		Context("when a notification can't be sent to some of the destinations", func() {
+			var fakeError = fmt.Errorf("fake error")
+			It("queues it for those destinations only and records it as sent", func() {
+				manager.notifier = mockRoutedNotifier
+				gomock.InOrder(
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+					mockRoutedNotifier.EXPECT().Destinations(testState).Return([]notifier.NotifierDestination{notifier.NotifierDestinationOCM, notifier.NotifierDestinationWebhook}),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, notifier.NotifierDestinationOCM).Return(false, nil),
+					mockRoutedNotifier.EXPECT().NotifyDestination(notifier.NotifierDestinationOCM, testState, gomock.Any()),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, notifier.NotifierDestinationWebhook).Return(false, nil),
+					mockRoutedNotifier.EXPECT().NotifyDestination(notifier.NotifierDestinationWebhook, testState, gomock.Any()).Return(fakeError),
+					mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationFailed(TEST_UPGRADECONFIG_CR, string(testState)),
+					mockOutbox.EXPECT().Enqueue(TEST_UPGRADE_VERSION, testState, notifier.NotifierDestinationWebhook, gomock.Any(), fakeError),
+					mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
+				)
+				err := manager.Notify(testState)
+				Expect(err).To(BeNil())
+			})
+			It("queues it behind the earlier notifications of a destination only", func() {
+				manager.notifier = mockRoutedNotifier
+				gomock.InOrder(
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+					mockRoutedNotifier.EXPECT().Destinations(testState).Return([]notifier.NotifierDestination{notifier.NotifierDestinationOCM, notifier.NotifierDestinationWebhook}),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, notifier.NotifierDestinationOCM).Return(false, nil),
+					mockRoutedNotifier.EXPECT().NotifyDestination(notifier.NotifierDestinationOCM, testState, gomock.Any()),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, notifier.NotifierDestinationWebhook).Return(true, nil),
+					mockOutbox.EXPECT().Enqueue(TEST_UPGRADE_VERSION, testState, notifier.NotifierDestinationWebhook, gomock.Any(), nil),
+					mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
+				)
+				err := manager.Notify(testState)
+				Expect(err).To(BeNil())
+			})
+		})
+		Context("when a sent notification can't be recorded", func() {
+			var fakeError = fmt.Errorf("fake error")
+			It("returns an error", func() {
+				gomock.InOrder(
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
+					mockNotifier.EXPECT().NotifyState(testState, gomock.Any()),
+					mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState).Return(fakeError),
 				)
 				err := manager.Notify(testState)
 				Expect(err).NotTo(BeNil())
@@ -143,10 +246,12 @@ var _ = Describe("OCM Notifier", func() {
 				expectedDescription := fmt.Sprintf(UPGRADE_PREHEALTHCHECK_FAILED_DESC, uc.Spec.Desired.Version)
 				gomock.InOrder(
 					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
-					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
 					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
 					mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
 					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
 				)
 				err := manager.Notify(testState)
 				Expect(err).To(BeNil())
@@ -166,10 +271,12 @@ var _ = Describe("OCM Notifier", func() {
 				expectedDescription := fmt.Sprintf(UPGRADE_EXTDEPCHECK_FAILED_DESC, uc.Spec.Desired.Version)
 				gomock.InOrder(
 					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
-					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
 					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
 					mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
 					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
 				)
 				err := manager.Notify(testState)
 				Expect(err).To(BeNil())
@@ -189,10 +296,12 @@ var _ = Describe("OCM Notifier", func() {
 				expectedDescription := fmt.Sprintf(UPGRADE_SCALE_FAILED_DESC, uc.Spec.Desired.Version)
 				gomock.InOrder(
 					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
-					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
 					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
 					mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
 					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
 				)
 				err := manager.Notify(testState)
 				Expect(err).To(BeNil())
@@ -212,10 +321,12 @@ var _ = Describe("OCM Notifier", func() {
 				expectedDescription := fmt.Sprintf(UPGRADE_PRECHECK_FAILED_DESC, uc.Spec.Desired.Version)
 				gomock.InOrder(
 					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
-					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
 					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
 					mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
 					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
 				)
 				err := manager.Notify(testState)
 				Expect(err).To(BeNil())
@@ -251,10 +362,12 @@ var _ = Describe("OCM Notifier", func() {
 				expectedDescription := fmt.Sprintf(UPGRADE_PREHEALTHCHECK_DELAY_DESC, uc.Spec.Desired.Version)
 				gomock.InOrder(
 					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
-					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
 					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
 					mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
 					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
 				)
 				err := manager.Notify(testState)
 				Expect(err).To(BeNil())
@@ -274,10 +387,12 @@ var _ = Describe("OCM Notifier", func() {
 				expectedDescription := fmt.Sprintf(UPGRADE_EXTDEPCHECK_DELAY_DESC, uc.Spec.Desired.Version)
 				gomock.InOrder(
 					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
-					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
 					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
 					mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
 					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
 				)
 				err := manager.Notify(testState)
 				Expect(err).To(BeNil())
@@ -297,10 +412,12 @@ var _ = Describe("OCM Notifier", func() {
 				expectedDescription := fmt.Sprintf(UPGRADE_SCALE_DELAY_DESC, uc.Spec.Desired.Version)
 				gomock.InOrder(
 					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
-					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
 					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
 					mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
 					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
 				)
 				err := manager.Notify(testState)
 				Expect(err).To(BeNil())
@@ -320,16 +437,48 @@ var _ = Describe("OCM Notifier", func() {
 				expectedDescription := fmt.Sprintf(UPGRADE_DEFAULT_DELAY_DESC, uc.Spec.Desired.Version)
 				gomock.InOrder(
 					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
-					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
 					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
 					mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
 					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
 				)
 				err := manager.Notify(testState)
 				Expect(err).To(BeNil())
 			})
 		})
 
+		Context("when the cause of the delay is given", func() {
+			var cause = "Node drain grace period might be impacting cluster upgrade."
+			It("sends the cause as description", func() {
+				gomock.InOrder(
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
+					mockNotifier.EXPECT().NotifyState(testState, cause),
+					mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
+				)
+				err := manager.NotifyResult(testState, cause)
+				Expect(err).To(BeNil())
+			})
+			It("queues it in the outbox when it can't be sent", func() {
+				fakeError := fmt.Errorf("fake error")
+				gomock.InOrder(
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
+					mockNotifier.EXPECT().NotifyState(testState, cause).Return(fakeError),
+					mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationFailed(TEST_UPGRADECONFIG_CR, string(testState)),
+					mockOutbox.EXPECT().Enqueue(TEST_UPGRADE_VERSION, testState, allDestinations, cause, fakeError),
+					mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
+				)
+				err := manager.NotifyResult(testState, cause)
+				Expect(err).To(BeNil())
+			})
+		})
 	})
 
 	Context("When notifying a MuoStateHealthCheck state", func() {
@@ -350,13 +499,317 @@ var _ = Describe("OCM Notifier", func() {
 				expectedDescription := fmt.Sprintf(UPGRADE_HEALTHCHECK_DELAY_DESC, uc.Spec.Desired.Version, gomock.Any().String())
 				gomock.InOrder(
 					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
-					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+					mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
 					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
 					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+					mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
 				)
 				err := manager.NotifyResult(testState, gomock.Any().String())
 				Expect(err).To(BeNil())
 			})
 		})
 	})
+
+	Context("When reminding of the upcoming upgrade", func() {
+		var uc upgradev1alpha1.UpgradeConfig
+		var testState = notifier.MuoStateUpgradeReminderSL
+		var upgradeAt time.Time
+		BeforeEach(func() {
+			upgradeConfigName = types.NamespacedName{
+				Name:      TEST_UPGRADECONFIG_CR,
+				Namespace: TEST_OPERATOR_NAMESPACE,
+			}
+			uc = *testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhasePending).GetUpgradeConfig()
+			uc.Spec.Desired.Version = TEST_UPGRADE_VERSION
+			uc.Status.History[0].Version = TEST_UPGRADE_VERSION
+			upgradeAt = time.Now().Add(20 * time.Hour).UTC().Truncate(time.Second)
+			uc.Spec.UpgradeAt = upgradeAt.Format(time.RFC3339)
+		})
+		JustBeforeEach(func() {
+			manager.reminders = []time.Duration{72 * time.Hour, 24 * time.Hour, time.Hour}
+		})
+
+		It("does nothing without reminders", func() {
+			manager.reminders = nil
+			Expect(manager.NotifyReminder()).To(Succeed())
+		})
+
+		It("does nothing before the first offset is reached", func() {
+			uc.Spec.UpgradeAt = time.Now().Add(100 * time.Hour).UTC().Format(time.RFC3339)
+			mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil)
+			Expect(manager.NotifyReminder()).To(Succeed())
+		})
+
+		It("sends the reminder of the latest offset reached", func() {
+			gomock.InOrder(
+				mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+				mockStore.EXPECT().SentAt(TEST_UPGRADE_VERSION, testState).Return(nil, nil),
+				mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
+				mockNotifier.EXPECT().NotifyState(testState, gomock.Any()).DoAndReturn(func(_ notifier.MuoState, description string) error {
+					Expect(description).To(HavePrefix("Cluster upgrade to version 4.4.4 is scheduled to start in 20h0m, at " + upgradeAt.Format(time.RFC1123)))
+					return nil
+				}),
+				mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
+			)
+			Expect(manager.NotifyReminder()).To(Succeed())
+		})
+
+		It("includes the latest pre-upgrade health check failures", func() {
+			uc.Status.History[0].HealthChecks = upgradev1alpha1.HealthCheckReports{
+				{Name: "CriticalAlerts", Result: upgradev1alpha1.HealthCheckFailed, AffectedObjects: []string{"KubeAPIDown"}},
+			}
+			gomock.InOrder(
+				mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+				mockStore.EXPECT().SentAt(TEST_UPGRADE_VERSION, testState).Return(nil, nil),
+				mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
+				mockNotifier.EXPECT().NotifyState(testState, gomock.Any()).DoAndReturn(func(_ notifier.MuoState, description string) error {
+					Expect(description).To(ContainSubstring("CriticalAlertsHealthcheckFailed:(KubeAPIDown)"))
+					return nil
+				}),
+				mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
+			)
+			Expect(manager.NotifyReminder()).To(Succeed())
+		})
+
+		It("does not send the reminder of an offset twice", func() {
+			sentAt := upgradeAt.Add(-23 * time.Hour)
+			gomock.InOrder(
+				mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+				mockStore.EXPECT().SentAt(TEST_UPGRADE_VERSION, testState).Return(&sentAt, nil),
+			)
+			Expect(manager.NotifyReminder()).To(Succeed())
+		})
+
+		It("sends the reminder of an offset reached since the last reminder", func() {
+			sentAt := upgradeAt.Add(-70 * time.Hour)
+			gomock.InOrder(
+				mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+				mockStore.EXPECT().SentAt(TEST_UPGRADE_VERSION, testState).Return(&sentAt, nil),
+				mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
+				mockNotifier.EXPECT().NotifyState(testState, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
+			)
+			Expect(manager.NotifyReminder()).To(Succeed())
+		})
+	})
+
+	Context("When sending the queued notifications", func() {
+		var uc upgradev1alpha1.UpgradeConfig
+		var fakeError = fmt.Errorf("fake error")
+		var started, completed notifier.OutboxEntry
+		BeforeEach(func() {
+			upgradeConfigName = types.NamespacedName{
+				Name:      TEST_UPGRADECONFIG_CR,
+				Namespace: TEST_OPERATOR_NAMESPACE,
+			}
+			uc = *testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseUpgraded).GetUpgradeConfig()
+			uc.Spec.Desired.Version = TEST_UPGRADE_VERSION
+			enqueuedAt := time.Now().Add(-10 * time.Minute)
+			started = notifier.OutboxEntry{Version: TEST_UPGRADE_VERSION, State: notifier.MuoStateStarted, Description: "started", Attempts: 1, EnqueuedAt: enqueuedAt, NextAttempt: enqueuedAt}
+			completed = notifier.OutboxEntry{Version: TEST_UPGRADE_VERSION, State: notifier.MuoStateCompleted, Description: "completed", Attempts: 1, EnqueuedAt: enqueuedAt.Add(time.Minute), NextAttempt: enqueuedAt}
+		})
+
+		It("sends the due notifications in order and removes them", func() {
+			gomock.InOrder(
+				mockOutbox.EXPECT().List().Return([]notifier.OutboxEntry{started, completed}, nil),
+				mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+				mockNotifier.EXPECT().NotifyState(notifier.MuoStateStarted, "started"),
+				mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(notifier.MuoStateStarted)),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(notifier.MuoStateStarted), TEST_UPGRADE_VERSION),
+				mockOutbox.EXPECT().Remove(started),
+				mockNotifier.EXPECT().NotifyState(notifier.MuoStateCompleted, "completed"),
+				mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(notifier.MuoStateCompleted)),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(notifier.MuoStateCompleted), TEST_UPGRADE_VERSION),
+				mockOutbox.EXPECT().Remove(completed),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationOutbox(0, time.Duration(0)),
+			)
+			Expect(manager.SendQueued()).To(Succeed())
+		})
+
+		It("holds the later notifications back when a notification still can't be sent", func() {
+			gomock.InOrder(
+				mockOutbox.EXPECT().List().Return([]notifier.OutboxEntry{started, completed}, nil),
+				mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+				mockNotifier.EXPECT().NotifyState(notifier.MuoStateStarted, "started").Return(fakeError),
+				mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationFailed(TEST_UPGRADECONFIG_CR, string(notifier.MuoStateStarted)),
+				mockOutbox.EXPECT().Retry(started, fakeError),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationOutbox(2, gomock.Any()).Do(func(_ int, age time.Duration) {
+					Expect(age).To(BeNumerically(">=", 10*time.Minute))
+				}),
+			)
+			Expect(manager.SendQueued()).To(Succeed())
+		})
+
+		It("sends the queued notifications to the destinations they failed to reach only", func() {
+			manager.notifier = mockRoutedNotifier
+			started.Destination = notifier.NotifierDestinationWebhook
+			completed.Destination = notifier.NotifierDestinationEmail
+			routed := []notifier.NotifierDestination{notifier.NotifierDestinationOCM, notifier.NotifierDestinationWebhook, notifier.NotifierDestinationEmail}
+			mockRoutedNotifier.EXPECT().Destinations(gomock.Any()).Return(routed).AnyTimes()
+			gomock.InOrder(
+				mockOutbox.EXPECT().List().Return([]notifier.OutboxEntry{started, completed}, nil),
+				mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+				mockRoutedNotifier.EXPECT().NotifyDestination(notifier.NotifierDestinationWebhook, notifier.MuoStateStarted, "started").Return(fakeError),
+				mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationFailed(TEST_UPGRADECONFIG_CR, string(notifier.MuoStateStarted)),
+				mockOutbox.EXPECT().Retry(started, fakeError),
+				mockRoutedNotifier.EXPECT().NotifyDestination(notifier.NotifierDestinationEmail, notifier.MuoStateCompleted, "completed"),
+				mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(notifier.MuoStateCompleted)),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(notifier.MuoStateCompleted), TEST_UPGRADE_VERSION),
+				mockOutbox.EXPECT().Remove(completed),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationOutbox(1, gomock.Any()),
+			)
+			Expect(manager.SendQueued()).To(Succeed())
+		})
+
+		It("drops the notifications to destinations the state is no longer routed to", func() {
+			manager.notifier = mockRoutedNotifier
+			started.Destination = notifier.NotifierDestinationWebhook
+			gomock.InOrder(
+				mockOutbox.EXPECT().List().Return([]notifier.OutboxEntry{started}, nil),
+				mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+				mockRoutedNotifier.EXPECT().Destinations(notifier.MuoStateStarted).Return([]notifier.NotifierDestination{notifier.NotifierDestinationOCM}),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationOutboxDropped(string(notifier.MuoStateStarted), TEST_UPGRADE_VERSION, string(notifier.NotifierDestinationWebhook)),
+				mockOutbox.EXPECT().Remove(started),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationOutbox(0, time.Duration(0)),
+			)
+			Expect(manager.SendQueued()).To(Succeed())
+		})
+
+		It("waits for the notifications that are not due", func() {
+			started.NextAttempt = time.Now().Add(time.Minute)
+			gomock.InOrder(
+				mockOutbox.EXPECT().List().Return([]notifier.OutboxEntry{started, completed}, nil),
+				mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationOutbox(2, gomock.Any()),
+			)
+			Expect(manager.SendQueued()).To(Succeed())
+		})
+
+		It("drops the notifications of superseded versions", func() {
+			started.Version = "4.4.3"
+			gomock.InOrder(
+				mockOutbox.EXPECT().List().Return([]notifier.OutboxEntry{started}, nil),
+				mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationOutboxDropped(string(notifier.MuoStateStarted), "4.4.3", ""),
+				mockOutbox.EXPECT().Remove(started),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationOutbox(0, time.Duration(0)),
+			)
+			Expect(manager.SendQueued()).To(Succeed())
+		})
+
+		It("reports an empty outbox", func() {
+			gomock.InOrder(
+				mockOutbox.EXPECT().List().Return([]notifier.OutboxEntry{}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationOutbox(0, time.Duration(0)),
+			)
+			Expect(manager.SendQueued()).To(Succeed())
+		})
+	})
+
+	Context("When a notification template is configured", func() {
+		var uc upgradev1alpha1.UpgradeConfig
+		BeforeEach(func() {
+			upgradeConfigName = types.NamespacedName{
+				Name:      TEST_UPGRADECONFIG_CR,
+				Namespace: TEST_OPERATOR_NAMESPACE,
+			}
+			uc = *testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseUpgrading).GetUpgradeConfig()
+			uc.Spec.Desired.Version = TEST_UPGRADE_VERSION
+			uc.Status.History[0].Version = TEST_UPGRADE_VERSION
+			uc.Spec.UpgradeAt = TEST_UPGRADE_TIME
+			uc.Status.History[0].HealthChecks = upgradev1alpha1.HealthCheckReports{
+				{Name: "CriticalAlerts", Result: upgradev1alpha1.HealthCheckFailed, AffectedObjects: []string{"KubeAPIDown"}},
+			}
+			templatesCfg.NotificationTemplates = map[notifier.MuoState]string{
+				notifier.MuoStateStarted:       `Upgrade of {{ .ClusterID }} to {{ .Version }} ({{ .History.Phase }}) started. See https://example.com/kb`,
+				notifier.MuoStateHealthCheckSL: `Failing: {{ .FailingHealthChecks }}.{{ range .HealthChecks }} {{ .Name }}: {{ join .AffectedObjects ", " }}{{ end }}`,
+				notifier.MuoStateCompleted:     `{{ .Description }} on {{ .UpgradeConfig.Spec.UpgradeAt }}`,
+				notifier.MuoStateDelayed:       `Upgrade to {{ .Version }} delayed: {{ .Description }}`,
+			}
+		})
+
+		It("renders the template of the state", func() {
+			testState := notifier.MuoStateStarted
+			gomock.InOrder(
+				mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+				mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+				mockCVClient.EXPECT().GetClusterId().Return("cluster-id"),
+				mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
+				mockNotifier.EXPECT().NotifyState(testState, "Upgrade of cluster-id to 4.4.4 (Upgrading) started. See https://example.com/kb"),
+				mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
+			)
+			Expect(manager.Notify(testState)).To(Succeed())
+		})
+
+		It("renders the health report of the health check notifications", func() {
+			testState := notifier.MuoStateHealthCheckSL
+			gomock.InOrder(
+				mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+				mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+				mockCVClient.EXPECT().GetClusterId().Return("cluster-id"),
+				mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
+				mockNotifier.EXPECT().NotifyState(testState, "Failing: CriticalAlerts. CriticalAlerts: KubeAPIDown"),
+				mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
+			)
+			Expect(manager.NotifyResult(testState, "CriticalAlerts")).To(Succeed())
+		})
+
+		It("gives the templates the default description", func() {
+			testState := notifier.MuoStateCompleted
+			gomock.InOrder(
+				mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+				mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+				mockCVClient.EXPECT().GetClusterId().Return("cluster-id"),
+				mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
+				mockNotifier.EXPECT().NotifyState(testState, "Cluster has been successfully upgraded to version 4.4.4 on "+TEST_UPGRADE_TIME),
+				mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
+			)
+			Expect(manager.Notify(testState)).To(Succeed())
+		})
+
+		It("renders the template of the delay notifications given their cause", func() {
+			testState := notifier.MuoStateDelayed
+			gomock.InOrder(
+				mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+				mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+				mockCVClient.EXPECT().GetClusterId().Return("cluster-id"),
+				mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
+				mockNotifier.EXPECT().NotifyState(testState, "Upgrade to 4.4.4 delayed: Node drain grace period might be impacting cluster upgrade."),
+				mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
+			)
+			Expect(manager.NotifyResult(testState, "Node drain grace period might be impacting cluster upgrade.")).To(Succeed())
+		})
+
+		It("sends the default description when the template can't be rendered", func() {
+			testState := notifier.MuoStateStarted
+			uc.Status.History = nil
+			gomock.InOrder(
+				mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+				mockStore.EXPECT().IsSent(TEST_UPGRADE_VERSION, testState).Return(false, nil),
+				mockCVClient.EXPECT().GetClusterId().Return("cluster-id"),
+				mockOutbox.EXPECT().HasQueued(TEST_UPGRADE_VERSION, allDestinations).Return(false, nil),
+				mockNotifier.EXPECT().NotifyState(testState, "Cluster is currently being upgraded to version 4.4.4"),
+				mockMetricsClient.EXPECT().UpdatemetricUpgradeNotificationSucceeded(TEST_UPGRADECONFIG_CR, string(testState)),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				mockStore.EXPECT().SetSent(TEST_UPGRADE_VERSION, testState),
+			)
+			Expect(manager.Notify(testState)).To(Succeed())
+		})
+	})
 })
@@ -53,6 +53,20 @@ func (mr *MockEventManagerMockRecorder) Notify(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockEventManager)(nil).Notify), arg0)
 }
 
+// NotifyReminder mocks base method.
+func (m *MockEventManager) NotifyReminder() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyReminder")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyReminder indicates an expected call of NotifyReminder.
+func (mr *MockEventManagerMockRecorder) NotifyReminder() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyReminder", reflect.TypeOf((*MockEventManager)(nil).NotifyReminder))
+}
+
 // NotifyResult mocks base method.
 func (m *MockEventManager) NotifyResult(arg0 notifier.MuoState, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -66,3 +80,17 @@ func (mr *MockEventManagerMockRecorder) NotifyResult(arg0, arg1 any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyResult", reflect.TypeOf((*MockEventManager)(nil).NotifyResult), arg0, arg1)
 }
+
+// SendQueued mocks base method.
+func (m *MockEventManager) SendQueued() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendQueued")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendQueued indicates an expected call of SendQueued.
+func (mr *MockEventManagerMockRecorder) SendQueued() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendQueued", reflect.TypeOf((*MockEventManager)(nil).SendQueued))
+}
@@ -14,6 +14,7 @@ import (
 
 	eventmanager "github.com/openshift/managed-upgrade-operator/pkg/eventmanager"
 	gomock "go.uber.org/mock/gomock"
+	record "k8s.io/client-go/tools/record"
 	client "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -41,16 +42,16 @@ func (m *MockEventManagerBuilder) EXPECT() *MockEventManagerBuilderMockRecorder
 }
 
 // NewManager mocks base method.
-func (m *MockEventManagerBuilder) NewManager(arg0 client.Client) (eventmanager.EventManager, error) {
+func (m *MockEventManagerBuilder) NewManager(arg0 client.Client, arg1 record.EventRecorder) (eventmanager.EventManager, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "NewManager", arg0)
+	ret := m.ctrl.Call(m, "NewManager", arg0, arg1)
 	ret0, _ := ret[0].(eventmanager.EventManager)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // NewManager indicates an expected call of NewManager.
-func (mr *MockEventManagerBuilderMockRecorder) NewManager(arg0 any) *gomock.Call {
+func (mr *MockEventManagerBuilderMockRecorder) NewManager(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewManager", reflect.TypeOf((*MockEventManagerBuilder)(nil).NewManager), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewManager", reflect.TypeOf((*MockEventManagerBuilder)(nil).NewManager), arg0, arg1)
 }
@@ -0,0 +1,70 @@
+package eventmanager
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
+)
+
+// outboxInterval is the interval the queued notifications are sent at
+const outboxInterval = 30 * time.Second
+
+// OutboxProcessor periodically sends the notifications queued in the notification outbox
+type OutboxProcessor struct {
+	client              client.Client
+	eventManagerBuilder EventManagerBuilder
+	outbox              notifier.NotificationOutbox
+	recorder            record.EventRecorder
+}
+
+// NewOutboxProcessor returns an OutboxProcessor
+func NewOutboxProcessor(c client.Client, emb EventManagerBuilder, recorder record.EventRecorder) *OutboxProcessor {
+	return &OutboxProcessor{
+		client:              c,
+		eventManagerBuilder: emb,
+		outbox:              notifier.NewNotificationOutbox(c),
+		recorder:            recorder,
+	}
+}
+
+// Start sends the queued notifications until the context is done. It is run by the controller
+// manager, which only starts it on the leader so that notifications are not sent twice.
+func (p *OutboxProcessor) Start(ctx context.Context) error {
+	ticker := time.NewTicker(outboxInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.process()
+		case <-ctx.Done():
+			log.Info("Stopping the notification outbox")
+			return nil
+		}
+	}
+}
+
+// process sends the queued notifications that are due. The event manager, which connects to the
+// notifiers and Prometheus, is only created when notifications are queued.
+func (p *OutboxProcessor) process() {
+	entries, err := p.outbox.List()
+	if err != nil {
+		log.Error(err, "can't read the queued notifications")
+		return
+	}
+	if len(entries) == 0 {
+		return
+	}
+	em, err := p.eventManagerBuilder.NewManager(p.client, p.recorder)
+	if err != nil {
+		log.Error(err, "can't create the event manager sending the queued notifications")
+		return
+	}
+	err = em.SendQueued()
+	if err != nil {
+		log.Error(err, "can't send the queued notifications")
+	}
+}
@@ -0,0 +1,60 @@
+package eventmanager
+
+import (
+	"fmt"
+
+	"go.uber.org/mock/gomock"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
+	notifierMock "github.com/openshift/managed-upgrade-operator/pkg/notifier/mocks"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeEventManagerBuilder counts the event managers it is asked to create
+type fakeEventManagerBuilder struct {
+	created int
+}
+
+func (b *fakeEventManagerBuilder) NewManager(client.Client, record.EventRecorder) (EventManager, error) {
+	b.created++
+	return nil, fmt.Errorf("fake error")
+}
+
+var _ = Describe("Notification outbox processor", func() {
+	var (
+		mockCtrl   *gomock.Controller
+		mockOutbox *notifierMock.MockNotificationOutbox
+		builder    *fakeEventManagerBuilder
+		processor  *OutboxProcessor
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockOutbox = notifierMock.NewMockNotificationOutbox(mockCtrl)
+		builder = &fakeEventManagerBuilder{}
+		processor = &OutboxProcessor{
+			eventManagerBuilder: builder,
+			outbox:              mockOutbox,
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("does not create the event manager when no notification is queued", func() {
+		mockOutbox.EXPECT().List().Return([]notifier.OutboxEntry{}, nil)
+		processor.process()
+		Expect(builder.created).To(Equal(0))
+	})
+
+	It("creates the event manager to send the queued notifications", func() {
+		mockOutbox.EXPECT().List().Return([]notifier.OutboxEntry{{Version: TEST_UPGRADE_VERSION, State: notifier.MuoStateStarted}}, nil)
+		processor.process()
+		Expect(builder.created).To(Equal(1))
+	})
+})
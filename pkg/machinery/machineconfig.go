@@ -4,15 +4,19 @@ import (
 	"context"
 
 	machineconfigv1 "github.com/openshift/api/machineconfiguration/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // UpgradingResult provides a struct to illustrate the upgrading result
 type UpgradingResult struct {
+	Name         string
 	IsUpgrading  bool
 	UpdatedCount int32
 	MachineCount int32
+	// IsDegraded is true if the pool reports a Degraded or NodeDegraded condition or degraded machines
+	IsDegraded bool
 }
 
 // IsUpgrading determines if machines are currently upgrading by comparing
@@ -24,9 +28,39 @@ func (m *machinery) IsUpgrading(c client.Client, nodeType string) (*UpgradingRes
 		return nil, err
 	}
 
+	return upgradingResult(configPool), nil
+}
+
+// IsUpgradingPools determines for every MachineConfigPool if its machines are
+// currently upgrading and if it is degraded
+func (m *machinery) IsUpgradingPools(c client.Client) ([]*UpgradingResult, error) {
+	configPools := &machineconfigv1.MachineConfigPoolList{}
+	err := c.List(context.TODO(), configPools)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []*UpgradingResult{}
+	for i := range configPools.Items {
+		results = append(results, upgradingResult(&configPools.Items[i]))
+	}
+	return results, nil
+}
+
+func upgradingResult(configPool *machineconfigv1.MachineConfigPool) *UpgradingResult {
+	isDegraded := configPool.Status.DegradedMachineCount > 0
+	for _, condition := range configPool.Status.Conditions {
+		if (condition.Type == machineconfigv1.MachineConfigPoolDegraded || condition.Type == machineconfigv1.MachineConfigPoolNodeDegraded) &&
+			condition.Status == corev1.ConditionTrue {
+			isDegraded = true
+		}
+	}
+
 	return &UpgradingResult{
+		Name:         configPool.Name,
 		IsUpgrading:  configPool.Status.MachineCount != configPool.Status.UpdatedMachineCount,
 		UpdatedCount: configPool.Status.UpdatedMachineCount,
 		MachineCount: configPool.Status.MachineCount,
-	}, nil
+		IsDegraded:   isDegraded,
+	}
 }
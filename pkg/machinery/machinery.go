@@ -16,11 +16,14 @@ const (
 //go:generate mockgen -destination=mocks/machinery.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/machinery Machinery
 type Machinery interface {
 	IsUpgrading(c client.Client, nodeType string) (*UpgradingResult, error)
+	IsUpgradingPools(c client.Client) ([]*UpgradingResult, error)
 	IsNodeCordoned(node *corev1.Node) *IsCordonedResult
 	IsNodeUpgrading(node *corev1.Node) bool
+	IsNodeDegraded(node *corev1.Node) bool
 	HasMemoryPressure(node *corev1.Node) bool
 	HasDiskPressure(node *corev1.Node) bool
 	HasPidPressure(node *corev1.Node) bool
+	GetNodeConditions(node *corev1.Node) *NodeConditionsResult
 }
 
 type machinery struct{}
@@ -75,6 +75,63 @@ var _ = Describe("Machinery client and utils", func() {
 		})
 	})
 
+	Context("When assessing every MachineConfigPool", func() {
+		It("reports the error", func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(fmt.Errorf("Fake error"))
+			result, err := machineryClient.IsUpgradingPools(mockKubeClient)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeNil())
+		})
+
+		It("Reports the rollout and degraded state of each pool", func() {
+			configPools := &machineconfigapi.MachineConfigPoolList{
+				Items: []machineconfigapi.MachineConfigPool{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "master"},
+						Status:     machineconfigapi.MachineConfigPoolStatus{MachineCount: 3, UpdatedMachineCount: 3},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "infra"},
+						Status: machineconfigapi.MachineConfigPoolStatus{
+							MachineCount:        3,
+							UpdatedMachineCount: 2,
+							Conditions: []machineconfigapi.MachineConfigPoolCondition{
+								{Type: machineconfigapi.MachineConfigPoolNodeDegraded, Status: corev1.ConditionTrue},
+							},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+						Status:     machineconfigapi.MachineConfigPoolStatus{MachineCount: 4, UpdatedMachineCount: 4, DegradedMachineCount: 1},
+					},
+				},
+			}
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *configPools).Return(nil)
+			result, err := machineryClient.IsUpgradingPools(mockKubeClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal([]*UpgradingResult{
+				{Name: "master", MachineCount: 3, UpdatedCount: 3},
+				{Name: "infra", IsUpgrading: true, MachineCount: 3, UpdatedCount: 2, IsDegraded: true},
+				{Name: "worker", MachineCount: 4, UpdatedCount: 4, IsDegraded: true},
+			}))
+		})
+	})
+
+	Context("When assessing if a node is degraded", func() {
+		It("Reports a node the machine config daemon failed to update", func() {
+			testNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{MachineConfigDaemonStateAnnotationKey: MachineConfigDaemonStateDegraded},
+			}}
+			Expect(machineryClient.IsNodeDegraded(testNode)).To(BeTrue())
+		})
+		It("Reports a node that is being updated as not degraded", func() {
+			testNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{MachineConfigDaemonStateAnnotationKey: MachineConfigDaemonStateWorking},
+			}}
+			Expect(machineryClient.IsNodeDegraded(testNode)).To(BeFalse())
+		})
+	})
+
 	Context("When assessing if a node is cordoned", func() {
 		It("Reports if the node is draining", func() {
 			testNode := &corev1.Node{
@@ -260,4 +317,61 @@ var _ = Describe("Machinery client and utils", func() {
 			Expect(result).To(BeTrue())
 		})
 	})
+
+	Context("When assessing the conditions of a node", func() {
+		It("Reports a ready node without pressure as healthy", func() {
+			testNode := &corev1.Node{
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{
+						{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+						{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+					},
+				},
+			}
+			result := machineryClient.GetNodeConditions(testNode)
+			Expect(result.IsReady).To(BeTrue())
+			Expect(result.IsUnknown).To(BeFalse())
+			Expect(result.Pressure).To(BeEmpty())
+			Expect(result.IsHealthy()).To(BeTrue())
+		})
+
+		It("Reports the pressure of a node", func() {
+			testNode := &corev1.Node{
+				Spec: corev1.NodeSpec{
+					Taints: []corev1.Taint{
+						{Effect: corev1.TaintEffectNoSchedule, Key: corev1.TaintNodePIDPressure},
+						{Effect: corev1.TaintEffectNoSchedule, Key: corev1.TaintNodeDiskPressure},
+					},
+				},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{
+						{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+					},
+				},
+			}
+			result := machineryClient.GetNodeConditions(testNode)
+			Expect(result.Pressure).To(Equal([]corev1.NodeConditionType{corev1.NodeDiskPressure, corev1.NodePIDPressure}))
+			Expect(result.IsHealthy()).To(BeFalse())
+		})
+
+		It("Reports a node whose kubelet stopped posting status as unknown", func() {
+			testNode := &corev1.Node{
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{
+						{Type: corev1.NodeReady, Status: corev1.ConditionUnknown},
+					},
+				},
+			}
+			result := machineryClient.GetNodeConditions(testNode)
+			Expect(result.IsReady).To(BeFalse())
+			Expect(result.IsUnknown).To(BeTrue())
+			Expect(result.IsHealthy()).To(BeFalse())
+		})
+
+		It("Reports a node without a Ready condition as unknown", func() {
+			result := machineryClient.GetNodeConditions(&corev1.Node{})
+			Expect(result.IsReady).To(BeFalse())
+			Expect(result.IsUnknown).To(BeTrue())
+		})
+	})
 })
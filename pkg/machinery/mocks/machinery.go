@@ -41,6 +41,20 @@ func (m *MockMachinery) EXPECT() *MockMachineryMockRecorder {
 	return m.recorder
 }
 
+// GetNodeConditions mocks base method.
+func (m *MockMachinery) GetNodeConditions(arg0 *v1.Node) *machinery.NodeConditionsResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodeConditions", arg0)
+	ret0, _ := ret[0].(*machinery.NodeConditionsResult)
+	return ret0
+}
+
+// GetNodeConditions indicates an expected call of GetNodeConditions.
+func (mr *MockMachineryMockRecorder) GetNodeConditions(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeConditions", reflect.TypeOf((*MockMachinery)(nil).GetNodeConditions), arg0)
+}
+
 // HasDiskPressure mocks base method.
 func (m *MockMachinery) HasDiskPressure(arg0 *v1.Node) bool {
 	m.ctrl.T.Helper()
@@ -97,6 +111,20 @@ func (mr *MockMachineryMockRecorder) IsNodeCordoned(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsNodeCordoned", reflect.TypeOf((*MockMachinery)(nil).IsNodeCordoned), arg0)
 }
 
+// IsNodeDegraded mocks base method.
+func (m *MockMachinery) IsNodeDegraded(arg0 *v1.Node) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsNodeDegraded", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsNodeDegraded indicates an expected call of IsNodeDegraded.
+func (mr *MockMachineryMockRecorder) IsNodeDegraded(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsNodeDegraded", reflect.TypeOf((*MockMachinery)(nil).IsNodeDegraded), arg0)
+}
+
 // IsNodeUpgrading mocks base method.
 func (m *MockMachinery) IsNodeUpgrading(arg0 *v1.Node) bool {
 	m.ctrl.T.Helper()
@@ -125,3 +153,18 @@ func (mr *MockMachineryMockRecorder) IsUpgrading(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUpgrading", reflect.TypeOf((*MockMachinery)(nil).IsUpgrading), arg0, arg1)
 }
+
+// IsUpgradingPools mocks base method.
+func (m *MockMachinery) IsUpgradingPools(arg0 client.Client) ([]*machinery.UpgradingResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUpgradingPools", arg0)
+	ret0, _ := ret[0].([]*machinery.UpgradingResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUpgradingPools indicates an expected call of IsUpgradingPools.
+func (mr *MockMachineryMockRecorder) IsUpgradingPools(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUpgradingPools", reflect.TypeOf((*MockMachinery)(nil).IsUpgradingPools), arg0)
+}
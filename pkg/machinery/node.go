@@ -10,6 +10,8 @@ const (
 	MachineConfigDaemonStateAnnotationKey = "machineconfiguration.openshift.io/state"
 	// MachineConfigDaemonStateWorking is set by daemon when it is applying an update.
 	MachineConfigDaemonStateWorking = "Working"
+	// MachineConfigDaemonStateDegraded is set by daemon when it failed to apply an update.
+	MachineConfigDaemonStateDegraded = "Degraded"
 )
 
 // IsCordonedResult is a type that holds cordoned information
@@ -46,6 +48,11 @@ func (m *machinery) IsNodeUpgrading(node *corev1.Node) bool {
 	}
 }
 
+// IsNodeDegraded returns true if the machine config daemon failed to apply an update to the node
+func (m *machinery) IsNodeDegraded(node *corev1.Node) bool {
+	return node.Annotations[MachineConfigDaemonStateAnnotationKey] == MachineConfigDaemonStateDegraded
+}
+
 func (m *machinery) HasMemoryPressure(node *corev1.Node) bool {
 	if len(node.Spec.Taints) > 0 {
 		// Only check if there are taints
@@ -81,3 +88,40 @@ func (m *machinery) HasPidPressure(node *corev1.Node) bool {
 	}
 	return false
 }
+
+// NodeConditionsResult is a type that holds the unhealthy conditions reported for a node
+type NodeConditionsResult struct {
+	// IsReady is true when the kubelet reports the node as Ready
+	IsReady bool
+	// IsUnknown is true when the kubelet has stopped posting the node status
+	IsUnknown bool
+	// Pressure lists the memory, disk or PID pressure the node is tainted for
+	Pressure []corev1.NodeConditionType
+}
+
+// IsHealthy returns true if the node is ready and has no pressure conditions
+func (r *NodeConditionsResult) IsHealthy() bool {
+	return r.IsReady && len(r.Pressure) == 0
+}
+
+// GetNodeConditions returns a NodeConditionsResult built from the node status conditions and pressure taints
+func (m *machinery) GetNodeConditions(node *corev1.Node) *NodeConditionsResult {
+	result := &NodeConditionsResult{IsUnknown: true}
+	for _, c := range node.Status.Conditions {
+		switch c.Type {
+		case corev1.NodeReady:
+			result.IsReady = c.Status == corev1.ConditionTrue
+			result.IsUnknown = c.Status == corev1.ConditionUnknown
+		}
+	}
+	if m.HasMemoryPressure(node) {
+		result.Pressure = append(result.Pressure, corev1.NodeMemoryPressure)
+	}
+	if m.HasDiskPressure(node) {
+		result.Pressure = append(result.Pressure, corev1.NodeDiskPressure)
+	}
+	if m.HasPidPressure(node) {
+		result.Pressure = append(result.Pressure, corev1.NodePIDPressure)
+	}
+	return result
+}
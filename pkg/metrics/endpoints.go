@@ -0,0 +1,256 @@
+package metrics
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/openshift/managed-upgrade-operator/config"
+)
+
+const (
+	thanosQuerierApp = "thanos-querier"
+	// prometheusOperatedService is the headless service giving each prometheus-k8s replica a DNS name
+	prometheusOperatedService = "prometheus-operated"
+
+	thanosQuerierTimeout  = 30 * time.Second
+	prometheusTimeout     = 15 * time.Second
+	externalThanosTimeout = 60 * time.Second
+
+	// endpointBackoff is how long a query endpoint that failed is tried after the healthy ones
+	endpointBackoff = time.Minute
+)
+
+var log = logf.Log.WithName("metrics")
+
+// queryEndpointHealth is shared by the metrics clients, which are built for each reconcile,
+// so that the endpoints that failed are tried last across them
+var queryEndpointHealth = newEndpointHealth()
+
+// queryEndpoint is a Prometheus compatible query API the metrics client can query
+type queryEndpoint struct {
+	// name identifies the endpoint in query results and errors
+	name string
+	// url is the base URL of the endpoint, e.g. https://thanos-querier.openshift-monitoring.svc.cluster.local:9091
+	url    string
+	client http.Client
+}
+
+// endpointHealth records, by URL, until when the query endpoints that failed are tried after the healthy ones
+type endpointHealth struct {
+	mutex          sync.Mutex
+	unhealthyUntil map[string]time.Time
+}
+
+func newEndpointHealth() *endpointHealth {
+	return &endpointHealth{unhealthyUntil: map[string]time.Time{}}
+}
+
+// queryEndpoints is an ordered list of query endpoints
+type queryEndpoints struct {
+	endpoints []*queryEndpoint
+	health    *endpointHealth
+}
+
+// newQueryEndpoints resolves, in order of preference, the thanos-querier, each prometheus-k8s replica and
+// the optional external Thanos endpoint. Endpoints that cannot be resolved are left out.
+func newQueryEndpoints(c client.Client, token string, tlsConfig *tls.Config) (*queryEndpoints, error) {
+	newClient := func(timeout time.Duration, tlsConfig *tls.Config) http.Client {
+		return http.Client{
+			Timeout:   timeout,
+			Transport: &prometheusRoundTripper{token: token, tls: tlsConfig},
+		}
+	}
+
+	var endpoints []*queryEndpoint
+	var errs []string
+	thanosTarget, err := NetworkTarget(c, MonitoringNS, thanosQuerierApp, "web")
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("%s: %s", thanosQuerierApp, err))
+	} else {
+		endpoints = append(endpoints, &queryEndpoint{
+			name:   thanosQuerierApp,
+			url:    "https://" + thanosTarget,
+			client: newClient(thanosQuerierTimeout, tlsConfig),
+		})
+	}
+
+	promTarget, err := NetworkTarget(c, MonitoringNS, promApp, "web")
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("%s: %s", promApp, err))
+	} else {
+		endpoints = append(endpoints, prometheusEndpoints(c, promTarget, tlsConfig, newClient)...)
+	}
+
+	if externalURL := config.ExternalThanosURL(); externalURL != "" {
+		externalTLSConfig := tlsConfig.Clone()
+		// The external Thanos is not served with the cluster's service CA
+		externalTLSConfig.RootCAs, _ = x509.SystemCertPool()
+		endpoints = append(endpoints, &queryEndpoint{
+			name:   "external-thanos",
+			url:    strings.TrimSuffix(externalURL, "/"),
+			client: newClient(externalThanosTimeout, externalTLSConfig),
+		})
+	}
+
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no prometheus query endpoint found: %s", strings.Join(errs, "; "))
+	}
+	return &queryEndpoints{endpoints: endpoints, health: queryEndpointHealth}, nil
+}
+
+// prometheusEndpoints returns an endpoint for each prometheus-k8s replica, or the endpoint of the service
+// balancing across them when they can't be addressed individually
+func prometheusEndpoints(c client.Client, promTarget string, tlsConfig *tls.Config, newClient func(time.Duration, *tls.Config) http.Client) []*queryEndpoint {
+	service := []*queryEndpoint{{
+		name:   promApp,
+		url:    "https://" + promTarget,
+		client: newClient(prometheusTimeout, tlsConfig),
+	}}
+	// Routes balance across the replicas, which cannot be addressed individually
+	if isRunModeLocal() && config.UseRoutes() {
+		return service
+	}
+	replicas, err := prometheusReplicas(c)
+	if err != nil {
+		log.Info(fmt.Sprintf("Unable to get the %s replicas, querying them through their service: %s", promApp, err))
+		return service
+	}
+
+	// The replicas serve the certificate of the prometheus-k8s service
+	replicaTLSConfig := tlsConfig.Clone()
+	replicaTLSConfig.ServerName = promApp + "." + MonitoringNS + ".svc"
+	host, port, _ := strings.Cut(promTarget, ":")
+	var endpoints []*queryEndpoint
+	for i := 0; i < replicas; i++ {
+		replica := fmt.Sprintf("%s-%d", promApp, i)
+		replicaHost := strings.Replace(host, promApp+"."+MonitoringNS, replica+"."+prometheusOperatedService+"."+MonitoringNS, 1)
+		if port != "" {
+			replicaHost = replicaHost + ":" + port
+		}
+		endpoints = append(endpoints, &queryEndpoint{
+			name:   replica,
+			url:    "https://" + replicaHost,
+			client: newClient(prometheusTimeout, replicaTLSConfig),
+		})
+	}
+	return endpoints
+}
+
+// prometheusReplicas returns the number of replicas of the prometheus-k8s StatefulSet
+func prometheusReplicas(c client.Client) (int, error) {
+	sts := &appsv1.StatefulSet{}
+	err := c.Get(context.TODO(), types.NamespacedName{Namespace: MonitoringNS, Name: promApp}, sts)
+	if err != nil {
+		return 0, err
+	}
+	if sts.Spec.Replicas == nil {
+		return 1, nil
+	}
+	if *sts.Spec.Replicas < 1 {
+		return 0, fmt.Errorf("statefulset %s is scaled down", promApp)
+	}
+	return int(*sts.Spec.Replicas), nil
+}
+
+// ordered returns the healthy endpoints in order of preference, followed by the unhealthy ones
+func (qe *queryEndpoints) ordered() []*queryEndpoint {
+	qe.health.mutex.Lock()
+	defer qe.health.mutex.Unlock()
+
+	now := time.Now()
+	var healthy, unhealthy []*queryEndpoint
+	for _, e := range qe.endpoints {
+		if now.Before(qe.health.unhealthyUntil[e.url]) {
+			unhealthy = append(unhealthy, e)
+		} else {
+			healthy = append(healthy, e)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// setHealthy records the outcome of a query to the endpoint
+func (qe *queryEndpoints) setHealthy(e *queryEndpoint, healthy bool) {
+	qe.health.mutex.Lock()
+	defer qe.health.mutex.Unlock()
+
+	if healthy {
+		delete(qe.health.unhealthyUntil, e.url)
+	} else {
+		qe.health.unhealthyUntil[e.url] = time.Now().Add(endpointBackoff)
+	}
+}
+
+// query runs the query against each endpoint in turn until one answers. The response records
+// the endpoint that answered.
+func (qe *queryEndpoints) query(query string) (*AlertResponse, error) {
+	var errs []string
+	for _, e := range qe.ordered() {
+		result, failover, err := e.query(query)
+		if err == nil {
+			qe.setHealthy(e, true)
+			result.Endpoint = e.name
+			if len(errs) > 0 {
+				log.Info(fmt.Sprintf("Prometheus query answered by %s after failing over: %s", e.name, strings.Join(errs, "; ")))
+			}
+			return result, nil
+		}
+		if !failover {
+			return nil, err
+		}
+		qe.setHealthy(e, false)
+		errs = append(errs, fmt.Sprintf("%s: %s", e.name, err))
+	}
+	return nil, fmt.Errorf("could not query prometheus: %s", strings.Join(errs, "; "))
+}
+
+// query runs the query against the endpoint. It reports whether another endpoint
+// may answer a query that failed.
+func (e *queryEndpoint) query(query string) (*AlertResponse, bool, error) {
+	req, err := http.NewRequest("GET", e.url+"/api/v1/query", nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not query prometheus: %s", err)
+	}
+
+	q := req.URL.Query()
+	q.Add("query", query)
+	req.URL.RawQuery = q.Encode()
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, fmt.Errorf("error when querying prometheus: %s", err)
+	}
+	// Bad queries fail the same way on every endpoint. Any other error, such as the server errors
+	// returned while the monitoring stack restarts during the upgrade, may not.
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusBadRequest, http.StatusUnprocessableEntity:
+	default:
+		return nil, true, fmt.Errorf("prometheus returned %s", resp.Status)
+	}
+
+	result := &AlertResponse{}
+	err = json.Unmarshal(body, result)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return result, false, nil
+}
@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+)
+
+var _ = Describe("Query endpoints", func() {
+	var (
+		thanos    *httptest.Server
+		replica   *httptest.Server
+		endpoints *queryEndpoints
+
+		thanosStatus  int
+		thanosQueries int
+	)
+
+	alertResponse := `{"status":"success","data":{"result":[{"metric":{"alertname":"Watchdog"}}]}}`
+
+	BeforeEach(func() {
+		thanosStatus = http.StatusOK
+		thanosQueries = 0
+		thanos = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			thanosQueries++
+			w.WriteHeader(thanosStatus)
+			_, _ = fmt.Fprint(w, alertResponse)
+		}))
+		replica = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Path).To(Equal("/api/v1/query"))
+			Expect(r.URL.Query().Get("query")).To(Equal("ALERTS"))
+			_, _ = fmt.Fprint(w, alertResponse)
+		}))
+		endpoints = &queryEndpoints{
+			endpoints: []*queryEndpoint{
+				{name: thanosQuerierApp, url: thanos.URL, client: http.Client{Timeout: time.Second}},
+				{name: "prometheus-k8s-0", url: replica.URL, client: http.Client{Timeout: time.Second}},
+			},
+			health: newEndpointHealth(),
+		}
+	})
+
+	AfterEach(func() {
+		thanos.Close()
+		replica.Close()
+	})
+
+	It("queries the first endpoints and reports it answered", func() {
+		result, err := endpoints.query("ALERTS")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Endpoint).To(Equal(thanosQuerierApp))
+		Expect(result.Data.Result).To(HaveLen(1))
+	})
+
+	It("fails over to the next endpoints and tries the failed endpoints last until it recovers", func() {
+		thanosStatus = http.StatusServiceUnavailable
+		result, err := endpoints.query("ALERTS")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Endpoint).To(Equal("prometheus-k8s-0"))
+		Expect(thanosQueries).To(Equal(1))
+
+		result, err = endpoints.query("ALERTS")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Endpoint).To(Equal("prometheus-k8s-0"))
+		Expect(thanosQueries).To(Equal(1))
+
+		endpoints.health.unhealthyUntil[thanos.URL] = time.Now()
+		thanosStatus = http.StatusOK
+		result, err = endpoints.query("ALERTS")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Endpoint).To(Equal(thanosQuerierApp))
+	})
+
+	It("keeps the health of the endpoints across the clients sharing it", func() {
+		thanosStatus = http.StatusServiceUnavailable
+		_, err := endpoints.query("ALERTS")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(thanosQueries).To(Equal(1))
+
+		thanosStatus = http.StatusOK
+		next := &queryEndpoints{
+			endpoints: []*queryEndpoint{
+				{name: thanosQuerierApp, url: thanos.URL, client: http.Client{Timeout: time.Second}},
+				{name: "prometheus-k8s-0", url: replica.URL, client: http.Client{Timeout: time.Second}},
+			},
+			health: endpoints.health,
+		}
+		result, err := next.query("ALERTS")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Endpoint).To(Equal("prometheus-k8s-0"))
+		Expect(thanosQueries).To(Equal(1))
+	})
+
+	It("reports the error of every endpoints when none answers", func() {
+		thanosStatus = http.StatusBadGateway
+		replica.Close()
+		_, err := endpoints.query("ALERTS")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("thanos-querier: prometheus returned 502 Bad Gateway"))
+		Expect(err.Error()).To(ContainSubstring("prometheus-k8s-0: "))
+	})
+
+	It("does not fail over a bad query", func() {
+		thanosStatus = http.StatusBadRequest
+		result, err := endpoints.query("ALERTS")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Endpoint).To(Equal(thanosQuerierApp))
+		Expect(endpoints.ordered()[0].name).To(Equal(thanosQuerierApp))
+	})
+})
+
+var _ = Describe("Prometheus endpoints", func() {
+	var (
+		mockCtrl       *gomock.Controller
+		mockKubeClient *mocks.MockClient
+		newClient      func(time.Duration, *tls.Config) http.Client
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		newClient = func(timeout time.Duration, _ *tls.Config) http.Client {
+			return http.Client{Timeout: timeout}
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("queries each replica of the prometheus-k8s StatefulSet", func() {
+		replicas := int32(3)
+		sts := appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Replicas: &replicas}}
+		mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Namespace: MonitoringNS, Name: promApp}, gomock.Any()).SetArg(2, sts).Return(nil)
+		endpoints := prometheusEndpoints(mockKubeClient, "prometheus-k8s.openshift-monitoring.svc.cluster.local:9091", &tls.Config{}, newClient)
+		Expect(endpoints).To(HaveLen(3))
+		Expect(endpoints[2].name).To(Equal("prometheus-k8s-2"))
+		Expect(endpoints[2].url).To(Equal("https://prometheus-k8s-2.prometheus-operated.openshift-monitoring.svc.cluster.local:9091"))
+	})
+
+	It("queries the prometheus-k8s service when the replicas can't be listed", func() {
+		mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error"))
+		endpoints := prometheusEndpoints(mockKubeClient, "prometheus-k8s.openshift-monitoring.svc.cluster.local:9091", &tls.Config{}, newClient)
+		Expect(endpoints).To(HaveLen(1))
+		Expect(endpoints[0].name).To(Equal(promApp))
+		Expect(endpoints[0].url).To(Equal("https://prometheus-k8s.openshift-monitoring.svc.cluster.local:9091"))
+	})
+})
@@ -4,9 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net"
 	"net/http"
 	"os"
@@ -27,12 +25,13 @@ import (
 )
 
 const (
-	eventLabel   = "event"
-	metricsTag   = "upgradeoperator"
-	nameLabel    = "upgradeconfig_name"
-	nodeLabel    = "node_name"
-	alertsLabel  = "alerts"
-	failedReason = "reason"
+	eventLabel       = "event"
+	metricsTag       = "upgradeoperator"
+	nameLabel        = "upgradeconfig_name"
+	nodeLabel        = "node_name"
+	alertsLabel      = "alerts"
+	failedReason     = "reason"
+	destinationLabel = "destination"
 
 	Namespace = "upgradeoperator"
 	Subsystem = "upgrade"
@@ -64,11 +63,30 @@ const (
 	ClusterNodeQueryFailed           = "cluster_node_query_failed"
 	ClusterNodesManuallyCordoned     = "cluster_node_manually_cordoned"
 	ClusterNodesTaintedUnschedulable = "cluster_node_taint_unschedulable"
+	ClusterNodesUnhealthyConditions  = "cluster_node_unhealthy_conditions"
+	ClusterDrainSimulationFailed     = "cluster_drain_simulation_failed"
+	ClusterDrainInfeasible           = "cluster_drain_infeasible"
 	ClusterInvalidPDB                = "cluster_invalid_pdb"
 	ClusterInvalidPDBConf            = "cluster_invalid_pdb_configuration"
+	ClusterPDBDisruptionsBlocked     = "cluster_pdb_disruptions_blocked"
 	PDBQueryFailed                   = "pdb_query_failed"
 	DvoClientCreationFailed          = "dvo_client_creation_failed"
 	DvoMetricsQueryFailed            = "dvo_metrics_query_failed"
+	EtcdQueryFailed                  = "etcd_query_failed"
+	EtcdUnhealthy                    = "etcd_unhealthy"
+	DeprecatedAPIQueryFailed         = "deprecated_api_query_failed"
+	DeprecatedAPIsInUse              = "deprecated_apis_in_use"
+	OLMQueryFailed                   = "olm_query_failed"
+	OLMOperatorsIncompatible         = "olm_operators_incompatible"
+	MachineConfigPoolQueryFailed     = "machineconfigpool_query_failed"
+	MachineConfigPoolsNotSettled     = "machineconfigpools_not_settled"
+	CertificateQueryFailed           = "certificate_query_failed"
+	CertificatesAtRisk               = "certificates_at_risk"
+	KubeletVersionQueryFailed        = "kubelet_version_query_failed"
+	KubeletVersionSkewUnsupported    = "kubelet_version_skew_unsupported"
+	MonitoringUnavailable            = "monitoring_unavailable"
+	FallbackQueryFailed              = "fallback_query_failed"
+	FallbackClusterUnhealthy         = "fallback_cluster_unhealthy"
 )
 
 // Alerts sourced from https://github.com/openshift/managed-cluster-config/blob/master/deploy/sre-prometheus/100-managed-upgrade-operator.PrometheusRule.yaml
@@ -110,6 +128,9 @@ type Metrics interface {
 	ResetFailureMetrics()
 	ResetEphemeralMetrics()
 	UpdateMetricNotificationEventSent(string, string, string)
+	UpdateMetricNotificationOutbox(int, time.Duration)
+	UpdateMetricNotificationOutboxDropped(string, string, string)
+	UpdateMetricNotifierDisabled(string, bool)
 	UpdateMetricUpgradeResult(string, string, string, string, []string)
 	AlertsFromUpgrade(time.Time, time.Time) ([]string, error)
 	IsAlertFiring(alert string, checkedNS, ignoredNS []string) (bool, error)
@@ -130,11 +151,6 @@ func NewBuilder() MetricsBuilder {
 type metricsBuilder struct{}
 
 func (mb *metricsBuilder) NewClient(c client.Client) (Metrics, error) {
-	promTarget, err := NetworkTarget(c, MonitoringNS, promApp, "web")
-	if err != nil {
-		return nil, err
-	}
-
 	token, err := prometheusToken(c)
 	if err != nil {
 		return nil, err
@@ -152,14 +168,13 @@ func (mb *metricsBuilder) NewClient(c client.Client) (Metrics, error) {
 		}
 	}
 
+	endpoints, err := newQueryEndpoints(c, *token, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Counter{
-		promTarget: promTarget,
-		promClient: http.Client{
-			Transport: &prometheusRoundTripper{
-				token: *token,
-				tls:   tlsConfig,
-			},
-		},
+		endpoints: endpoints,
 	}, nil
 }
 
@@ -219,8 +234,7 @@ func (prt *prometheusRoundTripper) RoundTrip(req *http.Request) (*http.Response,
 }
 
 type Counter struct {
-	promClient http.Client
-	promTarget string
+	endpoints *queryEndpoints
 }
 
 var (
@@ -273,6 +287,26 @@ var (
 		Name: "upgrade_notification_failed",
 		Help: "Failed to send notification",
 	}, []string{nameLabel, eventLabel})
+	metricNotificationOutboxDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricsTag,
+		Name:      "notification_outbox_depth",
+		Help:      "Notifications waiting in the outbox to be sent again",
+	}, []string{})
+	metricNotificationOutboxAge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricsTag,
+		Name:      "notification_outbox_oldest_age_seconds",
+		Help:      "Age of the oldest notification waiting in the outbox",
+	}, []string{})
+	metricNotificationOutboxDropped = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricsTag,
+		Name:      "notification_outbox_dropped",
+		Help:      "Notification dropped from the outbox before it could be sent",
+	}, []string{eventLabel, VersionLabel, destinationLabel})
+	metricNotifierDisabled = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricsTag,
+		Name:      "notifier_disabled",
+		Help:      "Notifier left out as its configuration is invalid",
+	}, []string{destinationLabel})
 	metricUpgradeConfigSyncTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Subsystem: metricsTag,
 		Name:      "upgradeconfig_sync_timestamp",
@@ -332,6 +366,10 @@ var (
 	// persistentMetrics defines metrics whose data should not be cleared when an upgrade completes
 	persistentMetrics = []*prometheus.GaugeVec{
 		metricUpgradeResult,
+		metricNotificationOutboxDepth,
+		metricNotificationOutboxAge,
+		metricNotificationOutboxDropped,
+		metricNotifierDisabled,
 	}
 	metricsList = append(ephemeralMetrics, persistentMetrics...)
 )
@@ -452,6 +490,31 @@ func (c *Counter) UpdateMetricNotificationEventSent(upgradeConfigName string, ev
 		float64(1))
 }
 
+// UpdateMetricNotificationOutbox sets the number of queued notifications and the age of the oldest one
+func (c *Counter) UpdateMetricNotificationOutbox(depth int, oldestAge time.Duration) {
+	metricNotificationOutboxDepth.With(prometheus.Labels{}).Set(float64(depth))
+	metricNotificationOutboxAge.With(prometheus.Labels{}).Set(oldestAge.Seconds())
+}
+
+// UpdateMetricNotificationOutboxDropped records a queued notification dropped before it could be sent
+func (c *Counter) UpdateMetricNotificationOutboxDropped(event string, version string, destination string) {
+	metricNotificationOutboxDropped.With(prometheus.Labels{
+		eventLabel:       event,
+		VersionLabel:     version,
+		destinationLabel: destination}).Set(
+		float64(1))
+}
+
+// UpdateMetricNotifierDisabled sets whether the notifier of the destination is left out as it can't be configured
+func (c *Counter) UpdateMetricNotifierDisabled(destination string, disabled bool) {
+	value := float64(0)
+	if disabled {
+		value = float64(1)
+	}
+	metricNotifierDisabled.With(prometheus.Labels{
+		destinationLabel: destination}).Set(value)
+}
+
 func (c *Counter) UpdatemetricUpgradeNotificationFailed(upgradeConfigName string, event string) {
 	metricUpgradeNotificationFailed.With(prometheus.Labels{
 		eventLabel: event,
@@ -673,32 +736,13 @@ func getRouteHost(c client.Client, appName string) (string, error) {
 	return route.Spec.Host, nil
 }
 
+// Query runs the query against the first query endpoint that answers
 func (c *Counter) Query(query string) (*AlertResponse, error) {
-	req, err := http.NewRequest("GET", "https://"+c.promTarget+"/api/v1/query", nil)
-	if err != nil {
-		return nil, fmt.Errorf("could not query prometheus: %s", err)
-	}
-
-	q := req.URL.Query()
-	q.Add("query", query)
-	req.URL.RawQuery = q.Encode()
-	resp, err := c.promClient.Do(req)
+	result, err := c.endpoints.query(query)
 	if err != nil {
 		return nil, err
 	}
-
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error when querying prometheus: %s", err)
-	}
-
-	result := &AlertResponse{}
-	err = json.Unmarshal(body, result)
-	if err != nil {
-		return nil, err
-	}
-
+	log.V(1).Info("Prometheus query answered", "endpoint", result.Endpoint)
 	return result, nil
 }
 
@@ -747,6 +791,8 @@ func RequestPrometheusServiceAccountAPIToken(c client.Client) (string, error) {
 type AlertResponse struct {
 	Status string    `json:"status"`
 	Data   AlertData `json:"data"`
+	// Endpoint is the name of the query endpoint that answered
+	Endpoint string `json:"-"`
 }
 
 type AlertData struct {
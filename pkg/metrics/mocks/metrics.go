@@ -259,6 +259,42 @@ func (mr *MockMetricsMockRecorder) UpdateMetricNotificationEventSent(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricNotificationEventSent", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricNotificationEventSent), arg0, arg1, arg2)
 }
 
+// UpdateMetricNotificationOutbox mocks base method.
+func (m *MockMetrics) UpdateMetricNotificationOutbox(arg0 int, arg1 time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateMetricNotificationOutbox", arg0, arg1)
+}
+
+// UpdateMetricNotificationOutbox indicates an expected call of UpdateMetricNotificationOutbox.
+func (mr *MockMetricsMockRecorder) UpdateMetricNotificationOutbox(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricNotificationOutbox", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricNotificationOutbox), arg0, arg1)
+}
+
+// UpdateMetricNotificationOutboxDropped mocks base method.
+func (m *MockMetrics) UpdateMetricNotificationOutboxDropped(arg0, arg1, arg2 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateMetricNotificationOutboxDropped", arg0, arg1, arg2)
+}
+
+// UpdateMetricNotificationOutboxDropped indicates an expected call of UpdateMetricNotificationOutboxDropped.
+func (mr *MockMetricsMockRecorder) UpdateMetricNotificationOutboxDropped(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricNotificationOutboxDropped", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricNotificationOutboxDropped), arg0, arg1, arg2)
+}
+
+// UpdateMetricNotifierDisabled mocks base method.
+func (m *MockMetrics) UpdateMetricNotifierDisabled(arg0 string, arg1 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateMetricNotifierDisabled", arg0, arg1)
+}
+
+// UpdateMetricNotifierDisabled indicates an expected call of UpdateMetricNotifierDisabled.
+func (mr *MockMetricsMockRecorder) UpdateMetricNotifierDisabled(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricNotifierDisabled", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricNotifierDisabled), arg0, arg1)
+}
+
 // UpdateMetricScalingFailed mocks base method.
 func (m *MockMetrics) UpdateMetricScalingFailed(arg0 string) {
 	m.ctrl.T.Helper()
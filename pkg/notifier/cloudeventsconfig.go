@@ -0,0 +1,72 @@
+package notifier
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// CloudEventsMode is the HTTP content mode CloudEvents are sent in
+type CloudEventsMode string
+
+const (
+	// CloudEventsModeBinary sends the event attributes as ce- headers and the event data as the body
+	CloudEventsModeBinary CloudEventsMode = "binary"
+	// CloudEventsModeStructured sends the whole event as an application/cloudevents+json body
+	CloudEventsModeStructured CloudEventsMode = "structured"
+)
+
+// CloudEventsNotifierConfig holds the CloudEvents field for its CloudEvents configuration
+type CloudEventsNotifierConfig struct {
+	CloudEvents CloudEventsConfig `yaml:"cloudEvents"`
+}
+
+// CloudEventsConfig holds the configuration of the CloudEvents notifier
+type CloudEventsConfig struct {
+	// URL the events are posted to
+	URL string `yaml:"url"`
+	// Mode is the HTTP content mode, binary by default
+	Mode CloudEventsMode `yaml:"mode"`
+	// Source overrides the source attribute of the events, /clusters/<cluster ID>/managed-upgrade-operator by default
+	Source string `yaml:"source"`
+	// Headers added to the requests
+	Headers map[string]string `yaml:"headers"`
+	// SecretRef is the name of a Secret in the operator namespace holding the url and authorization of the endpoint
+	SecretRef string `yaml:"secretRef"`
+}
+
+// IsConfigured returns true if a CloudEvents endpoint has been configured
+func (cfg *CloudEventsNotifierConfig) IsConfigured() bool {
+	return cfg.CloudEvents.URL != "" || cfg.CloudEvents.SecretRef != ""
+}
+
+// IsValid returns a nil error when the CloudEventsNotifierConfig is valid
+func (cfg *CloudEventsNotifierConfig) IsValid() error {
+	if !cfg.IsConfigured() {
+		return nil
+	}
+	if cfg.CloudEvents.URL != "" {
+		if err := validateWebhookURL(cfg.CloudEvents.URL); err != nil {
+			return fmt.Errorf("cloudEvents: %v", err)
+		}
+	}
+	switch cfg.GetMode() {
+	case CloudEventsModeBinary, CloudEventsModeStructured:
+	default:
+		return fmt.Errorf("cloudEvents mode %q is not one of binary or structured", cfg.CloudEvents.Mode)
+	}
+	if cfg.CloudEvents.Source != "" {
+		if _, err := url.Parse(cfg.CloudEvents.Source); err != nil {
+			return fmt.Errorf("cloudEvents source %q is not a valid URI reference: %v", cfg.CloudEvents.Source, err)
+		}
+	}
+	return nil
+}
+
+// GetMode returns the HTTP content mode of the events, binary by default
+func (cfg *CloudEventsNotifierConfig) GetMode() CloudEventsMode {
+	if cfg.CloudEvents.Mode == "" {
+		return CloudEventsModeBinary
+	}
+	return CloudEventsMode(strings.ToLower(string(cfg.CloudEvents.Mode)))
+}
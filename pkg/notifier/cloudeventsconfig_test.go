@@ -0,0 +1,44 @@
+package notifier
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CloudEvents notifier config", func() {
+	var cfg CloudEventsNotifierConfig
+
+	BeforeEach(func() {
+		cfg = CloudEventsNotifierConfig{}
+	})
+
+	It("is valid when no endpoint is configured", func() {
+		Expect(cfg.IsConfigured()).To(BeFalse())
+		Expect(cfg.IsValid()).To(Succeed())
+	})
+
+	It("defaults to the binary mode", func() {
+		cfg.CloudEvents.URL = "https://example.com/events"
+		Expect(cfg.IsValid()).To(Succeed())
+		Expect(cfg.GetMode()).To(Equal(CloudEventsModeBinary))
+	})
+
+	It("accepts an endpoint held by a Secret only", func() {
+		cfg.CloudEvents.SecretRef = "cloudevents"
+		cfg.CloudEvents.Mode = "Structured"
+		Expect(cfg.IsConfigured()).To(BeTrue())
+		Expect(cfg.IsValid()).To(Succeed())
+		Expect(cfg.GetMode()).To(Equal(CloudEventsModeStructured))
+	})
+
+	It("rejects URLs that are not http(s)", func() {
+		cfg.CloudEvents.URL = "amqp://example.com/events"
+		Expect(cfg.IsValid()).NotTo(Succeed())
+	})
+
+	It("rejects unknown modes", func() {
+		cfg.CloudEvents.URL = "https://example.com/events"
+		cfg.CloudEvents.Mode = "batch"
+		Expect(cfg.IsValid()).NotTo(Succeed())
+	})
+})
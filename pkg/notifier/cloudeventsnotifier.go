@@ -0,0 +1,228 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	"github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
+)
+
+// cloudEventsSpecVersion is the version of the CloudEvents specification the events follow
+const cloudEventsSpecVersion = "1.0"
+
+// cloudEventTypePrefix prefixes the type of the events
+const cloudEventTypePrefix = "com.redhat.openshift.managed-upgrade-operator."
+
+// Types of the CloudEvents. The version suffix is bumped when the schema of the event data changes
+// in a way that is not backward compatible.
+const (
+	CloudEventTypeUpgradePending             = cloudEventTypePrefix + "upgrade.pending.v1"
+	CloudEventTypeUpgradeScheduled           = cloudEventTypePrefix + "upgrade.scheduled.v1"
+	CloudEventTypeUpgradeStarted             = cloudEventTypePrefix + "upgrade.started.v1"
+	CloudEventTypeUpgradeDelayed             = cloudEventTypePrefix + "upgrade.delayed.v1"
+	CloudEventTypeUpgradeSkipped             = cloudEventTypePrefix + "upgrade.skipped.v1"
+	CloudEventTypeUpgradeScaleSkipped        = cloudEventTypePrefix + "upgrade.scaleskipped.v1"
+	CloudEventTypeUpgradeCompleted           = cloudEventTypePrefix + "upgrade.completed.v1"
+	CloudEventTypeUpgradeFailed              = cloudEventTypePrefix + "upgrade.failed.v1"
+	CloudEventTypeUpgradeCancelled           = cloudEventTypePrefix + "upgrade.cancelled.v1"
+	CloudEventTypeUpgradeReminder            = cloudEventTypePrefix + "upgrade.reminder.v1"
+	CloudEventTypeControlPlaneUpgradeStarted = cloudEventTypePrefix + "controlplane.started.v1"
+	CloudEventTypeControlPlaneUpgraded       = cloudEventTypePrefix + "controlplane.completed.v1"
+	CloudEventTypeWorkersUpgraded            = cloudEventTypePrefix + "workers.completed.v1"
+	CloudEventTypeHealthCheckFailed          = cloudEventTypePrefix + "healthcheck.failed.v1"
+	CloudEventTypePreHealthCheckFailed       = cloudEventTypePrefix + "prehealthcheck.failed.v1"
+	CloudEventTypeDrainStrategyExecuted      = cloudEventTypePrefix + "node.drainstrategy.executed.v1"
+)
+
+// cloudEventTypes are the types of the events of the notified states
+var cloudEventTypes = map[MuoState]string{
+	MuoStatePending:                       CloudEventTypeUpgradePending,
+	MuoStateScheduled:                     CloudEventTypeUpgradeScheduled,
+	MuoStateStarted:                       CloudEventTypeUpgradeStarted,
+	MuoStateDelayed:                       CloudEventTypeUpgradeDelayed,
+	MuoStateSkipped:                       CloudEventTypeUpgradeSkipped,
+	MuoStateScaleSkipped:                  CloudEventTypeUpgradeScaleSkipped,
+	MuoStateCompleted:                     CloudEventTypeUpgradeCompleted,
+	MuoStateFailed:                        CloudEventTypeUpgradeFailed,
+	MuoStateCancelled:                     CloudEventTypeUpgradeCancelled,
+	MuoStateControlPlaneUpgradeStartedSL:  CloudEventTypeControlPlaneUpgradeStarted,
+	MuoStateControlPlaneUpgradeFinishedSL: CloudEventTypeControlPlaneUpgraded,
+	MuoStateWorkerPlaneUpgradeFinishedSL:  CloudEventTypeWorkersUpgraded,
+	MuoStateHealthCheckSL:                 CloudEventTypeHealthCheckFailed,
+	MuoStatePreHealthCheckSL:              CloudEventTypePreHealthCheckFailed,
+	MuoStateUpgradeReminderSL:             CloudEventTypeUpgradeReminder,
+}
+
+// CloudEventUpgradeData is the data of the upgrade lifecycle and health check events
+type CloudEventUpgradeData struct {
+	// State is the notified state, e.g. StateStarted
+	State MuoState `json:"state"`
+	// Summary is a short summary of the state
+	Summary string `json:"summary"`
+	// Description is the notification text
+	Description string `json:"description"`
+	// Version is the desired version of the upgrade
+	Version string `json:"version"`
+	// ClusterID is the ID of the cluster
+	ClusterID string `json:"clusterID"`
+}
+
+// CloudEventDrainData is the data of the node drain events
+type CloudEventDrainData struct {
+	// Node is the name of the drained node
+	Node string `json:"node"`
+	// Strategy is the name of the executed drain strategy, e.g. PDB-DELETE
+	Strategy string `json:"strategy"`
+	// Message describes the result of the drain strategy
+	Message string `json:"message"`
+	// Version is the desired version of the upgrade
+	Version string `json:"version"`
+	// ClusterID is the ID of the cluster
+	ClusterID string `json:"clusterID"`
+}
+
+// cloudEvent is a CloudEvent in its JSON format
+type cloudEvent struct {
+	SpecVersion     string      `json:"specversion"`
+	ID              string      `json:"id"`
+	Source          string      `json:"source"`
+	Type            string      `json:"type"`
+	Subject         string      `json:"subject,omitempty"`
+	Time            string      `json:"time"`
+	DataContentType string      `json:"datacontenttype"`
+	Data            interface{} `json:"data"`
+}
+
+// NewCloudEventsNotifier returns a cloudEventsNotifier
+func NewCloudEventsNotifier(client client.Client, cfg *CloudEventsNotifierConfig, upgradeConfigManager upgradeconfigmanager.UpgradeConfigManager, cvClient cv.ClusterVersion) (*cloudEventsNotifier, error) {
+	return &cloudEventsNotifier{
+		client:               client,
+		cfg:                  cfg.CloudEvents,
+		mode:                 cfg.GetMode(),
+		upgradeConfigManager: upgradeConfigManager,
+		cvClient:             cvClient,
+		httpClient:           newWebhookHTTPClient(),
+	}, nil
+}
+
+// A notifier that publishes CloudEvents over HTTP
+type cloudEventsNotifier struct {
+	// Cluster k8s client
+	client client.Client
+	// CloudEvents configuration
+	cfg CloudEventsConfig
+	// HTTP content mode of the events
+	mode CloudEventsMode
+	// Retrieves the upgrade config from the cluster
+	upgradeConfigManager upgradeconfigmanager.UpgradeConfigManager
+	// Retrieves the cluster ID
+	cvClient cv.ClusterVersion
+	// HTTP client posting the events
+	httpClient *http.Client
+}
+
+func (s *cloudEventsNotifier) NotifyState(state MuoState, description string) error {
+	eventType, ok := cloudEventTypes[state]
+	if !ok {
+		return fmt.Errorf("no CloudEvent type for state %s", state)
+	}
+	message, err := newNotificationMessage(state, description, s.upgradeConfigManager, s.cvClient)
+	if err != nil {
+		return err
+	}
+	return s.send(eventType, message.ClusterID, message.Version, CloudEventUpgradeData{
+		State:       message.State,
+		Summary:     message.Summary,
+		Description: message.Description,
+		Version:     message.Version,
+		ClusterID:   message.ClusterID,
+	})
+}
+
+func (s *cloudEventsNotifier) NotifyDrain(node string, strategy string, message string) error {
+	uc, err := s.upgradeConfigManager.Get()
+	if err != nil {
+		return fmt.Errorf("can't read UpgradeConfig: %v", err)
+	}
+	clusterID := s.cvClient.GetClusterId()
+	return s.send(CloudEventTypeDrainStrategyExecuted, clusterID, node, CloudEventDrainData{
+		Node:      node,
+		Strategy:  strategy,
+		Message:   message,
+		Version:   uc.Spec.Desired.Version,
+		ClusterID: clusterID,
+	})
+}
+
+// send posts an event of the type about the subject in the configured content mode
+func (s *cloudEventsNotifier) send(eventType string, clusterID string, subject string, data interface{}) error {
+	event := cloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              uuid.New().String(),
+		Source:          s.cfg.Source,
+		Type:            eventType,
+		Subject:         subject,
+		Time:            time.Now().UTC().Format(time.RFC3339),
+		DataContentType: "application/json",
+		Data:            data,
+	}
+	if event.Source == "" {
+		event.Source = fmt.Sprintf("/clusters/%s/managed-upgrade-operator", clusterID)
+	}
+
+	var body []byte
+	var err error
+	if s.mode == CloudEventsModeStructured {
+		body, err = json.Marshal(event)
+	} else {
+		body, err = json.Marshal(event.Data)
+	}
+	if err != nil {
+		return fmt.Errorf("can't encode CloudEvent: %v", err)
+	}
+
+	endpoint, authorization, err := readWebhookCredentials(s.client, s.cfg.SecretRef, s.cfg.URL)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("can't create CloudEvent request: %v", err)
+	}
+	for name, value := range s.cfg.Headers {
+		req.Header.Set(name, value)
+	}
+	if s.mode == CloudEventsModeStructured {
+		req.Header.Set("Content-Type", "application/cloudevents+json; charset=UTF-8")
+	} else {
+		req.Header.Set("Content-Type", event.DataContentType)
+		req.Header.Set("ce-specversion", event.SpecVersion)
+		req.Header.Set("ce-id", event.ID)
+		req.Header.Set("ce-source", event.Source)
+		req.Header.Set("ce-type", event.Type)
+		req.Header.Set("ce-subject", event.Subject)
+		req.Header.Set("ce-time", event.Time)
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("can't send CloudEvent: %v", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("CloudEvents endpoint returned %s", resp.Status)
+	}
+	return nil
+}
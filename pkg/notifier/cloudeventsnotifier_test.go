@@ -0,0 +1,148 @@
+package notifier
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	cvMocks "github.com/openshift/managed-upgrade-operator/pkg/clusterversion/mocks"
+	ucMgrMocks "github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager/mocks"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+)
+
+var _ = Describe("CloudEvents Notifier", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		mockKubeClient           *mocks.MockClient
+		mockUpgradeConfigManager *ucMgrMocks.MockUpgradeConfigManager
+		mockCVClient             *cvMocks.MockClusterVersion
+		server                   *httptest.Server
+		requests                 []*http.Request
+		bodies                   []string
+		status                   int
+		cfg                      *CloudEventsNotifierConfig
+		uc                       *upgradev1alpha1.UpgradeConfig
+	)
+
+	BeforeEach(func() {
+		_ = os.Setenv("OPERATOR_NAMESPACE", "test-namespace")
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockUpgradeConfigManager = ucMgrMocks.NewMockUpgradeConfigManager(mockCtrl)
+		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
+		requests = nil
+		bodies = nil
+		status = http.StatusAccepted
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			requests = append(requests, r)
+			bodies = append(bodies, string(body))
+			w.WriteHeader(status)
+		}))
+		cfg = &CloudEventsNotifierConfig{CloudEvents: CloudEventsConfig{URL: server.URL}}
+		uc = &upgradev1alpha1.UpgradeConfig{Spec: upgradev1alpha1.UpgradeConfigSpec{Desired: upgradev1alpha1.Update{Version: "4.14.2"}}}
+	})
+
+	AfterEach(func() {
+		server.Close()
+		mockCtrl.Finish()
+	})
+
+	newNotifier := func() *cloudEventsNotifier {
+		n, err := NewCloudEventsNotifier(mockKubeClient, cfg, mockUpgradeConfigManager, mockCVClient)
+		Expect(err).NotTo(HaveOccurred())
+		return n
+	}
+
+	It("has an event type for every notified state", func() {
+		for state := range stateSummaries {
+			Expect(cloudEventTypes).To(HaveKey(state))
+		}
+	})
+
+	It("posts a binary mode event", func() {
+		mockCVClient.EXPECT().GetClusterId().Return("cluster-id")
+		mockUpgradeConfigManager.EXPECT().Get().Return(uc, nil)
+		Expect(newNotifier().NotifyState(MuoStateStarted, "Cluster is currently being upgraded")).To(Succeed())
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Method).To(Equal(http.MethodPost))
+		Expect(requests[0].Header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(requests[0].Header.Get("ce-specversion")).To(Equal("1.0"))
+		Expect(requests[0].Header.Get("ce-type")).To(Equal(CloudEventTypeUpgradeStarted))
+		Expect(requests[0].Header.Get("ce-source")).To(Equal("/clusters/cluster-id/managed-upgrade-operator"))
+		Expect(requests[0].Header.Get("ce-subject")).To(Equal("4.14.2"))
+		Expect(requests[0].Header.Get("ce-id")).NotTo(BeEmpty())
+		Expect(requests[0].Header.Get("ce-time")).NotTo(BeEmpty())
+		data := CloudEventUpgradeData{}
+		Expect(json.Unmarshal([]byte(bodies[0]), &data)).To(Succeed())
+		Expect(data).To(Equal(CloudEventUpgradeData{
+			State:       MuoStateStarted,
+			Summary:     "Cluster upgrade started",
+			Description: "Cluster is currently being upgraded",
+			Version:     "4.14.2",
+			ClusterID:   "cluster-id",
+		}))
+	})
+
+	It("posts a structured mode event", func() {
+		cfg.CloudEvents.Mode = CloudEventsModeStructured
+		cfg.CloudEvents.Source = "/fleet/cluster-id"
+		mockCVClient.EXPECT().GetClusterId().Return("cluster-id")
+		mockUpgradeConfigManager.EXPECT().Get().Return(uc, nil)
+		Expect(newNotifier().NotifyState(MuoStateHealthCheckSL, "Critical alerts are firing")).To(Succeed())
+		Expect(requests[0].Header.Get("Content-Type")).To(Equal("application/cloudevents+json; charset=UTF-8"))
+		Expect(requests[0].Header.Get("ce-type")).To(BeEmpty())
+		event := map[string]interface{}{}
+		Expect(json.Unmarshal([]byte(bodies[0]), &event)).To(Succeed())
+		Expect(event).To(HaveKeyWithValue("specversion", "1.0"))
+		Expect(event).To(HaveKeyWithValue("type", CloudEventTypeHealthCheckFailed))
+		Expect(event).To(HaveKeyWithValue("source", "/fleet/cluster-id"))
+		Expect(event).To(HaveKeyWithValue("datacontenttype", "application/json"))
+		Expect(event["data"]).To(HaveKeyWithValue("description", "Critical alerts are firing"))
+	})
+
+	It("posts the drain strategies executed on a node", func() {
+		mockCVClient.EXPECT().GetClusterId().Return("cluster-id")
+		mockUpgradeConfigManager.EXPECT().Get().Return(uc, nil)
+		Expect(newNotifier().NotifyDrain("worker-1", "PDB-DELETE", "deleted 2 pods")).To(Succeed())
+		Expect(requests[0].Header.Get("ce-type")).To(Equal(CloudEventTypeDrainStrategyExecuted))
+		Expect(requests[0].Header.Get("ce-subject")).To(Equal("worker-1"))
+		data := CloudEventDrainData{}
+		Expect(json.Unmarshal([]byte(bodies[0]), &data)).To(Succeed())
+		Expect(data).To(Equal(CloudEventDrainData{
+			Node:      "worker-1",
+			Strategy:  "PDB-DELETE",
+			Message:   "deleted 2 pods",
+			Version:   "4.14.2",
+			ClusterID: "cluster-id",
+		}))
+	})
+
+	It("reads the URL and authorization from the Secret", func() {
+		cfg.CloudEvents.URL = ""
+		cfg.CloudEvents.SecretRef = "cloudevents"
+		secret := corev1.Secret{Data: map[string][]byte{"url": []byte(server.URL), "authorization": []byte("Bearer token")}}
+		mockCVClient.EXPECT().GetClusterId().Return("cluster-id")
+		mockUpgradeConfigManager.EXPECT().Get().Return(uc, nil)
+		mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, secret)
+		Expect(newNotifier().NotifyState(MuoStateCompleted, "Cluster has been upgraded")).To(Succeed())
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Header.Get("Authorization")).To(Equal("Bearer token"))
+	})
+
+	It("returns an error when the endpoint does not accept the event", func() {
+		status = http.StatusBadRequest
+		mockCVClient.EXPECT().GetClusterId().Return("cluster-id")
+		mockUpgradeConfigManager.EXPECT().Get().Return(uc, nil)
+		Expect(newNotifier().NotifyState(MuoStateStarted, "Cluster is currently being upgraded")).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,67 @@
+package notifier
+
+import (
+	"fmt"
+	"net/mail"
+)
+
+// defaultSMTPPort is the SMTP submission port
+const defaultSMTPPort = 587
+
+const (
+	// emailSecretUsernameKey is the key of the email Secret holding the SMTP username
+	emailSecretUsernameKey = "username"
+	// emailSecretPasswordKey is the key of the email Secret holding the SMTP password
+	emailSecretPasswordKey = "password"
+	// emailSecretToKey is the key of the email Secret holding the comma separated recipients
+	emailSecretToKey = "to"
+	// emailSecretCcKey is the key of the email Secret holding the comma separated carbon copy recipients
+	emailSecretCcKey = "cc"
+)
+
+// EmailNotifierConfig holds the Email field for its email configuration
+type EmailNotifierConfig struct {
+	Email EmailConfig `yaml:"email"`
+}
+
+// EmailConfig holds the configuration of the email notifier
+type EmailConfig struct {
+	// Host is the SMTP server, which must support STARTTLS
+	Host string `yaml:"host"`
+	// Port is the SMTP port, 587 by default
+	Port int `yaml:"port"`
+	// From is the sender address
+	From string `yaml:"from"`
+	// SecretRef is the name of a Secret in the operator namespace holding the recipients and SMTP credentials
+	SecretRef string `yaml:"secretRef"`
+}
+
+// IsConfigured returns true if an SMTP server has been configured
+func (cfg *EmailNotifierConfig) IsConfigured() bool {
+	return cfg.Email.Host != ""
+}
+
+// IsValid returns a nil error when the EmailNotifierConfig is valid
+func (cfg *EmailNotifierConfig) IsValid() error {
+	if !cfg.IsConfigured() {
+		return nil
+	}
+	if cfg.Email.Port < 0 || cfg.Email.Port > 65535 {
+		return fmt.Errorf("email port %d is not a valid port", cfg.Email.Port)
+	}
+	if _, err := mail.ParseAddress(cfg.Email.From); err != nil {
+		return fmt.Errorf("email sender %q is not a valid address: %v", cfg.Email.From, err)
+	}
+	if cfg.Email.SecretRef == "" {
+		return fmt.Errorf("email secretRef must be set to provide the recipients")
+	}
+	return nil
+}
+
+// GetPort returns the SMTP port
+func (cfg *EmailNotifierConfig) GetPort() int {
+	if cfg.Email.Port == 0 {
+		return defaultSMTPPort
+	}
+	return cfg.Email.Port
+}
@@ -0,0 +1,294 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	"github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
+	"github.com/openshift/managed-upgrade-operator/util"
+)
+
+// smtpTimeout is the time allowed to send an email
+const smtpTimeout = 30 * time.Second
+
+// emailStates are the states notified by email
+var emailStates = map[MuoState]bool{
+	MuoStateStarted:           true,
+	MuoStateDelayed:           true,
+	MuoStateFailed:            true,
+	MuoStateCompleted:         true,
+	MuoStateHealthCheckSL:     true,
+	MuoStatePreHealthCheckSL:  true,
+	MuoStateUpgradeReminderSL: true,
+}
+
+var (
+	emailSubjectTemplate = template.Must(template.New("subject").Parse(
+		`[{{ .ClusterID }}] {{ .Summary }}: {{ .Version }}`))
+	emailTextTemplate = template.Must(template.New("text").Parse(`{{ .Summary }}
+
+{{ .Description }}
+
+Cluster: {{ .ClusterID }}
+Version: {{ .Version }}
+Time:    {{ .Time }}
+`))
+	emailHTMLTemplate = htmltemplate.Must(htmltemplate.New("html").Parse(`<html>
+<body>
+<h2>{{ .Summary }}</h2>
+<p>{{ .Description }}</p>
+<table>
+<tr><th align="left">Cluster</th><td>{{ .ClusterID }}</td></tr>
+<tr><th align="left">Version</th><td>{{ .Version }}</td></tr>
+<tr><th align="left">Time</th><td>{{ .Time }}</td></tr>
+</table>
+</body>
+</html>
+`))
+)
+
+// smtpSender sends a message to the recipients through the SMTP server at addr
+type smtpSender func(addr string, host string, auth smtp.Auth, from string, recipients []string, msg []byte) error
+
+// NewEmailNotifier returns an emailNotifier
+func NewEmailNotifier(client client.Client, cfg *EmailNotifierConfig, upgradeConfigManager upgradeconfigmanager.UpgradeConfigManager, cvClient cv.ClusterVersion) (*emailNotifier, error) {
+	from, err := mail.ParseAddress(cfg.Email.From)
+	if err != nil {
+		return nil, fmt.Errorf("email sender %q is not a valid address: %v", cfg.Email.From, err)
+	}
+	return &emailNotifier{
+		client:               client,
+		host:                 cfg.Email.Host,
+		port:                 cfg.GetPort(),
+		from:                 from,
+		secretRef:            cfg.Email.SecretRef,
+		upgradeConfigManager: upgradeConfigManager,
+		cvClient:             cvClient,
+		send:                 sendMailStartTLS,
+	}, nil
+}
+
+// A notifier that sends emails over SMTP
+type emailNotifier struct {
+	// Cluster k8s client
+	client client.Client
+	// SMTP server host and port
+	host string
+	port int
+	// Sender address
+	from *mail.Address
+	// Secret holding the recipients and SMTP credentials
+	secretRef string
+	// Retrieves the upgrade config from the cluster
+	upgradeConfigManager upgradeconfigmanager.UpgradeConfigManager
+	// Retrieves the cluster ID
+	cvClient cv.ClusterVersion
+	// Sends the emails
+	send smtpSender
+}
+
+// emailRecipients holds the recipients and SMTP credentials read from the email Secret
+type emailRecipients struct {
+	to       []string
+	cc       []string
+	username string
+	password string
+}
+
+func (s *emailNotifier) NotifyState(state MuoState, description string) error {
+	if !emailStates[state] {
+		return nil
+	}
+
+	recipients, err := s.recipients()
+	if err != nil {
+		return err
+	}
+	message, err := newNotificationMessage(state, description, s.upgradeConfigManager, s.cvClient)
+	if err != nil {
+		return err
+	}
+	msg, err := buildEmail(s.from, recipients.to, recipients.cc, message)
+	if err != nil {
+		return fmt.Errorf("can't build email: %v", err)
+	}
+
+	var auth smtp.Auth
+	if recipients.username != "" {
+		auth = smtp.PlainAuth("", recipients.username, recipients.password, s.host)
+	}
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+	err = s.send(addr, s.host, auth, s.from.Address, append(recipients.to, recipients.cc...), msg)
+	if err != nil {
+		return fmt.Errorf("can't send email notification: %v", err)
+	}
+	return nil
+}
+
+// recipients reads the recipients and SMTP credentials from the email Secret
+func (s *emailNotifier) recipients() (*emailRecipients, error) {
+	ns, err := util.GetOperatorNamespace()
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{}
+	err = s.client.Get(context.TODO(), client.ObjectKey{Name: s.secretRef, Namespace: ns}, secret)
+	if err != nil {
+		return nil, fmt.Errorf("can't read email secret %s: %v", s.secretRef, err)
+	}
+
+	to, err := parseAddressList(string(secret.Data[emailSecretToKey]))
+	if err != nil {
+		return nil, fmt.Errorf("email secret %s has invalid recipients: %v", s.secretRef, err)
+	}
+	if len(to) == 0 {
+		return nil, fmt.Errorf("email secret %s has no recipients", s.secretRef)
+	}
+	cc, err := parseAddressList(string(secret.Data[emailSecretCcKey]))
+	if err != nil {
+		return nil, fmt.Errorf("email secret %s has invalid carbon copy recipients: %v", s.secretRef, err)
+	}
+	return &emailRecipients{
+		to:       to,
+		cc:       cc,
+		username: string(secret.Data[emailSecretUsernameKey]),
+		password: string(secret.Data[emailSecretPasswordKey]),
+	}, nil
+}
+
+// parseAddressList returns the addresses of a comma separated list of addresses
+func parseAddressList(list string) ([]string, error) {
+	if strings.TrimSpace(list) == "" {
+		return nil, nil
+	}
+	parsed, err := mail.ParseAddressList(list)
+	if err != nil {
+		return nil, err
+	}
+	var addresses []string
+	for _, a := range parsed {
+		addresses = append(addresses, a.Address)
+	}
+	return addresses, nil
+}
+
+// buildEmail renders the message as a multipart email with plain text and HTML alternatives
+func buildEmail(from *mail.Address, to []string, cc []string, message NotificationMessage) ([]byte, error) {
+	subject := &strings.Builder{}
+	if err := emailSubjectTemplate.Execute(subject, message); err != nil {
+		return nil, err
+	}
+
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	parts := []struct {
+		contentType string
+		render      func(w *quotedprintable.Writer) error
+	}{
+		{"text/plain; charset=UTF-8", func(w *quotedprintable.Writer) error { return emailTextTemplate.Execute(w, message) }},
+		{"text/html; charset=UTF-8", func(w *quotedprintable.Writer) error { return emailHTMLTemplate.Execute(w, message) }},
+	}
+	for _, p := range parts {
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qw := quotedprintable.NewWriter(pw)
+		if err := p.render(qw); err != nil {
+			return nil, err
+		}
+		if err := qw.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	msg := &bytes.Buffer{}
+	fmt.Fprintf(msg, "From: %s\r\n", from.String())
+	fmt.Fprintf(msg, "To: %s\r\n", strings.Join(to, ", "))
+	if len(cc) > 0 {
+		fmt.Fprintf(msg, "Cc: %s\r\n", strings.Join(cc, ", "))
+	}
+	fmt.Fprintf(msg, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", subject.String()))
+	fmt.Fprintf(msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(msg, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", mw.Boundary())
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+// sendMailStartTLS sends the message like smtp.SendMail, but fails instead of sending
+// in plain text when the server does not support STARTTLS
+func sendMailStartTLS(addr string, host string, auth smtp.Auth, from string, recipients []string, msg []byte) error {
+	conn, err := net.DialTimeout("tcp", addr, smtpTimeout)
+	if err != nil {
+		return err
+	}
+	_ = conn.SetDeadline(time.Now().Add(smtpTimeout))
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); !ok {
+		return fmt.Errorf("SMTP server %s does not support STARTTLS", addr)
+	}
+	err = c.StartTLS(&tls.Config{ServerName: host, MinVersion: tls.VersionTLS12})
+	if err != nil {
+		return err
+	}
+	if auth != nil {
+		err = c.Auth(auth)
+		if err != nil {
+			return err
+		}
+	}
+	err = c.Mail(from)
+	if err != nil {
+		return err
+	}
+	for _, r := range recipients {
+		err = c.Rcpt(r)
+		if err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(msg)
+	if err != nil {
+		return err
+	}
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+	return c.Quit()
+}
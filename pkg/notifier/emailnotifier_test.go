@@ -0,0 +1,147 @@
+package notifier
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	cvMocks "github.com/openshift/managed-upgrade-operator/pkg/clusterversion/mocks"
+	ucMgrMocks "github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager/mocks"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+)
+
+var _ = Describe("Email Notifier", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		mockKubeClient           *mocks.MockClient
+		mockUpgradeConfigManager *ucMgrMocks.MockUpgradeConfigManager
+		mockCVClient             *cvMocks.MockClusterVersion
+		notifier                 *emailNotifier
+		secret                   corev1.Secret
+		uc                       *upgradev1alpha1.UpgradeConfig
+
+		// arguments the email was sent with
+		sentAddr       string
+		sentAuth       smtp.Auth
+		sentFrom       string
+		sentRecipients []string
+		sentMsg        string
+	)
+
+	BeforeEach(func() {
+		_ = os.Setenv("OPERATOR_NAMESPACE", "test-namespace")
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockUpgradeConfigManager = ucMgrMocks.NewMockUpgradeConfigManager(mockCtrl)
+		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
+		cfg := &EmailNotifierConfig{Email: EmailConfig{Host: "smtp.example.com", From: "MUO <muo@example.com>", SecretRef: "email"}}
+		Expect(cfg.IsValid()).To(Succeed())
+		var err error
+		notifier, err = NewEmailNotifier(mockKubeClient, cfg, mockUpgradeConfigManager, mockCVClient)
+		Expect(err).NotTo(HaveOccurred())
+		notifier.send = func(addr string, host string, auth smtp.Auth, from string, recipients []string, msg []byte) error {
+			sentAddr, sentAuth, sentFrom, sentRecipients, sentMsg = addr, auth, from, recipients, string(msg)
+			return nil
+		}
+		sentAddr, sentAuth, sentFrom, sentRecipients, sentMsg = "", nil, "", nil, ""
+		secret = corev1.Secret{Data: map[string][]byte{
+			"to":       []byte("sre@example.com, Owner <owner@example.com>"),
+			"cc":       []byte("audit@example.com"),
+			"username": []byte("muo"),
+			"password": []byte("secret"),
+		}}
+		uc = &upgradev1alpha1.UpgradeConfig{Spec: upgradev1alpha1.UpgradeConfigSpec{Desired: upgradev1alpha1.Update{Version: "4.14.2"}}}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("sends the notification to the recipients of the Secret", func() {
+		gomock.InOrder(
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, secret),
+			mockCVClient.EXPECT().GetClusterId().Return("cluster-id"),
+			mockUpgradeConfigManager.EXPECT().Get().Return(uc, nil),
+		)
+		Expect(notifier.NotifyState(MuoStateFailed, "Cluster upgrade to version 4.14.2 was cancelled")).To(Succeed())
+		Expect(sentAddr).To(Equal("smtp.example.com:587"))
+		Expect(sentAuth).NotTo(BeNil())
+		Expect(sentFrom).To(Equal("muo@example.com"))
+		Expect(sentRecipients).To(Equal([]string{"sre@example.com", "owner@example.com", "audit@example.com"}))
+		Expect(sentMsg).To(ContainSubstring("To: sre@example.com, owner@example.com\r\n"))
+		Expect(sentMsg).To(ContainSubstring("Cc: audit@example.com\r\n"))
+		Expect(sentMsg).To(ContainSubstring("Subject: [cluster-id] Cluster upgrade failed: 4.14.2\r\n"))
+		Expect(sentMsg).To(ContainSubstring("Content-Type: text/plain; charset=UTF-8"))
+		Expect(sentMsg).To(ContainSubstring("Content-Type: text/html; charset=UTF-8"))
+		Expect(sentMsg).To(ContainSubstring("Cluster upgrade to version 4.14.2 was cancelled"))
+	})
+
+	It("does not send states that are not notified by email", func() {
+		Expect(notifier.NotifyState(MuoStateControlPlaneUpgradeFinishedSL, "Control plane upgraded")).To(Succeed())
+		Expect(sentMsg).To(BeEmpty())
+	})
+
+	It("returns an error when the Secret has no recipients", func() {
+		delete(secret.Data, "to")
+		mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, secret)
+		Expect(notifier.NotifyState(MuoStateStarted, "Cluster is currently being upgraded")).NotTo(Succeed())
+		Expect(sentMsg).To(BeEmpty())
+	})
+
+	It("refuses to send through a server without STARTTLS", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		defer listener.Close()
+		go func() {
+			defer GinkgoRecover()
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			r := bufio.NewReader(conn)
+			fmt.Fprint(conn, "220 smtp.example.com ESMTP\r\n")
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				switch line[:4] {
+				case "EHLO":
+					fmt.Fprint(conn, "250-smtp.example.com\r\n250 AUTH PLAIN\r\n")
+				case "QUIT":
+					fmt.Fprint(conn, "221 bye\r\n")
+					return
+				default:
+					fmt.Fprint(conn, "250 ok\r\n")
+				}
+			}
+		}()
+		err = sendMailStartTLS(listener.Addr().String(), "smtp.example.com", nil, "muo@example.com", []string{"sre@example.com"}, []byte("test"))
+		Expect(err).To(MatchError(ContainSubstring("does not support STARTTLS")))
+	})
+})
+
+var _ = Describe("Email notifier config", func() {
+	It("requires a valid sender and a Secret when configured", func() {
+		cfg := EmailNotifierConfig{}
+		Expect(cfg.IsValid()).To(Succeed())
+		cfg.Email.Host = "smtp.example.com"
+		cfg.Email.From = "not an address"
+		Expect(cfg.IsValid()).NotTo(Succeed())
+		cfg.Email.From = "muo@example.com"
+		Expect(cfg.IsValid()).NotTo(Succeed())
+		cfg.Email.SecretRef = "email"
+		Expect(cfg.IsValid()).To(Succeed())
+		Expect(cfg.GetPort()).To(Equal(587))
+	})
+})
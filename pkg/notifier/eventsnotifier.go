@@ -0,0 +1,56 @@
+package notifier
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
+)
+
+// EventReasonUpgradeNotification is the reason of the Events recorded for the notified states
+const EventReasonUpgradeNotification = "UpgradeNotification"
+
+// warningStates are the notified states recorded as Warning Events
+var warningStates = map[MuoState]bool{
+	MuoStateDelayed:          true,
+	MuoStateFailed:           true,
+	MuoStateHealthCheckSL:    true,
+	MuoStatePreHealthCheckSL: true,
+}
+
+// NewEventsNotifier returns a new eventsNotifier
+func NewEventsNotifier(recorder record.EventRecorder, upgradeConfigManager upgradeconfigmanager.UpgradeConfigManager) (*eventsNotifier, error) {
+	if recorder == nil {
+		return nil, fmt.Errorf("no event recorder to record the notifications with")
+	}
+	return &eventsNotifier{
+		recorder:             recorder,
+		upgradeConfigManager: upgradeConfigManager,
+	}, nil
+}
+
+// A notifier that records the notifications as Kubernetes Events on the UpgradeConfig
+type eventsNotifier struct {
+	recorder             record.EventRecorder
+	upgradeConfigManager upgradeconfigmanager.UpgradeConfigManager
+}
+
+func (s *eventsNotifier) NotifyState(state MuoState, description string) error {
+	uc, err := s.upgradeConfigManager.Get()
+	if err != nil {
+		return fmt.Errorf("can't read UpgradeConfig: %v", err)
+	}
+
+	summary := stateSummaries[state]
+	if summary == "" {
+		summary = string(state)
+	}
+	eventType := corev1.EventTypeNormal
+	if warningStates[state] {
+		eventType = corev1.EventTypeWarning
+	}
+	s.recorder.Eventf(uc, eventType, EventReasonUpgradeNotification, "%s: %s", summary, description)
+	return nil
+}
@@ -0,0 +1,63 @@
+package notifier
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	ucMgrMocks "github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager/mocks"
+)
+
+var _ = Describe("Events Notifier", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		mockUpgradeConfigManager *ucMgrMocks.MockUpgradeConfigManager
+		recorder                 *record.FakeRecorder
+		notifier                 *eventsNotifier
+		uc                       *upgradev1alpha1.UpgradeConfig
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockUpgradeConfigManager = ucMgrMocks.NewMockUpgradeConfigManager(mockCtrl)
+		recorder = record.NewFakeRecorder(10)
+		notifier, _ = NewEventsNotifier(recorder, mockUpgradeConfigManager)
+		uc = &upgradev1alpha1.UpgradeConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "managed-upgrade-config", Namespace: "test-namespace", UID: "uid"},
+			Spec:       upgradev1alpha1.UpgradeConfigSpec{Desired: upgradev1alpha1.Update{Version: "4.14.2"}},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("records the notification as an Event on the UpgradeConfig", func() {
+		mockUpgradeConfigManager.EXPECT().Get().Return(uc, nil)
+		Expect(notifier.NotifyState(MuoStateStarted, "upgrading to 4.14.2")).To(Succeed())
+		Expect(recorder.Events).To(Receive(Equal("Normal UpgradeNotification Cluster upgrade started: upgrading to 4.14.2")))
+	})
+
+	It("records failures as Warning Events", func() {
+		mockUpgradeConfigManager.EXPECT().Get().Return(uc, nil)
+		Expect(notifier.NotifyState(MuoStateFailed, "upgrade failed")).To(Succeed())
+		Expect(recorder.Events).To(Receive(HavePrefix("Warning UpgradeNotification ")))
+	})
+
+	It("fails when the UpgradeConfig can't be read", func() {
+		mockUpgradeConfigManager.EXPECT().Get().Return(nil, fmt.Errorf("fake error"))
+		Expect(notifier.NotifyState(MuoStateStarted, "upgrading to 4.14.2")).NotTo(Succeed())
+		Expect(recorder.Events).NotTo(Receive())
+	})
+
+	It("can't be created without an event recorder", func() {
+		_, err := NewEventsNotifier(nil, mockUpgradeConfigManager)
+		Expect(err).To(HaveOccurred())
+	})
+})
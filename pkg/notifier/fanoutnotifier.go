@@ -0,0 +1,119 @@
+package notifier
+
+import (
+	"fmt"
+	"strings"
+)
+
+// notifierRoute is a notifier and the states routed to it
+type notifierRoute struct {
+	// destination names the notifier in logs and errors
+	destination NotifierDestination
+	notifier    Notifier
+	// states routed to the notifier, nil if every state is
+	states map[MuoState]bool
+	// required notifiers fail the notification when they fail. The failures of
+	// other notifiers are logged only, so that they do not hold up the upgrade.
+	required bool
+}
+
+// newNotifierRoute returns the route of the destination notifier
+func newNotifierRoute(destination NotifierDestination, notifier Notifier, cfg *NotifierRoutingConfig, required bool) notifierRoute {
+	return notifierRoute{
+		destination: destination,
+		notifier:    notifier,
+		states:      cfg.GetStates(destination),
+		required:    required,
+	}
+}
+
+// routes returns true if the state is routed to the notifier
+func (r notifierRoute) routes(state MuoState) bool {
+	return r.states == nil || r.states[state]
+}
+
+// newFanoutNotifier returns a fanoutNotifier
+func newFanoutNotifier(routes []notifierRoute, disabled []NotifierDestination) *fanoutNotifier {
+	return &fanoutNotifier{routes: routes, disabled: disabled}
+}
+
+// A notifier that sends each notification to the notifiers it is routed to
+type fanoutNotifier struct {
+	routes []notifierRoute
+	// disabled destinations are configured but left out as they can't be
+	disabled []NotifierDestination
+}
+
+func (s *fanoutNotifier) NotifyState(state MuoState, description string) error {
+	var errs []string
+	for _, route := range s.routes {
+		if !route.routes(state) {
+			continue
+		}
+		err := route.notifier.NotifyState(state, description)
+		if err == nil {
+			continue
+		}
+		if route.required {
+			errs = append(errs, fmt.Sprintf("%s: %v", route.destination, err))
+		} else {
+			log.Error(err, fmt.Sprintf("failed to send %s notification to %s", state, route.destination))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Destinations returns the destinations the state is routed to
+func (s *fanoutNotifier) Destinations(state MuoState) []NotifierDestination {
+	var destinations []NotifierDestination
+	for _, route := range s.routes {
+		if route.routes(state) {
+			destinations = append(destinations, route.destination)
+		}
+	}
+	return destinations
+}
+
+// DisabledDestinations returns the destinations left out as they can't be configured
+func (s *fanoutNotifier) DisabledDestinations() []NotifierDestination {
+	return s.disabled
+}
+
+// NotifyDestination sends the notification of the state to the destination only. Its failures fail
+// the notification whether the destination is required or not.
+func (s *fanoutNotifier) NotifyDestination(destination NotifierDestination, state MuoState, description string) error {
+	for _, route := range s.routes {
+		if route.destination == destination {
+			return route.notifier.NotifyState(state, description)
+		}
+	}
+	return fmt.Errorf("no notifier configured for destination %s", destination)
+}
+
+// NotifyDrain sends the drain strategy execution to the notifiers that publish drain strategies.
+// Drain strategies are not notified states, so they are sent regardless of the routed states.
+func (s *fanoutNotifier) NotifyDrain(node string, strategy string, message string) error {
+	var errs []string
+	for _, route := range s.routes {
+		drainNotifier, ok := route.notifier.(DrainNotifier)
+		if !ok {
+			continue
+		}
+		err := drainNotifier.NotifyDrain(node, strategy, message)
+		if err == nil {
+			continue
+		}
+		if route.required {
+			errs = append(errs, fmt.Sprintf("%s: %v", route.destination, err))
+		} else {
+			log.Error(err, fmt.Sprintf("failed to send %s drain strategy of node %s to %s", strategy, node, route.destination))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package notifier
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeNotifier records the states it is notified of
+type fakeNotifier struct {
+	notified []MuoState
+	err      error
+}
+
+func (f *fakeNotifier) NotifyState(state MuoState, description string) error {
+	f.notified = append(f.notified, state)
+	return f.err
+}
+
+// fakeDrainNotifier also records the drain strategies it is notified of
+type fakeDrainNotifier struct {
+	fakeNotifier
+	drained []string
+}
+
+func (f *fakeDrainNotifier) NotifyDrain(node string, strategy string, message string) error {
+	f.drained = append(f.drained, node+"/"+strategy)
+	return f.err
+}
+
+var _ = Describe("Fan-out Notifier", func() {
+	var (
+		ocm         *fakeNotifier
+		webhook     *fakeNotifier
+		email       *fakeNotifier
+		routingCfg  *NotifierRoutingConfig
+		description = "Cluster is currently being upgraded"
+		fakeError   = fmt.Errorf("fake error")
+	)
+
+	newFanout := func() *fanoutNotifier {
+		return newFanoutNotifier([]notifierRoute{
+			newNotifierRoute(NotifierDestinationOCM, ocm, routingCfg, true),
+			newNotifierRoute(NotifierDestinationWebhook, webhook, routingCfg, false),
+			newNotifierRoute(NotifierDestinationEmail, email, routingCfg, false),
+		}, nil)
+	}
+
+	BeforeEach(func() {
+		ocm = &fakeNotifier{}
+		webhook = &fakeNotifier{}
+		email = &fakeNotifier{}
+		routingCfg = &NotifierRoutingConfig{}
+	})
+
+	It("sends every state to the destinations without a route", func() {
+		Expect(newFanout().NotifyState(MuoStateStarted, description)).To(Succeed())
+		Expect(ocm.notified).To(Equal([]MuoState{MuoStateStarted}))
+		Expect(webhook.notified).To(Equal([]MuoState{MuoStateStarted}))
+		Expect(email.notified).To(Equal([]MuoState{MuoStateStarted}))
+	})
+
+	It("sends the states routed to a destination only", func() {
+		routingCfg.NotificationRoutes = map[NotifierDestination][]MuoState{
+			NotifierDestinationWebhook: {MuoStateFailed},
+			NotifierDestinationEmail:   {},
+		}
+		Expect(routingCfg.IsValid()).To(Succeed())
+		fanout := newFanout()
+		Expect(fanout.NotifyState(MuoStateStarted, description)).To(Succeed())
+		Expect(fanout.NotifyState(MuoStateFailed, description)).To(Succeed())
+		Expect(ocm.notified).To(Equal([]MuoState{MuoStateStarted, MuoStateFailed}))
+		Expect(webhook.notified).To(Equal([]MuoState{MuoStateFailed}))
+		Expect(email.notified).To(BeEmpty())
+	})
+
+	It("does not fail when an optional destination fails", func() {
+		webhook.err = fakeError
+		Expect(newFanout().NotifyState(MuoStateStarted, description)).To(Succeed())
+		Expect(email.notified).To(Equal([]MuoState{MuoStateStarted}))
+	})
+
+	It("fails when the required destination fails, after notifying the others", func() {
+		ocm.err = fakeError
+		Expect(newFanout().NotifyState(MuoStateStarted, description)).To(MatchError("ocm: fake error"))
+		Expect(webhook.notified).To(Equal([]MuoState{MuoStateStarted}))
+		Expect(email.notified).To(Equal([]MuoState{MuoStateStarted}))
+	})
+
+	It("sends a notification again to a single destination, failing when it fails", func() {
+		routingCfg.NotificationRoutes = map[NotifierDestination][]MuoState{NotifierDestinationEmail: {MuoStateFailed}}
+		fanout := newFanout()
+		Expect(fanout.Destinations(MuoStateStarted)).To(Equal([]NotifierDestination{NotifierDestinationOCM, NotifierDestinationWebhook}))
+		webhook.err = fakeError
+		Expect(fanout.NotifyDestination(NotifierDestinationWebhook, MuoStateStarted, description)).To(MatchError(fakeError))
+		Expect(ocm.notified).To(BeEmpty())
+		Expect(webhook.notified).To(Equal([]MuoState{MuoStateStarted}))
+		Expect(fanout.NotifyDestination(NotifierDestinationCloudEvents, MuoStateStarted, description)).NotTo(Succeed())
+	})
+
+	It("sends drain strategies to the destinations publishing them only, regardless of routes", func() {
+		cloudEvents := &fakeDrainNotifier{fakeNotifier: fakeNotifier{err: fakeError}}
+		routingCfg.NotificationRoutes = map[NotifierDestination][]MuoState{NotifierDestinationCloudEvents: {MuoStateFailed}}
+		fanout := newFanoutNotifier([]notifierRoute{
+			newNotifierRoute(NotifierDestinationOCM, ocm, routingCfg, true),
+			newNotifierRoute(NotifierDestinationCloudEvents, cloudEvents, routingCfg, false),
+		}, nil)
+		Expect(fanout.NotifyDrain("worker-1", "PDB-DELETE", "deleted 2 pods")).To(Succeed())
+		Expect(cloudEvents.drained).To(Equal([]string{"worker-1/PDB-DELETE"}))
+		Expect(ocm.notified).To(BeEmpty())
+	})
+
+	It("rejects routes to unknown destinations or states", func() {
+		routingCfg.NotificationRoutes = map[NotifierDestination][]MuoState{"pager": {MuoStateFailed}}
+		Expect(routingCfg.IsValid()).NotTo(Succeed())
+		routingCfg.NotificationRoutes = map[NotifierDestination][]MuoState{NotifierDestinationWebhook: {"StateExploded"}}
+		Expect(routingCfg.IsValid()).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,64 @@
+package notifier
+
+import (
+	"fmt"
+	"time"
+
+	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	"github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
+)
+
+// stateSummaries are the short summaries of the notified states
+var stateSummaries = map[MuoState]string{
+	MuoStatePending:                       "Cluster upgrade pending",
+	MuoStateScheduled:                     "Cluster upgrade scheduled",
+	MuoStateStarted:                       "Cluster upgrade started",
+	MuoStateDelayed:                       "Cluster upgrade delayed",
+	MuoStateCompleted:                     "Cluster upgrade completed",
+	MuoStateFailed:                        "Cluster upgrade failed",
+	MuoStateCancelled:                     "Cluster upgrade cancelled",
+	MuoStateScaleSkipped:                  "Cluster upgrade capacity reservation skipped",
+	MuoStateSkipped:                       "Cluster upgrade capacity reservation skipped",
+	MuoStateHealthCheckSL:                 "Cluster upgrade health check failing",
+	MuoStatePreHealthCheckSL:              "Cluster pre-upgrade health check failing",
+	MuoStateControlPlaneUpgradeStartedSL:  "Cluster control plane upgrade started",
+	MuoStateControlPlaneUpgradeFinishedSL: "Cluster control plane upgrade finished",
+	MuoStateWorkerPlaneUpgradeFinishedSL:  "Cluster worker plane upgrade finished",
+	MuoStateUpgradeReminderSL:             "Cluster upgrade approaching",
+}
+
+// NotificationMessage is the data the webhook and email notification templates are rendered with
+type NotificationMessage struct {
+	// State is the notified state, e.g. StateStarted
+	State MuoState
+	// Summary is a short summary of the state
+	Summary string
+	// Description is the notification text
+	Description string
+	// Version is the desired version of the upgrade
+	Version string
+	// ClusterID is the ID of the cluster
+	ClusterID string
+	// Time is the time of the notification in RFC3339 format
+	Time string
+}
+
+// newNotificationMessage returns the NotificationMessage of a state notification of the current UpgradeConfig
+func newNotificationMessage(state MuoState, description string, upgradeConfigManager upgradeconfigmanager.UpgradeConfigManager, cvClient cv.ClusterVersion) (NotificationMessage, error) {
+	message := NotificationMessage{
+		State:       state,
+		Summary:     stateSummaries[state],
+		Description: description,
+		ClusterID:   cvClient.GetClusterId(),
+		Time:        time.Now().UTC().Format(time.RFC3339),
+	}
+	if message.Summary == "" {
+		message.Summary = string(state)
+	}
+	uc, err := upgradeConfigManager.Get()
+	if err != nil {
+		return message, fmt.Errorf("can't read UpgradeConfig: %v", err)
+	}
+	message.Version = uc.Spec.Desired.Version
+	return message, nil
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/openshift/managed-upgrade-operator/pkg/notifier (interfaces: NotificationOutbox)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/outbox.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/notifier NotificationOutbox
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	notifier "github.com/openshift/managed-upgrade-operator/pkg/notifier"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotificationOutbox is a mock of NotificationOutbox interface.
+type MockNotificationOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationOutboxMockRecorder
+}
+
+// MockNotificationOutboxMockRecorder is the mock recorder for MockNotificationOutbox.
+type MockNotificationOutboxMockRecorder struct {
+	mock *MockNotificationOutbox
+}
+
+// NewMockNotificationOutbox creates a new mock instance.
+func NewMockNotificationOutbox(ctrl *gomock.Controller) *MockNotificationOutbox {
+	mock := &MockNotificationOutbox{ctrl: ctrl}
+	mock.recorder = &MockNotificationOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationOutbox) EXPECT() *MockNotificationOutboxMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockNotificationOutbox) Enqueue(arg0 string, arg1 notifier.MuoState, arg2 notifier.NotifierDestination, arg3 string, arg4 error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockNotificationOutboxMockRecorder) Enqueue(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockNotificationOutbox)(nil).Enqueue), arg0, arg1, arg2, arg3, arg4)
+}
+
+// HasQueued mocks base method.
+func (m *MockNotificationOutbox) HasQueued(arg0 string, arg1 notifier.NotifierDestination) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasQueued", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasQueued indicates an expected call of HasQueued.
+func (mr *MockNotificationOutboxMockRecorder) HasQueued(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasQueued", reflect.TypeOf((*MockNotificationOutbox)(nil).HasQueued), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockNotificationOutbox) List() ([]notifier.OutboxEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]notifier.OutboxEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockNotificationOutboxMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockNotificationOutbox)(nil).List))
+}
+
+// Remove mocks base method.
+func (m *MockNotificationOutbox) Remove(arg0 notifier.OutboxEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockNotificationOutboxMockRecorder) Remove(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockNotificationOutbox)(nil).Remove), arg0)
+}
+
+// Retry mocks base method.
+func (m *MockNotificationOutbox) Retry(arg0 notifier.OutboxEntry, arg1 error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockNotificationOutboxMockRecorder) Retry(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockNotificationOutbox)(nil).Retry), arg0, arg1)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/openshift/managed-upgrade-operator/pkg/notifier (interfaces: RoutedNotifier)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/routed_notifier.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/notifier RoutedNotifier
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	notifier "github.com/openshift/managed-upgrade-operator/pkg/notifier"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRoutedNotifier is a mock of RoutedNotifier interface.
+type MockRoutedNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoutedNotifierMockRecorder
+}
+
+// MockRoutedNotifierMockRecorder is the mock recorder for MockRoutedNotifier.
+type MockRoutedNotifierMockRecorder struct {
+	mock *MockRoutedNotifier
+}
+
+// NewMockRoutedNotifier creates a new mock instance.
+func NewMockRoutedNotifier(ctrl *gomock.Controller) *MockRoutedNotifier {
+	mock := &MockRoutedNotifier{ctrl: ctrl}
+	mock.recorder = &MockRoutedNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoutedNotifier) EXPECT() *MockRoutedNotifierMockRecorder {
+	return m.recorder
+}
+
+// Destinations mocks base method.
+func (m *MockRoutedNotifier) Destinations(arg0 notifier.MuoState) []notifier.NotifierDestination {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Destinations", arg0)
+	ret0, _ := ret[0].([]notifier.NotifierDestination)
+	return ret0
+}
+
+// Destinations indicates an expected call of Destinations.
+func (mr *MockRoutedNotifierMockRecorder) Destinations(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destinations", reflect.TypeOf((*MockRoutedNotifier)(nil).Destinations), arg0)
+}
+
+// DisabledDestinations mocks base method.
+func (m *MockRoutedNotifier) DisabledDestinations() []notifier.NotifierDestination {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisabledDestinations")
+	ret0, _ := ret[0].([]notifier.NotifierDestination)
+	return ret0
+}
+
+// DisabledDestinations indicates an expected call of DisabledDestinations.
+func (mr *MockRoutedNotifierMockRecorder) DisabledDestinations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisabledDestinations", reflect.TypeOf((*MockRoutedNotifier)(nil).DisabledDestinations))
+}
+
+// NotifyDestination mocks base method.
+func (m *MockRoutedNotifier) NotifyDestination(arg0 notifier.NotifierDestination, arg1 notifier.MuoState, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyDestination", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyDestination indicates an expected call of NotifyDestination.
+func (mr *MockRoutedNotifierMockRecorder) NotifyDestination(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyDestination", reflect.TypeOf((*MockRoutedNotifier)(nil).NotifyDestination), arg0, arg1, arg2)
+}
+
+// NotifyState mocks base method.
+func (m *MockRoutedNotifier) NotifyState(arg0 notifier.MuoState, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyState", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyState indicates an expected call of NotifyState.
+func (mr *MockRoutedNotifierMockRecorder) NotifyState(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyState", reflect.TypeOf((*MockRoutedNotifier)(nil).NotifyState), arg0, arg1)
+}
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/openshift/managed-upgrade-operator/pkg/notifier (interfaces: NotificationStore)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/store.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/notifier NotificationStore
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	notifier "github.com/openshift/managed-upgrade-operator/pkg/notifier"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotificationStore is a mock of NotificationStore interface.
+type MockNotificationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationStoreMockRecorder
+}
+
+// MockNotificationStoreMockRecorder is the mock recorder for MockNotificationStore.
+type MockNotificationStoreMockRecorder struct {
+	mock *MockNotificationStore
+}
+
+// NewMockNotificationStore creates a new mock instance.
+func NewMockNotificationStore(ctrl *gomock.Controller) *MockNotificationStore {
+	mock := &MockNotificationStore{ctrl: ctrl}
+	mock.recorder = &MockNotificationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationStore) EXPECT() *MockNotificationStoreMockRecorder {
+	return m.recorder
+}
+
+// IsSent mocks base method.
+func (m *MockNotificationStore) IsSent(arg0 string, arg1 notifier.MuoState) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSent", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSent indicates an expected call of IsSent.
+func (mr *MockNotificationStoreMockRecorder) IsSent(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSent", reflect.TypeOf((*MockNotificationStore)(nil).IsSent), arg0, arg1)
+}
+
+// SentAt mocks base method.
+func (m *MockNotificationStore) SentAt(arg0 string, arg1 notifier.MuoState) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SentAt", arg0, arg1)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SentAt indicates an expected call of SentAt.
+func (mr *MockNotificationStoreMockRecorder) SentAt(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SentAt", reflect.TypeOf((*MockNotificationStore)(nil).SentAt), arg0, arg1)
+}
+
+// SetSent mocks base method.
+func (m *MockNotificationStore) SetSent(arg0 string, arg1 notifier.MuoState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSent indicates an expected call of SetSent.
+func (mr *MockNotificationStoreMockRecorder) SetSent(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSent", reflect.TypeOf((*MockNotificationStore)(nil).SetSent), arg0, arg1)
+}
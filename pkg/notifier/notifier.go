@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"strings"
 
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/openshift/managed-upgrade-operator/config"
+	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
 	"github.com/openshift/managed-upgrade-operator/pkg/configmanager"
 	"github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
 
@@ -20,11 +22,28 @@ type Notifier interface {
 	NotifyState(value MuoState, description string) error
 }
 
+// DrainNotifier is implemented by the notifiers that also publish the drain strategies
+// executed on nodes
+type DrainNotifier interface {
+	NotifyDrain(node string, strategy string, message string) error
+}
+
+// RoutedNotifier is implemented by the notifiers that send the notifications to several
+// destinations, so that a notification can be sent again to the destinations it failed to reach only
+//
+//go:generate mockgen -destination=mocks/routed_notifier.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/notifier RoutedNotifier
+type RoutedNotifier interface {
+	Notifier
+	Destinations(state MuoState) []NotifierDestination
+	NotifyDestination(destination NotifierDestination, state MuoState, description string) error
+	DisabledDestinations() []NotifierDestination
+}
+
 // NotifierBuilder is an interface that enables implementation of a NotifierBuilder
 //
 //go:generate mockgen -destination=mocks/notifier_builder.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/notifier NotifierBuilder
 type NotifierBuilder interface {
-	New(client.Client, configmanager.ConfigManagerBuilder, upgradeconfigmanager.UpgradeConfigManagerBuilder) (Notifier, error)
+	New(client.Client, configmanager.ConfigManagerBuilder, upgradeconfigmanager.UpgradeConfigManagerBuilder, record.EventRecorder) (Notifier, error)
 }
 
 // Represents valid notify states that can be reported
@@ -43,11 +62,18 @@ const (
 	MuoStateControlPlaneUpgradeStartedSL  MuoState = "StateControlPlaneStartedSL"
 	MuoStateControlPlaneUpgradeFinishedSL MuoState = "StateControlPlaneFinishedSL"
 	MuoStateWorkerPlaneUpgradeFinishedSL  MuoState = "StateWorkerPlaneFinishedSL"
+	MuoStateUpgradeReminderSL             MuoState = "StateUpgradeReminderSL"
 )
 
 // MuoState is a type
 type MuoState string
 
+// IsValid returns true if the state is one of the notify states
+func (s MuoState) IsValid() bool {
+	_, ok := stateSummaries[s]
+	return ok
+}
+
 // Errors
 var (
 	ErrNoNotifierConfigured = fmt.Errorf("no valid configured notifier")
@@ -61,7 +87,7 @@ func NewBuilder() NotifierBuilder {
 type notifierBuilder struct{}
 
 // Creates a new Notifier instance
-func (nb *notifierBuilder) New(client client.Client, cfgBuilder configmanager.ConfigManagerBuilder, upgradeConfigManagerBuilder upgradeconfigmanager.UpgradeConfigManagerBuilder) (Notifier, error) {
+func (nb *notifierBuilder) New(client client.Client, cfgBuilder configmanager.ConfigManagerBuilder, upgradeConfigManagerBuilder upgradeconfigmanager.UpgradeConfigManagerBuilder, recorder record.EventRecorder) (Notifier, error) {
 	cfg, err := readNotifierConfig(client, cfgBuilder)
 	if err != nil {
 		return nil, err
@@ -80,6 +106,22 @@ func (nb *notifierBuilder) New(client client.Client, cfgBuilder configmanager.Co
 		return nil, err
 	}
 
+	// A notifier that can't be configured is left out, with an error log and the disabled
+	// destinations reported, so that only the OCM or log notifier can fail the notifications
+	var disabled []NotifierDestination
+	disable := func(destination NotifierDestination, err error) {
+		log.Error(err, fmt.Sprintf("leaving out the %s notifier as it can't be configured", destination))
+		disabled = append(disabled, destination)
+	}
+
+	routingCfg, routingErr := readNotifierRoutingConfig(client, cfgBuilder)
+	if routingErr != nil {
+		routingCfg = &NotifierRoutingConfig{}
+	}
+
+	// The notifier of the config manager source receives the notifications it is routed,
+	// or every notification, and its failures fail the notification
+	var routes []notifierRoute
 	switch strings.ToUpper(cfg.ConfigManager.Source) {
 	case "OCM":
 		cfg, err := readOcmNotifierConfig(client, cfgBuilder)
@@ -90,12 +132,90 @@ func (nb *notifierBuilder) New(client client.Client, cfgBuilder configmanager.Co
 		if err != nil {
 			return nil, err
 		}
-		return mgr, nil
+		routes = append(routes, newNotifierRoute(NotifierDestinationOCM, mgr, routingCfg, true))
 	default:
 		// Create a log notifier as a fallback
 		mgr, err := NewLogNotifier()
-		return mgr, err
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, newNotifierRoute(NotifierDestinationLog, mgr, routingCfg, true))
+	}
+
+	// The other notifiers can't be routed without a valid routing configuration
+	if routingErr != nil {
+		for _, destination := range OptionalDestinations {
+			disable(destination, routingErr)
+		}
+		return newFanoutNotifier(routes, disabled), nil
+	}
+
+	// Any other configured notifier receives the notifications alongside it
+	webhookCfg, err := readWebhookNotifierConfig(client, cfgBuilder)
+	if err != nil {
+		disable(NotifierDestinationWebhook, err)
+	} else if webhookCfg.IsConfigured() {
+		mgr, err := NewWebhookNotifier(client, webhookCfg, upgradeConfigManager, cv.NewBuilder().New(client))
+		if err != nil {
+			disable(NotifierDestinationWebhook, err)
+		} else {
+			routes = append(routes, newNotifierRoute(NotifierDestinationWebhook, mgr, routingCfg, false))
+		}
+	}
+	emailCfg, err := readEmailNotifierConfig(client, cfgBuilder)
+	if err != nil {
+		disable(NotifierDestinationEmail, err)
+	} else if emailCfg.IsConfigured() {
+		mgr, err := NewEmailNotifier(client, emailCfg, upgradeConfigManager, cv.NewBuilder().New(client))
+		if err != nil {
+			disable(NotifierDestinationEmail, err)
+		} else {
+			routes = append(routes, newNotifierRoute(NotifierDestinationEmail, mgr, routingCfg, false))
+		}
+	}
+	cloudEventsCfg, err := readCloudEventsNotifierConfig(client, cfgBuilder)
+	if err != nil {
+		disable(NotifierDestinationCloudEvents, err)
+	} else if cloudEventsCfg.IsConfigured() {
+		mgr, err := NewCloudEventsNotifier(client, cloudEventsCfg, upgradeConfigManager, cv.NewBuilder().New(client))
+		if err != nil {
+			disable(NotifierDestinationCloudEvents, err)
+		} else {
+			routes = append(routes, newNotifierRoute(NotifierDestinationCloudEvents, mgr, routingCfg, false))
+		}
 	}
+	// Kubernetes Events are only recorded for the states routed to them
+	if routingCfg.IsRouted(NotifierDestinationEvents) {
+		mgr, err := NewEventsNotifier(recorder, upgradeConfigManager)
+		if err != nil {
+			disable(NotifierDestinationEvents, err)
+		} else {
+			routes = append(routes, newNotifierRoute(NotifierDestinationEvents, mgr, routingCfg, false))
+		}
+	}
+
+	if len(routes) == 1 && routes[0].states == nil && len(disabled) == 0 {
+		return routes[0].notifier, nil
+	}
+	return newFanoutNotifier(routes, disabled), nil
+}
+
+// Read notifier routing configuration
+func readNotifierRoutingConfig(client client.Client, cfb configmanager.ConfigManagerBuilder) (*NotifierRoutingConfig, error) {
+	cfg := &NotifierRoutingConfig{}
+
+	target := config.CMTarget{}
+	cmTarget, err := target.NewCMTarget()
+	if err != nil {
+		return cfg, err
+	}
+
+	cfm := cfb.New(client, cmTarget)
+	err = cfm.Into(cfg)
+	if err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.IsValid()
 }
 
 // Read notifier configuration
@@ -134,6 +254,60 @@ func readOcmNotifierConfig(client client.Client, cfb configmanager.ConfigManager
 	return cfg, cfg.IsValid()
 }
 
+// Read webhook notifier configuration
+func readWebhookNotifierConfig(client client.Client, cfb configmanager.ConfigManagerBuilder) (*WebhookNotifierConfig, error) {
+	cfg := &WebhookNotifierConfig{}
+
+	target := config.CMTarget{}
+	cmTarget, err := target.NewCMTarget()
+	if err != nil {
+		return cfg, err
+	}
+
+	cfm := cfb.New(client, cmTarget)
+	err = cfm.Into(cfg)
+	if err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.IsValid()
+}
+
+// Read email notifier configuration
+func readEmailNotifierConfig(client client.Client, cfb configmanager.ConfigManagerBuilder) (*EmailNotifierConfig, error) {
+	cfg := &EmailNotifierConfig{}
+
+	target := config.CMTarget{}
+	cmTarget, err := target.NewCMTarget()
+	if err != nil {
+		return cfg, err
+	}
+
+	cfm := cfb.New(client, cmTarget)
+	err = cfm.Into(cfg)
+	if err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.IsValid()
+}
+
+// Read CloudEvents notifier configuration
+func readCloudEventsNotifierConfig(client client.Client, cfb configmanager.ConfigManagerBuilder) (*CloudEventsNotifierConfig, error) {
+	cfg := &CloudEventsNotifierConfig{}
+
+	target := config.CMTarget{}
+	cmTarget, err := target.NewCMTarget()
+	if err != nil {
+		return cfg, err
+	}
+
+	cfm := cfb.New(client, cmTarget)
+	err = cfm.Into(cfg)
+	if err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.IsValid()
+}
+
 // Read featuregate configuration
 func readOcmFeatureGate(client client.Client, cfb configmanager.ConfigManagerBuilder) (*OcmFeatureConfig, error) {
 	cfg := &OcmFeatureConfig{}
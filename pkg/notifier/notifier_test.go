@@ -0,0 +1,77 @@
+package notifier
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"go.uber.org/mock/gomock"
+
+	cmMocks "github.com/openshift/managed-upgrade-operator/pkg/configmanager/mocks"
+	ucMgrMocks "github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager/mocks"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+)
+
+var _ = Describe("Notifier builder", func() {
+	var (
+		mockCtrl                        *gomock.Controller
+		mockKubeClient                  *mocks.MockClient
+		mockConfigManagerBuilder        *cmMocks.MockConfigManagerBuilder
+		mockConfigManager               *cmMocks.MockConfigManager
+		mockUpgradeConfigManagerBuilder *ucMgrMocks.MockUpgradeConfigManagerBuilder
+		mockUpgradeConfigManager        *ucMgrMocks.MockUpgradeConfigManager
+		webhookCfg                      WebhookConfig
+		emailCfg                        EmailConfig
+	)
+
+	BeforeEach(func() {
+		_ = os.Setenv("OPERATOR_NAMESPACE", "test-namespace")
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockConfigManagerBuilder = cmMocks.NewMockConfigManagerBuilder(mockCtrl)
+		mockConfigManager = cmMocks.NewMockConfigManager(mockCtrl)
+		mockUpgradeConfigManagerBuilder = ucMgrMocks.NewMockUpgradeConfigManagerBuilder(mockCtrl)
+		mockUpgradeConfigManager = ucMgrMocks.NewMockUpgradeConfigManager(mockCtrl)
+		webhookCfg = WebhookConfig{URL: "https://hooks.example.com/muo", Format: "slack"}
+		emailCfg = EmailConfig{}
+
+		mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager).AnyTimes()
+		mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil)
+		mockConfigManager.EXPECT().Into(gomock.Any()).DoAndReturn(func(cfg interface{}) error {
+			switch c := cfg.(type) {
+			case *NotifierConfig:
+				c.ConfigManager.Source = string(LOCAL)
+			case *WebhookNotifierConfig:
+				c.Webhook = webhookCfg
+			case *EmailNotifierConfig:
+				c.Email = emailCfg
+			}
+			return nil
+		}).AnyTimes()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("sends the notifications to the configured notifiers", func() {
+		n, err := NewBuilder().New(mockKubeClient, mockConfigManagerBuilder, mockUpgradeConfigManagerBuilder, nil)
+		Expect(err).NotTo(HaveOccurred())
+		fanout, ok := n.(*fanoutNotifier)
+		Expect(ok).To(BeTrue())
+		Expect(fanout.Destinations(MuoStateStarted)).To(Equal([]NotifierDestination{NotifierDestinationLog, NotifierDestinationWebhook}))
+		Expect(fanout.DisabledDestinations()).To(BeEmpty())
+	})
+
+	It("leaves out the optional notifiers that can't be configured", func() {
+		webhookCfg.Format = "pager"
+		emailCfg = EmailConfig{Host: "smtp.example.com", From: "not an address", SecretRef: "muo-email"}
+		n, err := NewBuilder().New(mockKubeClient, mockConfigManagerBuilder, mockUpgradeConfigManagerBuilder, nil)
+		Expect(err).NotTo(HaveOccurred())
+		fanout, ok := n.(*fanoutNotifier)
+		Expect(ok).To(BeTrue())
+		Expect(fanout.Destinations(MuoStateStarted)).To(Equal([]NotifierDestination{NotifierDestinationLog}))
+		Expect(fanout.DisabledDestinations()).To(Equal([]NotifierDestination{NotifierDestinationWebhook, NotifierDestinationEmail}))
+	})
+})
@@ -77,6 +77,8 @@ var (
 	ServiceLogStateHealthCheckSL = ServiceLogState{Severity: servicelogsv1.SeverityInfo, Summary: "Cluster has encountered healthcheck failure during upgrade"}
 	//ServiceLogStatePreHealthCheckSL defines the summary for finished cluster pre-upgrade healthcheck
 	ServiceLogStatePreHealthCheckSL = ServiceLogState{Severity: servicelogsv1.SeverityInfo, Summary: "Cluster has encountered pre-upgrade healthcheck failure"}
+	// ServiceLogStateUpgradeReminderSL defines the summary for the upcoming upgrade reminder servicelog
+	ServiceLogStateUpgradeReminderSL = ServiceLogState{Severity: servicelogsv1.SeverityInfo, Summary: "Cluster upgrade is approaching"}
 )
 
 // ServiceLogState type defines the ServiceLog metadata
@@ -88,6 +90,7 @@ var serviceLogMap = map[MuoState]ServiceLogState{
 	MuoStateWorkerPlaneUpgradeFinishedSL:  ServiceLogStateWorkerPlaneFinished,
 	MuoStateHealthCheckSL:                 ServiceLogStateHealthCheckSL,
 	MuoStatePreHealthCheckSL:              ServiceLogStatePreHealthCheckSL,
+	MuoStateUpgradeReminderSL:             ServiceLogStateUpgradeReminderSL,
 }
 
 type ocmNotifier struct {
@@ -0,0 +1,239 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jpillora/backoff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/managed-upgrade-operator/util"
+)
+
+// NotificationOutboxConfigMap is the name of the ConfigMap queuing the notifications that failed to be sent
+const NotificationOutboxConfigMap = "managed-upgrade-operator-notification-outbox"
+
+// OutboxEntry is a notification waiting in the outbox to be sent again
+type OutboxEntry struct {
+	// Version is the desired version of the upgrade the notification is about
+	Version string `json:"version"`
+	// State is the notified state
+	State MuoState `json:"state"`
+	// Destination is the destination the notification failed to be sent to. Entries queued
+	// without a destination are sent to every destination the state is routed to.
+	Destination NotifierDestination `json:"destination,omitempty"`
+	// Description is the notification text
+	Description string `json:"description"`
+	// Attempts is the number of times the notification failed to be sent
+	Attempts int `json:"attempts"`
+	// EnqueuedAt is the time the notification first failed to be sent
+	EnqueuedAt time.Time `json:"enqueuedAt"`
+	// NextAttempt is the time after which the notification is sent again
+	NextAttempt time.Time `json:"nextAttempt"`
+	// LastError is the error of the last attempt
+	LastError string `json:"lastError,omitempty"`
+}
+
+// key returns the outbox ConfigMap key of the entry
+func (e OutboxEntry) key() string {
+	return outboxKey(e.Version, e.State, e.Destination)
+}
+
+// IsDue returns true if the entry should be sent again at the given time
+func (e OutboxEntry) IsDue(now time.Time) bool {
+	return !now.Before(e.NextAttempt)
+}
+
+// NotificationOutbox queues the notifications that failed to be sent to a destination so that they
+// are sent to it again, with an exponential backoff, without holding the upgrade back
+//
+//go:generate mockgen -destination=mocks/outbox.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/notifier NotificationOutbox
+type NotificationOutbox interface {
+	Enqueue(version string, state MuoState, destination NotifierDestination, description string, sendErr error) error
+	HasQueued(version string, destination NotifierDestination) (bool, error)
+	List() ([]OutboxEntry, error)
+	Retry(entry OutboxEntry, sendErr error) error
+	Remove(entry OutboxEntry) error
+}
+
+// NewNotificationOutbox returns a NotificationOutbox backed by a ConfigMap in the operator namespace
+func NewNotificationOutbox(c client.Client) NotificationOutbox {
+	return &configMapOutbox{
+		client: c,
+		backoff: &backoff.Backoff{
+			Min:    30 * time.Second,
+			Max:    30 * time.Minute,
+			Factor: 2,
+			Jitter: false,
+		},
+	}
+}
+
+type configMapOutbox struct {
+	client  client.Client
+	backoff *backoff.Backoff
+}
+
+// Enqueue queues the notification of the state for the version to the destination. A notification
+// that is already queued keeps its place and schedule.
+func (s *configMapOutbox) Enqueue(version string, state MuoState, destination NotifierDestination, description string, sendErr error) error {
+	cm, found, err := s.get()
+	if err != nil {
+		return err
+	}
+	key := outboxKey(version, state, destination)
+	if _, ok := cm.Data[key]; ok {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	entry := OutboxEntry{
+		Version:     version,
+		State:       state,
+		Destination: destination,
+		Description: description,
+		Attempts:    1,
+		EnqueuedAt:  now,
+		NextAttempt: now.Add(s.backoff.ForAttempt(0)),
+	}
+	if sendErr != nil {
+		entry.LastError = sendErr.Error()
+	}
+	return s.put(cm, found, key, &entry)
+}
+
+// HasQueued reports whether notifications of the version are waiting in the outbox to be sent to
+// the destination, or to every destination
+func (s *configMapOutbox) HasQueued(version string, destination NotifierDestination) (bool, error) {
+	cm, _, err := s.get()
+	if err != nil {
+		return false, err
+	}
+	prefix := storeKey(version, "")
+	for key, value := range cm.Data {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		entry := OutboxEntry{}
+		if err := json.Unmarshal([]byte(value), &entry); err != nil {
+			return false, fmt.Errorf("can't decode notification outbox entry %s: %v", key, err)
+		}
+		if entry.Destination == "" || entry.Destination == destination {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// List returns the queued notifications, oldest first
+func (s *configMapOutbox) List() ([]OutboxEntry, error) {
+	cm, _, err := s.get()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]OutboxEntry, 0, len(cm.Data))
+	for key, value := range cm.Data {
+		entry := OutboxEntry{}
+		if err := json.Unmarshal([]byte(value), &entry); err != nil {
+			return nil, fmt.Errorf("can't decode notification outbox entry %s: %v", key, err)
+		}
+		entries = append(entries, entry)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].EnqueuedAt.Before(entries[j].EnqueuedAt)
+	})
+	return entries, nil
+}
+
+// Retry records a failed attempt to send the queued notification and schedules the next one
+func (s *configMapOutbox) Retry(entry OutboxEntry, sendErr error) error {
+	cm, found, err := s.get()
+	if err != nil {
+		return err
+	}
+	entry.Attempts++
+	entry.NextAttempt = time.Now().UTC().Add(s.backoff.ForAttempt(float64(entry.Attempts - 1)))
+	if sendErr != nil {
+		entry.LastError = sendErr.Error()
+	}
+	return s.put(cm, found, entry.key(), &entry)
+}
+
+// Remove removes the notification from the outbox
+func (s *configMapOutbox) Remove(entry OutboxEntry) error {
+	cm, found, err := s.get()
+	if err != nil {
+		return err
+	}
+	key := entry.key()
+	if _, ok := cm.Data[key]; !found || !ok {
+		return nil
+	}
+	return s.put(cm, found, key, nil)
+}
+
+// outboxKey returns the ConfigMap key queuing the notification of the state for the version to the
+// destination
+func outboxKey(version string, state MuoState, destination NotifierDestination) string {
+	key := storeKey(version, state)
+	if destination != "" {
+		key += "." + string(destination)
+	}
+	return key
+}
+
+// get returns the outbox ConfigMap and whether it exists
+func (s *configMapOutbox) get() (*corev1.ConfigMap, bool, error) {
+	ns, err := util.GetOperatorNamespace()
+	if err != nil {
+		return nil, false, err
+	}
+	cm := &corev1.ConfigMap{}
+	err = s.client.Get(context.TODO(), client.ObjectKey{Name: NotificationOutboxConfigMap, Namespace: ns}, cm)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      NotificationOutboxConfigMap,
+					Namespace: ns,
+				},
+			}, false, nil
+		}
+		return nil, false, fmt.Errorf("can't read notification outbox: %v", err)
+	}
+	return cm, true, nil
+}
+
+// put sets the entry of the key in the outbox, or deletes it if the entry is nil
+func (s *configMapOutbox) put(cm *corev1.ConfigMap, found bool, key string, entry *OutboxEntry) error {
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	if entry == nil {
+		delete(cm.Data, key)
+	} else {
+		value, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("can't encode notification outbox entry %s: %v", key, err)
+		}
+		cm.Data[key] = string(value)
+	}
+
+	var err error
+	if found {
+		err = s.client.Update(context.TODO(), cm)
+	} else {
+		err = s.client.Create(context.TODO(), cm)
+	}
+	if err != nil {
+		return fmt.Errorf("can't update notification outbox: %v", err)
+	}
+	return nil
+}
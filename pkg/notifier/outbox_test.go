@@ -0,0 +1,183 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+)
+
+var _ = Describe("Notification outbox", func() {
+	const testNamespace = "test-namespace"
+
+	var (
+		mockCtrl       *gomock.Controller
+		mockKubeClient *mocks.MockClient
+		outbox         NotificationOutbox
+		notFound       error
+		fakeError      error
+	)
+
+	newOutboxConfigMap := func(entries ...OutboxEntry) corev1.ConfigMap {
+		cm := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: NotificationOutboxConfigMap, Namespace: testNamespace},
+			Data:       map[string]string{},
+		}
+		for _, entry := range entries {
+			value, err := json.Marshal(entry)
+			Expect(err).NotTo(HaveOccurred())
+			cm.Data[entry.key()] = string(value)
+		}
+		return cm
+	}
+
+	decodeEntry := func(cm *corev1.ConfigMap, key string) OutboxEntry {
+		entry := OutboxEntry{}
+		Expect(json.Unmarshal([]byte(cm.Data[key]), &entry)).To(Succeed())
+		return entry
+	}
+
+	BeforeEach(func() {
+		_ = os.Setenv("OPERATOR_NAMESPACE", testNamespace)
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		outbox = NewNotificationOutbox(mockKubeClient)
+		notFound = errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, NotificationOutboxConfigMap)
+		fakeError = fmt.Errorf("fake error")
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When queuing a notification", func() {
+		It("creates the outbox if it does not exist", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(notFound),
+				mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, cm *corev1.ConfigMap, _ ...interface{}) error {
+						Expect(cm.Name).To(Equal(NotificationOutboxConfigMap))
+						Expect(cm.Namespace).To(Equal(testNamespace))
+						entry := decodeEntry(cm, "4.14.2.StateStarted.webhook")
+						Expect(entry.Destination).To(Equal(NotifierDestinationWebhook))
+						Expect(entry.Description).To(Equal("started"))
+						Expect(entry.Attempts).To(Equal(1))
+						Expect(entry.LastError).To(Equal("fake error"))
+						Expect(entry.NextAttempt).To(BeTemporally("~", entry.EnqueuedAt.Add(30*time.Second), time.Second))
+						return nil
+					}),
+			)
+			Expect(outbox.Enqueue("4.14.2", MuoStateStarted, NotifierDestinationWebhook, "started", fakeError)).To(Succeed())
+		})
+
+		It("keeps a notification that is already queued", func() {
+			cm := newOutboxConfigMap(OutboxEntry{Version: "4.14.2", State: MuoStateStarted, Attempts: 3})
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, cm)
+			Expect(outbox.Enqueue("4.14.2", MuoStateStarted, "", "started", fakeError)).To(Succeed())
+		})
+
+		It("returns an error when the outbox can't be read", func() {
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(fakeError)
+			Expect(outbox.Enqueue("4.14.2", MuoStateStarted, "", "started", fakeError)).NotTo(Succeed())
+		})
+	})
+
+	Context("When checking whether notifications are queued", func() {
+		It("reports the notifications of the version only", func() {
+			cm := newOutboxConfigMap(OutboxEntry{Version: "4.14.20", State: MuoStateStarted})
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, cm).Times(2)
+			queued, err := outbox.HasQueued("4.14.2", NotifierDestinationOCM)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(queued).To(BeFalse())
+			queued, err = outbox.HasQueued("4.14.20", NotifierDestinationOCM)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(queued).To(BeTrue())
+		})
+
+		It("reports the notifications of the destination only", func() {
+			cm := newOutboxConfigMap(OutboxEntry{Version: "4.14.2", State: MuoStateStarted, Destination: NotifierDestinationWebhook})
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, cm).Times(2)
+			queued, err := outbox.HasQueued("4.14.2", NotifierDestinationOCM)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(queued).To(BeFalse())
+			queued, err = outbox.HasQueued("4.14.2", NotifierDestinationWebhook)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(queued).To(BeTrue())
+		})
+
+		It("reports nothing queued before the outbox exists", func() {
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(notFound)
+			queued, err := outbox.HasQueued("4.14.2", NotifierDestinationOCM)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(queued).To(BeFalse())
+		})
+	})
+
+	Context("When listing the queued notifications", func() {
+		It("lists them oldest first", func() {
+			now := time.Now().UTC().Truncate(time.Second)
+			cm := newOutboxConfigMap(
+				OutboxEntry{Version: "4.14.2", State: MuoStateCompleted, EnqueuedAt: now},
+				OutboxEntry{Version: "4.14.2", State: MuoStateStarted, EnqueuedAt: now.Add(-time.Hour)},
+			)
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, cm)
+			entries, err := outbox.List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+			Expect(entries[0].State).To(Equal(MuoStateStarted))
+			Expect(entries[1].State).To(Equal(MuoStateCompleted))
+		})
+	})
+
+	Context("When a queued notification fails again", func() {
+		It("backs off exponentially", func() {
+			entry := OutboxEntry{Version: "4.14.2", State: MuoStateStarted, Attempts: 3}
+			cm := newOutboxConfigMap(entry)
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, cm),
+				mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, cm *corev1.ConfigMap, _ ...interface{}) error {
+						updated := decodeEntry(cm, "4.14.2.StateStarted")
+						Expect(updated.Attempts).To(Equal(4))
+						Expect(updated.LastError).To(Equal("fake error"))
+						Expect(updated.NextAttempt).To(BeTemporally("~", time.Now().Add(4*time.Minute), time.Second))
+						return nil
+					}),
+			)
+			Expect(outbox.Retry(entry, fakeError)).To(Succeed())
+		})
+	})
+
+	Context("When removing a notification", func() {
+		It("removes it from the outbox", func() {
+			started := OutboxEntry{Version: "4.14.2", State: MuoStateStarted}
+			cm := newOutboxConfigMap(started, OutboxEntry{Version: "4.14.2", State: MuoStateCompleted})
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, cm),
+				mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, cm *corev1.ConfigMap, _ ...interface{}) error {
+						Expect(cm.Data).To(HaveLen(1))
+						Expect(cm.Data).To(HaveKey("4.14.2.StateCompleted"))
+						return nil
+					}),
+			)
+			Expect(outbox.Remove(started)).To(Succeed())
+		})
+
+		It("does nothing when it is not queued", func() {
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(notFound)
+			Expect(outbox.Remove(OutboxEntry{Version: "4.14.2", State: MuoStateStarted})).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,72 @@
+package notifier
+
+import "fmt"
+
+// NotifierDestination names a notifier notifications can be routed to
+type NotifierDestination string
+
+const (
+	// NotifierDestinationOCM is the OCM notifier
+	NotifierDestinationOCM NotifierDestination = "ocm"
+	// NotifierDestinationLog is the log notifier
+	NotifierDestinationLog NotifierDestination = "log"
+	// NotifierDestinationWebhook is the webhook notifier
+	NotifierDestinationWebhook NotifierDestination = "webhook"
+	// NotifierDestinationEmail is the email notifier
+	NotifierDestinationEmail NotifierDestination = "email"
+	// NotifierDestinationCloudEvents is the CloudEvents notifier
+	NotifierDestinationCloudEvents NotifierDestination = "cloudevents"
+	// NotifierDestinationEvents is the Kubernetes Events notifier
+	NotifierDestinationEvents NotifierDestination = "events"
+)
+
+// OptionalDestinations are the destinations whose failures do not fail the notifications
+var OptionalDestinations = []NotifierDestination{
+	NotifierDestinationWebhook,
+	NotifierDestinationEmail,
+	NotifierDestinationCloudEvents,
+	NotifierDestinationEvents,
+}
+
+// NotifierRoutingConfig holds the NotificationRoutes field for its routing configuration
+type NotifierRoutingConfig struct {
+	// NotificationRoutes maps a destination to the states notified to it. A destination
+	// without a route is notified of every state.
+	NotificationRoutes map[NotifierDestination][]MuoState `yaml:"notificationRoutes"`
+}
+
+// IsValid returns a nil error when the NotifierRoutingConfig is valid
+func (cfg *NotifierRoutingConfig) IsValid() error {
+	for destination, states := range cfg.NotificationRoutes {
+		switch destination {
+		case NotifierDestinationOCM, NotifierDestinationLog, NotifierDestinationWebhook, NotifierDestinationEmail, NotifierDestinationCloudEvents, NotifierDestinationEvents:
+		default:
+			return fmt.Errorf("notification route destination %q is not one of ocm, log, webhook, email, cloudevents or events", destination)
+		}
+		for _, state := range states {
+			if !state.IsValid() {
+				return fmt.Errorf("notification route %s has unknown state %q", destination, state)
+			}
+		}
+	}
+	return nil
+}
+
+// IsRouted returns true if a route is configured for the destination
+func (cfg *NotifierRoutingConfig) IsRouted(destination NotifierDestination) bool {
+	_, ok := cfg.NotificationRoutes[destination]
+	return ok
+}
+
+// GetStates returns the states routed to the destination, or nil if every state is
+func (cfg *NotifierRoutingConfig) GetStates(destination NotifierDestination) map[MuoState]bool {
+	routed, ok := cfg.NotificationRoutes[destination]
+	if !ok {
+		return nil
+	}
+	states := map[MuoState]bool{}
+	for _, state := range routed {
+		states[state] = true
+	}
+	return states
+}
@@ -0,0 +1,135 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/managed-upgrade-operator/util"
+)
+
+// NotificationStoreConfigMap is the name of the ConfigMap recording the notifications that have been sent
+const NotificationStoreConfigMap = "managed-upgrade-operator-notifications"
+
+// invalidKeyChars matches the characters that are not allowed in a ConfigMap key
+var invalidKeyChars = regexp.MustCompile(`[^-._a-zA-Z0-9]`)
+
+// NotificationStore records which notifications have been sent for an upgrade, so that they are sent
+// only once per version and state across operator restarts
+//
+//go:generate mockgen -destination=mocks/store.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/notifier NotificationStore
+type NotificationStore interface {
+	IsSent(version string, state MuoState) (bool, error)
+	SentAt(version string, state MuoState) (*time.Time, error)
+	SetSent(version string, state MuoState) error
+}
+
+// NewNotificationStore returns a NotificationStore backed by a ConfigMap in the operator namespace
+func NewNotificationStore(c client.Client) NotificationStore {
+	return &configMapStore{client: c}
+}
+
+type configMapStore struct {
+	client client.Client
+}
+
+// IsSent reports whether the notification of the state has been sent for the version
+func (s *configMapStore) IsSent(version string, state MuoState) (bool, error) {
+	cm, err := s.get()
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("can't read notification store: %v", err)
+	}
+	_, ok := cm.Data[storeKey(version, state)]
+	return ok, nil
+}
+
+// SentAt returns the time the notification of the state was last sent for the version, or nil if
+// it has not been sent
+func (s *configMapStore) SentAt(version string, state MuoState) (*time.Time, error) {
+	cm, err := s.get()
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("can't read notification store: %v", err)
+	}
+	value, ok := cm.Data[storeKey(version, state)]
+	if !ok {
+		return nil, nil
+	}
+	sentAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse the time notification '%s' was sent: %v", state, err)
+	}
+	return &sentAt, nil
+}
+
+// SetSent records that the notification of the state has been sent for the version. Records
+// of other versions are dropped, as notifications are only ever sent for the desired version.
+func (s *configMapStore) SetSent(version string, state MuoState) error {
+	cm, err := s.get()
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("can't read notification store: %v", err)
+	}
+	found := err == nil
+	if !found {
+		ns, err := util.GetOperatorNamespace()
+		if err != nil {
+			return err
+		}
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      NotificationStoreConfigMap,
+				Namespace: ns,
+			},
+		}
+	}
+
+	prefix := storeKey(version, "")
+	data := map[string]string{}
+	for key, sentAt := range cm.Data {
+		if strings.HasPrefix(key, prefix) {
+			data[key] = sentAt
+		}
+	}
+	data[storeKey(version, state)] = time.Now().UTC().Format(time.RFC3339)
+	cm.Data = data
+
+	if found {
+		err = s.client.Update(context.TODO(), cm)
+	} else {
+		err = s.client.Create(context.TODO(), cm)
+	}
+	if err != nil {
+		return fmt.Errorf("can't update notification store: %v", err)
+	}
+	return nil
+}
+
+func (s *configMapStore) get() (*corev1.ConfigMap, error) {
+	ns, err := util.GetOperatorNamespace()
+	if err != nil {
+		return nil, err
+	}
+	cm := &corev1.ConfigMap{}
+	err = s.client.Get(context.TODO(), client.ObjectKey{Name: NotificationStoreConfigMap, Namespace: ns}, cm)
+	if err != nil {
+		return nil, err
+	}
+	return cm, nil
+}
+
+// storeKey returns the ConfigMap key recording the notification of the state for the version
+func storeKey(version string, state MuoState) string {
+	return invalidKeyChars.ReplaceAllString(version, "_") + "." + string(state)
+}
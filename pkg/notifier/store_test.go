@@ -0,0 +1,126 @@
+package notifier
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+)
+
+var _ = Describe("Notification store", func() {
+	const testNamespace = "test-namespace"
+
+	var (
+		mockCtrl       *gomock.Controller
+		mockKubeClient *mocks.MockClient
+		store          NotificationStore
+		notFound       error
+	)
+
+	BeforeEach(func() {
+		_ = os.Setenv("OPERATOR_NAMESPACE", testNamespace)
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		store = NewNotificationStore(mockKubeClient)
+		notFound = errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, NotificationStoreConfigMap)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When checking whether a notification was sent", func() {
+		It("reports notifications recorded for the version and state", func() {
+			cm := corev1.ConfigMap{Data: map[string]string{"4.14.2.StateStarted": "2026-01-01T00:00:00Z"}}
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, cm).Times(2)
+			sent, err := store.IsSent("4.14.2", MuoStateStarted)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sent).To(BeTrue())
+			sent, err = store.IsSent("4.14.2", MuoStateCompleted)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sent).To(BeFalse())
+		})
+
+		It("reports nothing sent before the store exists", func() {
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(notFound)
+			sent, err := store.IsSent("4.14.2", MuoStateStarted)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sent).To(BeFalse())
+		})
+
+		It("returns an error when the store can't be read", func() {
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error"))
+			_, err := store.IsSent("4.14.2", MuoStateStarted)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When checking when a notification was sent", func() {
+		It("returns the time it was recorded", func() {
+			cm := corev1.ConfigMap{Data: map[string]string{"4.14.2.StateUpgradeReminderSL": "2026-01-01T00:00:00Z"}}
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, cm).Times(2)
+			sentAt, err := store.SentAt("4.14.2", MuoStateUpgradeReminderSL)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sentAt).NotTo(BeNil())
+			Expect(*sentAt).To(Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+			sentAt, err = store.SentAt("4.14.2", MuoStateStarted)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sentAt).To(BeNil())
+		})
+
+		It("returns nil before the store exists", func() {
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(notFound)
+			sentAt, err := store.SentAt("4.14.2", MuoStateStarted)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sentAt).To(BeNil())
+		})
+	})
+
+	Context("When recording a sent notification", func() {
+		It("creates the store if it does not exist", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(notFound),
+				mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, cm *corev1.ConfigMap, _ ...interface{}) error {
+						Expect(cm.Name).To(Equal(NotificationStoreConfigMap))
+						Expect(cm.Namespace).To(Equal(testNamespace))
+						Expect(cm.Data).To(HaveKey("4.14.2.StateStarted"))
+						return nil
+					}),
+			)
+			Expect(store.SetSent("4.14.2", MuoStateStarted)).To(Succeed())
+		})
+
+		It("drops the records of other versions", func() {
+			cm := corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: NotificationStoreConfigMap, Namespace: testNamespace},
+				Data: map[string]string{
+					"4.14.2.StateStarted":  "2026-01-01T00:00:00Z",
+					"4.14.20.StateStarted": "2026-01-01T00:00:00Z",
+					"4.13.9.StateStarted":  "2026-01-01T00:00:00Z",
+				},
+			}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, cm),
+				mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, cm *corev1.ConfigMap, _ ...interface{}) error {
+						Expect(cm.Data).To(HaveLen(2))
+						Expect(cm.Data).To(HaveKey("4.14.2.StateStarted"))
+						Expect(cm.Data).To(HaveKey("4.14.2.StateCompleted"))
+						return nil
+					}),
+			)
+			Expect(store.SetSent("4.14.2", MuoStateCompleted)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,97 @@
+package notifier
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+)
+
+// WebhookFormat is a preset payload format of the webhook notifier
+type WebhookFormat string
+
+const (
+	// WebhookFormatJSON posts the notification as a JSON object
+	WebhookFormatJSON WebhookFormat = "json"
+	// WebhookFormatSlack posts the notification as a Slack incoming webhook message
+	WebhookFormatSlack WebhookFormat = "slack"
+	// WebhookFormatTeams posts the notification as a Microsoft Teams incoming webhook message card
+	WebhookFormatTeams WebhookFormat = "teams"
+)
+
+const (
+	// webhookSecretURLKey is the key of the webhook Secret holding the webhook URL
+	webhookSecretURLKey = "url"
+	// webhookSecretAuthorizationKey is the key of the webhook Secret holding the Authorization header value
+	webhookSecretAuthorizationKey = "authorization"
+)
+
+// WebhookNotifierConfig holds the Webhook field for its webhook configuration
+type WebhookNotifierConfig struct {
+	Webhook WebhookConfig `yaml:"webhook"`
+}
+
+// WebhookConfig holds the configuration of the webhook notifier
+type WebhookConfig struct {
+	// URL the notifications are posted to
+	URL string `yaml:"url"`
+	// Format of the payload, one of json, slack or teams
+	Format WebhookFormat `yaml:"format"`
+	// Headers added to the requests
+	Headers map[string]string `yaml:"headers"`
+	// SecretRef is the name of a Secret in the operator namespace holding the url and authorization of the webhook
+	SecretRef string `yaml:"secretRef"`
+	// Templates override the payload of the format per notified state
+	Templates map[MuoState]string `yaml:"templates"`
+}
+
+// IsConfigured returns true if a webhook has been configured
+func (cfg *WebhookNotifierConfig) IsConfigured() bool {
+	return cfg.Webhook.URL != "" || cfg.Webhook.SecretRef != ""
+}
+
+// IsValid returns a nil error when the WebhookNotifierConfig is valid
+func (cfg *WebhookNotifierConfig) IsValid() error {
+	if !cfg.IsConfigured() {
+		return nil
+	}
+	if cfg.Webhook.URL != "" {
+		if err := validateWebhookURL(cfg.Webhook.URL); err != nil {
+			return err
+		}
+	}
+	if _, ok := webhookPresets[cfg.GetFormat()]; !ok {
+		return fmt.Errorf("webhook format %q is not one of json, slack or teams", cfg.Webhook.Format)
+	}
+	for state, text := range cfg.Webhook.Templates {
+		if !state.IsValid() {
+			return fmt.Errorf("webhook template for unknown state %q", state)
+		}
+		if _, err := newWebhookTemplate(string(state), text); err != nil {
+			return fmt.Errorf("webhook template for %s is invalid: %v", state, err)
+		}
+	}
+	return nil
+}
+
+// GetFormat returns the payload format of the webhook, json by default
+func (cfg *WebhookNotifierConfig) GetFormat() WebhookFormat {
+	if cfg.Webhook.Format == "" {
+		return WebhookFormatJSON
+	}
+	return WebhookFormat(strings.ToLower(string(cfg.Webhook.Format)))
+}
+
+// validateWebhookURL returns an error if the URL is not an absolute http(s) URL
+func validateWebhookURL(webhookURL string) error {
+	u, err := url.Parse(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("webhook URL is not a valid http(s) URL")
+	}
+	return nil
+}
+
+// newWebhookTemplate parses a webhook payload template
+func newWebhookTemplate(name string, text string) (*template.Template, error) {
+	return template.New(name).Funcs(webhookTemplateFuncs).Option("missingkey=error").Parse(text)
+}
@@ -0,0 +1,56 @@
+package notifier
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Webhook notifier config", func() {
+	var cfg WebhookNotifierConfig
+
+	BeforeEach(func() {
+		cfg = WebhookNotifierConfig{}
+	})
+
+	It("is valid when no webhook is configured", func() {
+		Expect(cfg.IsConfigured()).To(BeFalse())
+		Expect(cfg.IsValid()).To(Succeed())
+	})
+
+	It("defaults to the json format", func() {
+		cfg.Webhook.URL = "https://example.com/hook"
+		Expect(cfg.IsValid()).To(Succeed())
+		Expect(cfg.GetFormat()).To(Equal(WebhookFormatJSON))
+	})
+
+	It("accepts a webhook URL held by a Secret only", func() {
+		cfg.Webhook.SecretRef = "webhook"
+		cfg.Webhook.Format = "Slack"
+		Expect(cfg.IsConfigured()).To(BeTrue())
+		Expect(cfg.IsValid()).To(Succeed())
+		Expect(cfg.GetFormat()).To(Equal(WebhookFormatSlack))
+	})
+
+	It("rejects URLs that are not http(s)", func() {
+		cfg.Webhook.URL = "ftp://example.com/hook"
+		Expect(cfg.IsValid()).NotTo(Succeed())
+	})
+
+	It("rejects unknown formats", func() {
+		cfg.Webhook.URL = "https://example.com/hook"
+		cfg.Webhook.Format = "irc"
+		Expect(cfg.IsValid()).NotTo(Succeed())
+	})
+
+	It("rejects templates that do not parse", func() {
+		cfg.Webhook.URL = "https://example.com/hook"
+		cfg.Webhook.Templates = map[MuoState]string{MuoStateStarted: `{"text": {{ json .Description }`}
+		Expect(cfg.IsValid()).NotTo(Succeed())
+	})
+
+	It("rejects templates of unknown states", func() {
+		cfg.Webhook.URL = "https://example.com/hook"
+		cfg.Webhook.Templates = map[MuoState]string{"StateFinished": `{"text": {{ json .Description }}}`}
+		Expect(cfg.IsValid()).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,180 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"text/template"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	"github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
+	"github.com/openshift/managed-upgrade-operator/util"
+)
+
+// webhookTimeout is the time allowed for a webhook to answer
+const webhookTimeout = 30 * time.Second
+
+// webhookTemplateFuncs are the functions available to webhook payload templates
+var webhookTemplateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// webhookPresets are the payload templates of the webhook formats
+var webhookPresets = map[WebhookFormat]string{
+	WebhookFormatJSON: `{"state":{{ json .State }},"summary":{{ json .Summary }},"description":{{ json .Description }},` +
+		`"version":{{ json .Version }},"clusterID":{{ json .ClusterID }},"time":{{ json .Time }}}`,
+	WebhookFormatSlack: `{"text":{{ json (printf "*%s* (cluster %s, version %s)\n%s" .Summary .ClusterID .Version .Description) }}}`,
+	WebhookFormatTeams: `{"@type":"MessageCard","@context":"https://schema.org/extensions","summary":{{ json .Summary }},` +
+		`"title":{{ json (printf "%s (cluster %s, version %s)" .Summary .ClusterID .Version) }},"text":{{ json .Description }}}`,
+}
+
+// NewWebhookNotifier returns a webhookNotifier
+func NewWebhookNotifier(client client.Client, cfg *WebhookNotifierConfig, upgradeConfigManager upgradeconfigmanager.UpgradeConfigManager, cvClient cv.ClusterVersion) (*webhookNotifier, error) {
+	format := cfg.GetFormat()
+	preset, err := newWebhookTemplate(string(format), webhookPresets[format])
+	if err != nil {
+		return nil, err
+	}
+	templates := map[MuoState]*template.Template{}
+	for state, text := range cfg.Webhook.Templates {
+		templates[state], err = newWebhookTemplate(string(state), text)
+		if err != nil {
+			return nil, fmt.Errorf("webhook template for %s is invalid: %v", state, err)
+		}
+	}
+
+	return &webhookNotifier{
+		client:               client,
+		cfg:                  cfg.Webhook,
+		preset:               preset,
+		templates:            templates,
+		upgradeConfigManager: upgradeConfigManager,
+		cvClient:             cvClient,
+		httpClient:           newWebhookHTTPClient(),
+	}, nil
+}
+
+// A notifier that posts to a webhook
+type webhookNotifier struct {
+	// Cluster k8s client
+	client client.Client
+	// Webhook configuration
+	cfg WebhookConfig
+	// Payload template of the configured format
+	preset *template.Template
+	// Payload templates overriding the preset per state
+	templates map[MuoState]*template.Template
+	// Retrieves the upgrade config from the cluster
+	upgradeConfigManager upgradeconfigmanager.UpgradeConfigManager
+	// Retrieves the cluster ID
+	cvClient cv.ClusterVersion
+	// HTTP client posting to the webhook
+	httpClient *http.Client
+}
+
+func (s *webhookNotifier) NotifyState(state MuoState, description string) error {
+	message, err := newNotificationMessage(state, description, s.upgradeConfigManager, s.cvClient)
+	if err != nil {
+		return err
+	}
+
+	tmpl, ok := s.templates[state]
+	if !ok {
+		tmpl = s.preset
+	}
+	payload := &bytes.Buffer{}
+	err = tmpl.Execute(payload, message)
+	if err != nil {
+		return fmt.Errorf("can't render webhook payload: %v", err)
+	}
+
+	webhookURL, authorization, err := s.credentials()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, webhookURL, payload)
+	if err != nil {
+		return fmt.Errorf("can't create webhook request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range s.cfg.Headers {
+		req.Header.Set(name, value)
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("can't send webhook notification: %v", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// credentials returns the webhook URL and the Authorization header value
+func (s *webhookNotifier) credentials() (string, string, error) {
+	return readWebhookCredentials(s.client, s.cfg.SecretRef, s.cfg.URL)
+}
+
+// readWebhookCredentials returns the URL and the Authorization header value of a webhook. The URL
+// held by the webhook Secret, if any, takes precedence over the configured one.
+func readWebhookCredentials(c client.Client, secretRef string, configuredURL string) (string, string, error) {
+	if secretRef == "" {
+		return configuredURL, "", nil
+	}
+
+	ns, err := util.GetOperatorNamespace()
+	if err != nil {
+		return "", "", err
+	}
+	secret := &corev1.Secret{}
+	err = c.Get(context.TODO(), client.ObjectKey{Name: secretRef, Namespace: ns}, secret)
+	if err != nil {
+		return "", "", fmt.Errorf("can't read webhook secret %s: %v", secretRef, err)
+	}
+
+	webhookURL := configuredURL
+	if u, ok := secret.Data[webhookSecretURLKey]; ok {
+		webhookURL = string(u)
+		if err := validateWebhookURL(webhookURL); err != nil {
+			return "", "", fmt.Errorf("webhook secret %s: %v", secretRef, err)
+		}
+	}
+	if webhookURL == "" {
+		return "", "", fmt.Errorf("no webhook URL configured")
+	}
+	return webhookURL, string(secret.Data[webhookSecretAuthorizationKey]), nil
+}
+
+// newWebhookHTTPClient returns the HTTP client posting to webhooks
+func newWebhookHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: webhookTimeout,
+		Transport: &http.Transport{
+			// Use proxy from environment variables if configured
+			// See: https://pkg.go.dev/net/http#ProxyFromEnvironment
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+	}
+}
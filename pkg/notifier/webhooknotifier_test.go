@@ -0,0 +1,119 @@
+package notifier
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	cvMocks "github.com/openshift/managed-upgrade-operator/pkg/clusterversion/mocks"
+	ucMgrMocks "github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager/mocks"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+)
+
+var _ = Describe("Webhook Notifier", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		mockKubeClient           *mocks.MockClient
+		mockUpgradeConfigManager *ucMgrMocks.MockUpgradeConfigManager
+		mockCVClient             *cvMocks.MockClusterVersion
+		server                   *httptest.Server
+		requests                 []*http.Request
+		bodies                   []string
+		status                   int
+		cfg                      *WebhookNotifierConfig
+		uc                       *upgradev1alpha1.UpgradeConfig
+	)
+
+	BeforeEach(func() {
+		_ = os.Setenv("OPERATOR_NAMESPACE", "test-namespace")
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockUpgradeConfigManager = ucMgrMocks.NewMockUpgradeConfigManager(mockCtrl)
+		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
+		requests = nil
+		bodies = nil
+		status = http.StatusOK
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			requests = append(requests, r)
+			bodies = append(bodies, string(body))
+			w.WriteHeader(status)
+		}))
+		cfg = &WebhookNotifierConfig{Webhook: WebhookConfig{URL: server.URL, Headers: map[string]string{"X-Team": "sre"}}}
+		uc = &upgradev1alpha1.UpgradeConfig{Spec: upgradev1alpha1.UpgradeConfigSpec{Desired: upgradev1alpha1.Update{Version: "4.14.2"}}}
+	})
+
+	AfterEach(func() {
+		server.Close()
+		mockCtrl.Finish()
+	})
+
+	notify := func(state MuoState, description string) error {
+		n, err := NewWebhookNotifier(mockKubeClient, cfg, mockUpgradeConfigManager, mockCVClient)
+		Expect(err).NotTo(HaveOccurred())
+		return n.NotifyState(state, description)
+	}
+
+	It("posts the json payload with the configured headers", func() {
+		mockCVClient.EXPECT().GetClusterId().Return("cluster-id")
+		mockUpgradeConfigManager.EXPECT().Get().Return(uc, nil)
+		Expect(notify(MuoStateStarted, "Cluster is currently being upgraded")).To(Succeed())
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Method).To(Equal(http.MethodPost))
+		Expect(requests[0].Header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(requests[0].Header.Get("X-Team")).To(Equal("sre"))
+		payload := map[string]string{}
+		Expect(json.Unmarshal([]byte(bodies[0]), &payload)).To(Succeed())
+		Expect(payload).To(HaveKeyWithValue("state", "StateStarted"))
+		Expect(payload).To(HaveKeyWithValue("summary", "Cluster upgrade started"))
+		Expect(payload).To(HaveKeyWithValue("description", "Cluster is currently being upgraded"))
+		Expect(payload).To(HaveKeyWithValue("version", "4.14.2"))
+		Expect(payload).To(HaveKeyWithValue("clusterID", "cluster-id"))
+	})
+
+	It("posts a Slack message", func() {
+		cfg.Webhook.Format = WebhookFormatSlack
+		mockCVClient.EXPECT().GetClusterId().Return("cluster-id")
+		mockUpgradeConfigManager.EXPECT().Get().Return(uc, nil)
+		Expect(notify(MuoStateFailed, "Upgrade \"failed\"")).To(Succeed())
+		payload := map[string]string{}
+		Expect(json.Unmarshal([]byte(bodies[0]), &payload)).To(Succeed())
+		Expect(payload).To(HaveKeyWithValue("text", "*Cluster upgrade failed* (cluster cluster-id, version 4.14.2)\nUpgrade \"failed\""))
+	})
+
+	It("renders the template configured for the state", func() {
+		cfg.Webhook.Templates = map[MuoState]string{MuoStateCompleted: `{"text":{{ json (printf "%s is done" .Version) }}}`}
+		mockCVClient.EXPECT().GetClusterId().Return("cluster-id")
+		mockUpgradeConfigManager.EXPECT().Get().Return(uc, nil)
+		Expect(notify(MuoStateCompleted, "Cluster has been upgraded")).To(Succeed())
+		Expect(bodies[0]).To(Equal(`{"text":"4.14.2 is done"}`))
+	})
+
+	It("reads the URL and authorization from the Secret", func() {
+		cfg.Webhook.URL = "https://unused.example.com"
+		cfg.Webhook.SecretRef = "webhook"
+		secret := corev1.Secret{Data: map[string][]byte{"url": []byte(server.URL), "authorization": []byte("Bearer token")}}
+		mockCVClient.EXPECT().GetClusterId().Return("cluster-id")
+		mockUpgradeConfigManager.EXPECT().Get().Return(uc, nil)
+		mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, secret)
+		Expect(notify(MuoStateStarted, "Cluster is currently being upgraded")).To(Succeed())
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Header.Get("Authorization")).To(Equal("Bearer token"))
+	})
+
+	It("returns an error when the webhook does not accept the notification", func() {
+		status = http.StatusInternalServerError
+		mockCVClient.EXPECT().GetClusterId().Return("cluster-id")
+		mockUpgradeConfigManager.EXPECT().Get().Return(uc, nil)
+		Expect(notify(MuoStateStarted, "Cluster is currently being upgraded")).NotTo(Succeed())
+	})
+})
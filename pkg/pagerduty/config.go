@@ -0,0 +1,75 @@
+package pagerduty
+
+import (
+	"fmt"
+	"net/url"
+)
+
+const (
+	// defaultEventsURL is the PagerDuty Events API v2 endpoint
+	defaultEventsURL = "https://events.pagerduty.com/v2/enqueue"
+	// secretRoutingKeyKey is the key of the Secret holding the routing key
+	secretRoutingKeyKey = "routingKey"
+)
+
+// PagerDutyConfig holds the PagerDuty field for its PagerDuty configuration
+type PagerDutyConfig struct {
+	PagerDuty EventsConfig `yaml:"pagerDuty"`
+}
+
+// EventsConfig holds the configuration of the PagerDuty Events API v2 integration
+type EventsConfig struct {
+	// RoutingKey is the integration key of the PagerDuty service
+	RoutingKey string `yaml:"routingKey"`
+	// SecretRef is the name of a Secret in the operator namespace holding the routing key
+	SecretRef string `yaml:"secretRef"`
+	// URL overrides the Events API endpoint, e.g. for the EU service region
+	URL string `yaml:"url"`
+	// Source overrides the source of the incidents, the cluster ID by default
+	Source string `yaml:"source"`
+	// Severities override the severity of the incidents
+	Severities map[Incident]Severity `yaml:"severities"`
+}
+
+// IsConfigured returns true if a PagerDuty routing key has been configured
+func (cfg *PagerDutyConfig) IsConfigured() bool {
+	return cfg.PagerDuty.RoutingKey != "" || cfg.PagerDuty.SecretRef != ""
+}
+
+// IsValid returns a nil error when the PagerDutyConfig is valid
+func (cfg *PagerDutyConfig) IsValid() error {
+	if !cfg.IsConfigured() {
+		return nil
+	}
+	if cfg.PagerDuty.URL != "" {
+		u, err := url.Parse(cfg.PagerDuty.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("pagerDuty url is not a valid http(s) URL")
+		}
+	}
+	for incident, severity := range cfg.PagerDuty.Severities {
+		if _, ok := defaultSeverities[incident]; !ok {
+			return fmt.Errorf("pagerDuty severities contains unknown incident %q", incident)
+		}
+		if !severity.isValid() {
+			return fmt.Errorf("pagerDuty severity for %s is invalid (Requires one of critical, error, warning, info)", incident)
+		}
+	}
+	return nil
+}
+
+// GetURL returns the Events API endpoint
+func (cfg *PagerDutyConfig) GetURL() string {
+	if cfg.PagerDuty.URL == "" {
+		return defaultEventsURL
+	}
+	return cfg.PagerDuty.URL
+}
+
+// GetSeverity returns the severity of the incident
+func (cfg *PagerDutyConfig) GetSeverity(incident Incident) Severity {
+	if severity, ok := cfg.PagerDuty.Severities[incident]; ok {
+		return severity
+	}
+	return defaultSeverities[incident]
+}
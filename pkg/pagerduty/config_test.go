@@ -0,0 +1,48 @@
+package pagerduty
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PagerDuty config", func() {
+	var cfg *PagerDutyConfig
+
+	BeforeEach(func() {
+		cfg = &PagerDutyConfig{PagerDuty: EventsConfig{RoutingKey: "routing-key"}}
+	})
+
+	It("is not configured without a routing key", func() {
+		cfg.PagerDuty.RoutingKey = ""
+		Expect(cfg.IsConfigured()).To(BeFalse())
+		Expect(cfg.IsValid()).To(Succeed())
+	})
+
+	It("uses the Events API and default severities", func() {
+		Expect(cfg.IsValid()).To(Succeed())
+		Expect(cfg.GetURL()).To(Equal(defaultEventsURL))
+		Expect(cfg.GetSeverity(IncidentControlPlaneTimeout)).To(Equal(SeverityCritical))
+		Expect(cfg.GetSeverity(IncidentNodeDrainFailed)).To(Equal(SeverityWarning))
+	})
+
+	It("overrides the severity of an incident", func() {
+		cfg.PagerDuty.Severities = map[Incident]Severity{IncidentNodeDrainFailed: SeverityError}
+		Expect(cfg.IsValid()).To(Succeed())
+		Expect(cfg.GetSeverity(IncidentNodeDrainFailed)).To(Equal(SeverityError))
+	})
+
+	It("rejects an unknown incident", func() {
+		cfg.PagerDuty.Severities = map[Incident]Severity{"NodeOnFire": SeverityError}
+		Expect(cfg.IsValid()).NotTo(Succeed())
+	})
+
+	It("rejects an unknown severity", func() {
+		cfg.PagerDuty.Severities = map[Incident]Severity{IncidentWorkerTimeout: "page"}
+		Expect(cfg.IsValid()).NotTo(Succeed())
+	})
+
+	It("rejects an invalid URL", func() {
+		cfg.PagerDuty.URL = "events.pagerduty.com"
+		Expect(cfg.IsValid()).NotTo(Succeed())
+	})
+})
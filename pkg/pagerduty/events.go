@@ -0,0 +1,166 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/managed-upgrade-operator/util"
+)
+
+const (
+	// eventsTimeout is the time allowed for the Events API to answer
+	eventsTimeout = 30 * time.Second
+	// maxSummaryLength is the maximum length of an incident summary accepted by the Events API
+	maxSummaryLength = 1024
+	// component is the component of the incidents
+	component = "managed-upgrade-operator"
+)
+
+// event is an Events API v2 event
+type event struct {
+	RoutingKey  string        `json:"routing_key"`
+	EventAction string        `json:"event_action"`
+	DedupKey    string        `json:"dedup_key"`
+	Payload     *eventPayload `json:"payload,omitempty"`
+}
+
+// eventPayload describes the incident of a trigger event
+type eventPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      Severity          `json:"severity"`
+	Component     string            `json:"component"`
+	Group         string            `json:"group,omitempty"`
+	Class         string            `json:"class"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+// eventsClient sends events to the PagerDuty Events API v2
+type eventsClient struct {
+	client     client.Client
+	cfg        *PagerDutyConfig
+	httpClient *http.Client
+}
+
+func newEventsClient(c client.Client, cfg *PagerDutyConfig) *eventsClient {
+	return &eventsClient{
+		client: c,
+		cfg:    cfg,
+		httpClient: &http.Client{
+			Timeout: eventsTimeout,
+			Transport: &http.Transport{
+				// Use proxy from environment variables if configured
+				// See: https://pkg.go.dev/net/http#ProxyFromEnvironment
+				Proxy: http.ProxyFromEnvironment,
+				DialContext: (&net.Dialer{
+					Timeout:   30 * time.Second,
+					KeepAlive: 30 * time.Second,
+				}).DialContext,
+				TLSHandshakeTimeout: 10 * time.Second,
+			},
+		},
+	}
+}
+
+// trigger sends a trigger event for the incident
+func (e *eventsClient) trigger(record openIncident, clusterID string, summary string) error {
+	if len(summary) > maxSummaryLength {
+		summary = summary[:maxSummaryLength]
+	}
+	source := e.cfg.PagerDuty.Source
+	if source == "" {
+		source = clusterID
+	}
+	details := map[string]string{
+		"clusterID": clusterID,
+		"version":   record.Version,
+	}
+	if record.Subject != "" {
+		details["subject"] = record.Subject
+	}
+	return e.send(event{
+		EventAction: "trigger",
+		DedupKey:    record.DedupKey,
+		Payload: &eventPayload{
+			Summary:       summary,
+			Source:        source,
+			Severity:      e.cfg.GetSeverity(record.Incident),
+			Component:     component,
+			Group:         record.Version,
+			Class:         string(record.Incident),
+			CustomDetails: details,
+		},
+	})
+}
+
+// resolve sends a resolve event for the incident
+func (e *eventsClient) resolve(record openIncident) error {
+	return e.send(event{
+		EventAction: "resolve",
+		DedupKey:    record.DedupKey,
+	})
+}
+
+// send posts the event with the configured routing key
+func (e *eventsClient) send(ev event) error {
+	routingKey, err := e.routingKey()
+	if err != nil {
+		return err
+	}
+	ev.RoutingKey = routingKey
+
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("can't encode PagerDuty event: %v", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, e.cfg.GetURL(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("can't create PagerDuty event request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("can't send PagerDuty event: %v", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("PagerDuty Events API returned %s", resp.Status)
+	}
+	return nil
+}
+
+// routingKey returns the routing key from the referenced Secret, or else the configured one
+func (e *eventsClient) routingKey() (string, error) {
+	if e.cfg.PagerDuty.SecretRef == "" {
+		return e.cfg.PagerDuty.RoutingKey, nil
+	}
+
+	ns, err := util.GetOperatorNamespace()
+	if err != nil {
+		return "", err
+	}
+	secret := &corev1.Secret{}
+	err = e.client.Get(context.TODO(), client.ObjectKey{Name: e.cfg.PagerDuty.SecretRef, Namespace: ns}, secret)
+	if err != nil {
+		return "", fmt.Errorf("can't read PagerDuty secret %s: %v", e.cfg.PagerDuty.SecretRef, err)
+	}
+	routingKey := string(secret.Data[secretRoutingKeyKey])
+	if routingKey == "" {
+		routingKey = e.cfg.PagerDuty.RoutingKey
+	}
+	if routingKey == "" {
+		return "", fmt.Errorf("no PagerDuty routing key configured")
+	}
+	return routingKey, nil
+}
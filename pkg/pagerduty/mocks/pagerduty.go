@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/openshift/managed-upgrade-operator/pkg/pagerduty (interfaces: IncidentManager)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/pagerduty.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/pagerduty IncidentManager
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	pagerduty "github.com/openshift/managed-upgrade-operator/pkg/pagerduty"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIncidentManager is a mock of IncidentManager interface.
+type MockIncidentManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockIncidentManagerMockRecorder
+}
+
+// MockIncidentManagerMockRecorder is the mock recorder for MockIncidentManager.
+type MockIncidentManagerMockRecorder struct {
+	mock *MockIncidentManager
+}
+
+// NewMockIncidentManager creates a new mock instance.
+func NewMockIncidentManager(ctrl *gomock.Controller) *MockIncidentManager {
+	mock := &MockIncidentManager{ctrl: ctrl}
+	mock.recorder = &MockIncidentManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIncidentManager) EXPECT() *MockIncidentManagerMockRecorder {
+	return m.recorder
+}
+
+// Resolve mocks base method.
+func (m *MockIncidentManager) Resolve(arg0 pagerduty.Incident, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockIncidentManagerMockRecorder) Resolve(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockIncidentManager)(nil).Resolve), arg0, arg1, arg2)
+}
+
+// ResolveAll mocks base method.
+func (m *MockIncidentManager) ResolveAll(arg0 pagerduty.Incident) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveAll", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveAll indicates an expected call of ResolveAll.
+func (mr *MockIncidentManagerMockRecorder) ResolveAll(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveAll", reflect.TypeOf((*MockIncidentManager)(nil).ResolveAll), arg0)
+}
+
+// ResolveOtherVersions mocks base method.
+func (m *MockIncidentManager) ResolveOtherVersions(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveOtherVersions", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveOtherVersions indicates an expected call of ResolveOtherVersions.
+func (mr *MockIncidentManagerMockRecorder) ResolveOtherVersions(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveOtherVersions", reflect.TypeOf((*MockIncidentManager)(nil).ResolveOtherVersions), arg0)
+}
+
+// Trigger mocks base method.
+func (m *MockIncidentManager) Trigger(arg0 pagerduty.Incident, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trigger", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Trigger indicates an expected call of Trigger.
+func (mr *MockIncidentManagerMockRecorder) Trigger(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trigger", reflect.TypeOf((*MockIncidentManager)(nil).Trigger), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/openshift/managed-upgrade-operator/pkg/pagerduty (interfaces: IncidentManagerBuilder)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/pagerduty_builder.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/pagerduty IncidentManagerBuilder
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	configmanager "github.com/openshift/managed-upgrade-operator/pkg/configmanager"
+	pagerduty "github.com/openshift/managed-upgrade-operator/pkg/pagerduty"
+	gomock "go.uber.org/mock/gomock"
+	client "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MockIncidentManagerBuilder is a mock of IncidentManagerBuilder interface.
+type MockIncidentManagerBuilder struct {
+	ctrl     *gomock.Controller
+	recorder *MockIncidentManagerBuilderMockRecorder
+}
+
+// MockIncidentManagerBuilderMockRecorder is the mock recorder for MockIncidentManagerBuilder.
+type MockIncidentManagerBuilderMockRecorder struct {
+	mock *MockIncidentManagerBuilder
+}
+
+// NewMockIncidentManagerBuilder creates a new mock instance.
+func NewMockIncidentManagerBuilder(ctrl *gomock.Controller) *MockIncidentManagerBuilder {
+	mock := &MockIncidentManagerBuilder{ctrl: ctrl}
+	mock.recorder = &MockIncidentManagerBuilderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIncidentManagerBuilder) EXPECT() *MockIncidentManagerBuilderMockRecorder {
+	return m.recorder
+}
+
+// New mocks base method.
+func (m *MockIncidentManagerBuilder) New(arg0 client.Client, arg1 configmanager.ConfigManager) (pagerduty.IncidentManager, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "New", arg0, arg1)
+	ret0, _ := ret[0].(pagerduty.IncidentManager)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// New indicates an expected call of New.
+func (mr *MockIncidentManagerBuilderMockRecorder) New(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockIncidentManagerBuilder)(nil).New), arg0, arg1)
+}
@@ -0,0 +1,260 @@
+package pagerduty
+
+import (
+	"github.com/hashicorp/go-multierror"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	"github.com/openshift/managed-upgrade-operator/pkg/configmanager"
+)
+
+var log = logf.Log.WithName("pagerduty")
+
+// Incident is a condition of the upgrade PagerDuty incidents are triggered for
+type Incident string
+
+const (
+	// IncidentControlPlaneTimeout is triggered when the control plane upgrade exceeds its maintenance window
+	IncidentControlPlaneTimeout Incident = "ControlPlaneTimeout"
+	// IncidentWorkerTimeout is triggered when workers are upgrading without an active maintenance window
+	IncidentWorkerTimeout Incident = "WorkerTimeout"
+	// IncidentNodeDrainFailed is triggered when a node fails to drain in time
+	IncidentNodeDrainFailed Incident = "NodeDrainFailed"
+	// IncidentUpgradeWindowBreached is triggered when the upgrade did not commence within its window
+	IncidentUpgradeWindowBreached Incident = "UpgradeWindowBreached"
+	// IncidentPostUpgradeHealthCheckFailed is triggered when the cluster is unhealthy after the upgrade
+	IncidentPostUpgradeHealthCheckFailed Incident = "PostUpgradeHealthCheckFailed"
+)
+
+// Severity is the PagerDuty severity of an incident
+type Severity string
+
+// Severities of the PagerDuty incidents
+const (
+	SeverityCritical Severity = "critical"
+	SeverityError    Severity = "error"
+	SeverityWarning  Severity = "warning"
+	SeverityInfo     Severity = "info"
+)
+
+func (s Severity) isValid() bool {
+	switch s {
+	case SeverityCritical, SeverityError, SeverityWarning, SeverityInfo:
+		return true
+	}
+	return false
+}
+
+// defaultSeverities are the severities of the incidents when none is configured
+var defaultSeverities = map[Incident]Severity{
+	IncidentControlPlaneTimeout:          SeverityCritical,
+	IncidentWorkerTimeout:                SeverityError,
+	IncidentNodeDrainFailed:              SeverityWarning,
+	IncidentUpgradeWindowBreached:        SeverityError,
+	IncidentPostUpgradeHealthCheckFailed: SeverityCritical,
+}
+
+// IncidentManager triggers PagerDuty incidents for upgrade conditions and resolves them when the
+// conditions clear. An incident is identified by its kind, the upgrade version and an optional
+// subject, such as the name of a node.
+//
+//go:generate mockgen -destination=mocks/pagerduty.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/pagerduty IncidentManager
+type IncidentManager interface {
+	Trigger(incident Incident, version string, subject string, summary string) error
+	Resolve(incident Incident, version string, subject string) error
+	ResolveAll(incident Incident) error
+	ResolveOtherVersions(version string) error
+}
+
+// IncidentManagerBuilder enables implementation of an IncidentManagerBuilder
+//
+//go:generate mockgen -destination=mocks/pagerduty_builder.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/pagerduty IncidentManagerBuilder
+type IncidentManagerBuilder interface {
+	New(client.Client, configmanager.ConfigManager) (IncidentManager, error)
+}
+
+// NewBuilder returns an incidentManagerBuilder
+func NewBuilder() IncidentManagerBuilder {
+	return &incidentManagerBuilder{}
+}
+
+type incidentManagerBuilder struct{}
+
+// New returns an IncidentManager sending the incidents to PagerDuty, or one that does nothing
+// if PagerDuty is not configured
+func (b *incidentManagerBuilder) New(c client.Client, cfm configmanager.ConfigManager) (IncidentManager, error) {
+	cfg := &PagerDutyConfig{}
+	err := cfm.Into(cfg)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.IsValid()
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.IsConfigured() {
+		return &noopIncidentManager{}, nil
+	}
+	return newEventsIncidentManager(c, cfg, cv.NewCVClient(c)), nil
+}
+
+// NewLazyIncidentManager returns an IncidentManager that builds the IncidentManager configured for the
+// cluster when an incident is first triggered or resolved. If the PagerDuty configuration can't be
+// loaded, the error is logged and the incidents are dropped, so that PagerDuty holds nothing back.
+func NewLazyIncidentManager(b IncidentManagerBuilder, c client.Client, cfm configmanager.ConfigManager) IncidentManager {
+	return &lazyIncidentManager{
+		builder: b,
+		client:  c,
+		cfm:     cfm,
+	}
+}
+
+// lazyIncidentManager builds its IncidentManager on first use
+type lazyIncidentManager struct {
+	builder IncidentManagerBuilder
+	client  client.Client
+	cfm     configmanager.ConfigManager
+	manager IncidentManager
+}
+
+func (m *lazyIncidentManager) get() IncidentManager {
+	if m.manager == nil {
+		manager, err := m.builder.New(m.client, m.cfm)
+		if err != nil {
+			log.Error(err, "can't load the PagerDuty configuration, incidents are not sent")
+			manager = &noopIncidentManager{}
+		}
+		m.manager = manager
+	}
+	return m.manager
+}
+
+func (m *lazyIncidentManager) Trigger(incident Incident, version string, subject string, summary string) error {
+	return m.get().Trigger(incident, version, subject, summary)
+}
+
+func (m *lazyIncidentManager) Resolve(incident Incident, version string, subject string) error {
+	return m.get().Resolve(incident, version, subject)
+}
+
+func (m *lazyIncidentManager) ResolveAll(incident Incident) error {
+	return m.get().ResolveAll(incident)
+}
+
+func (m *lazyIncidentManager) ResolveOtherVersions(version string) error {
+	return m.get().ResolveOtherVersions(version)
+}
+
+// eventsIncidentManager sends the incidents to the PagerDuty Events API v2. The incidents it has
+// triggered are recorded so that they are resolved with the same dedup key, and only once.
+type eventsIncidentManager struct {
+	cvClient cv.ClusterVersion
+	events   *eventsClient
+	store    *incidentStore
+}
+
+func newEventsIncidentManager(c client.Client, cfg *PagerDutyConfig, cvClient cv.ClusterVersion) *eventsIncidentManager {
+	return &eventsIncidentManager{
+		cvClient: cvClient,
+		events:   newEventsClient(c, cfg),
+		store:    &incidentStore{client: c},
+	}
+}
+
+// Trigger triggers the incident unless it is already open
+func (m *eventsIncidentManager) Trigger(incident Incident, version string, subject string, summary string) error {
+	records, err := m.store.get()
+	if err != nil {
+		return err
+	}
+	key := incidentKey(incident, version, subject)
+	if _, ok := records.open[key]; ok {
+		return nil
+	}
+
+	clusterID := m.cvClient.GetClusterId()
+	record := openIncident{
+		Incident: incident,
+		Version:  version,
+		Subject:  subject,
+		DedupKey: clusterID + "/" + key,
+	}
+	err = m.events.trigger(record, clusterID, summary)
+	if err != nil {
+		return err
+	}
+	log.Info("Triggered PagerDuty incident", "incident", incident, "version", version, "subject", subject)
+	records.open[key] = record
+	return m.store.update(records)
+}
+
+// Resolve resolves the incident if it is open
+func (m *eventsIncidentManager) Resolve(incident Incident, version string, subject string) error {
+	return m.resolve(func(record openIncident) bool {
+		return record.Incident == incident && record.Version == version && record.Subject == subject
+	})
+}
+
+// ResolveAll resolves the open incidents of the kind, whatever their version and subject
+func (m *eventsIncidentManager) ResolveAll(incident Incident) error {
+	return m.resolve(func(record openIncident) bool {
+		return record.Incident == incident
+	})
+}
+
+// ResolveOtherVersions resolves the open incidents of the upgrades to other versions
+func (m *eventsIncidentManager) ResolveOtherVersions(version string) error {
+	return m.resolve(func(record openIncident) bool {
+		return record.Version != version
+	})
+}
+
+// resolve resolves the open incidents matched by the filter
+func (m *eventsIncidentManager) resolve(matches func(openIncident) bool) error {
+	records, err := m.store.get()
+	if err != nil {
+		return err
+	}
+
+	var me *multierror.Error
+	resolved := false
+	for key, record := range records.open {
+		if !matches(record) {
+			continue
+		}
+		err := m.events.resolve(record)
+		if err != nil {
+			me = multierror.Append(me, err)
+			continue
+		}
+		log.Info("Resolved PagerDuty incident", "incident", record.Incident, "version", record.Version, "subject", record.Subject)
+		delete(records.open, key)
+		resolved = true
+	}
+	if resolved {
+		if err := m.store.update(records); err != nil {
+			me = multierror.Append(me, err)
+		}
+	}
+	return me.ErrorOrNil()
+}
+
+// noopIncidentManager is used when PagerDuty is not configured
+type noopIncidentManager struct{}
+
+func (m *noopIncidentManager) Trigger(incident Incident, version string, subject string, summary string) error {
+	return nil
+}
+
+func (m *noopIncidentManager) Resolve(incident Incident, version string, subject string) error {
+	return nil
+}
+
+func (m *noopIncidentManager) ResolveAll(incident Incident) error {
+	return nil
+}
+
+func (m *noopIncidentManager) ResolveOtherVersions(version string) error {
+	return nil
+}
@@ -0,0 +1,13 @@
+package pagerduty
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestPagerDuty(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "PagerDuty Suite")
+}
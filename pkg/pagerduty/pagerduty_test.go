@@ -0,0 +1,241 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cvMocks "github.com/openshift/managed-upgrade-operator/pkg/clusterversion/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/configmanager"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+)
+
+var _ = Describe("PagerDuty incident manager", func() {
+	const (
+		testNamespace = "test-namespace"
+		testClusterID = "cluster-id"
+	)
+
+	var (
+		mockCtrl       *gomock.Controller
+		mockKubeClient *mocks.MockClient
+		mockCVClient   *cvMocks.MockClusterVersion
+		server         *httptest.Server
+		events         []event
+		status         int
+		cfg            *PagerDutyConfig
+		manager        IncidentManager
+		notFound       error
+	)
+
+	newStoreConfigMap := func(records ...openIncident) corev1.ConfigMap {
+		cm := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: IncidentStoreConfigMap, Namespace: testNamespace},
+			Data:       map[string]string{},
+		}
+		for _, record := range records {
+			value, err := json.Marshal(record)
+			Expect(err).NotTo(HaveOccurred())
+			cm.Data[incidentKey(record.Incident, record.Version, record.Subject)] = string(value)
+		}
+		return cm
+	}
+
+	BeforeEach(func() {
+		_ = os.Setenv("OPERATOR_NAMESPACE", testNamespace)
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
+		events = nil
+		status = http.StatusAccepted
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			body, err := io.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			ev := event{}
+			Expect(json.Unmarshal(body, &ev)).To(Succeed())
+			events = append(events, ev)
+			w.WriteHeader(status)
+		}))
+		cfg = &PagerDutyConfig{PagerDuty: EventsConfig{RoutingKey: "routing-key", URL: server.URL}}
+		manager = newEventsIncidentManager(mockKubeClient, cfg, mockCVClient)
+		notFound = errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, IncidentStoreConfigMap)
+	})
+
+	AfterEach(func() {
+		server.Close()
+		mockCtrl.Finish()
+	})
+
+	Context("When triggering an incident", func() {
+		It("sends a trigger event and records the incident", func() {
+			mockCVClient.EXPECT().GetClusterId().Return(testClusterID)
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(notFound),
+				mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, cm *corev1.ConfigMap, _ ...interface{}) error {
+						Expect(cm.Name).To(Equal(IncidentStoreConfigMap))
+						Expect(cm.Data).To(HaveKey("NodeDrainFailed_4.14.2_node-1"))
+						return nil
+					}),
+			)
+			Expect(manager.Trigger(IncidentNodeDrainFailed, "4.14.2", "node-1", "node-1 failed to drain")).To(Succeed())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].RoutingKey).To(Equal("routing-key"))
+			Expect(events[0].EventAction).To(Equal("trigger"))
+			Expect(events[0].DedupKey).To(Equal("cluster-id/NodeDrainFailed_4.14.2_node-1"))
+			Expect(events[0].Payload.Summary).To(Equal("node-1 failed to drain"))
+			Expect(events[0].Payload.Source).To(Equal(testClusterID))
+			Expect(events[0].Payload.Severity).To(Equal(SeverityWarning))
+			Expect(events[0].Payload.Class).To(Equal("NodeDrainFailed"))
+		})
+
+		It("does not trigger an incident that is already open", func() {
+			cm := newStoreConfigMap(openIncident{Incident: IncidentWorkerTimeout, Version: "4.14.2", DedupKey: "key"})
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, cm)
+			Expect(manager.Trigger(IncidentWorkerTimeout, "4.14.2", "", "workers timed out")).To(Succeed())
+			Expect(events).To(BeEmpty())
+		})
+
+		It("does not record the incident when PagerDuty rejects the event", func() {
+			status = http.StatusBadRequest
+			mockCVClient.EXPECT().GetClusterId().Return(testClusterID)
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(notFound)
+			Expect(manager.Trigger(IncidentWorkerTimeout, "4.14.2", "", "workers timed out")).NotTo(Succeed())
+		})
+
+		It("reads the routing key from the secret", func() {
+			cfg.PagerDuty.RoutingKey = ""
+			cfg.PagerDuty.SecretRef = "pagerduty"
+			secret := corev1.Secret{Data: map[string][]byte{"routingKey": []byte("secret-routing-key")}}
+			mockCVClient.EXPECT().GetClusterId().Return(testClusterID)
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(notFound),
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, secret),
+				mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil),
+			)
+			Expect(manager.Trigger(IncidentWorkerTimeout, "4.14.2", "", "workers timed out")).To(Succeed())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].RoutingKey).To(Equal("secret-routing-key"))
+		})
+	})
+
+	Context("When resolving incidents", func() {
+		var cm corev1.ConfigMap
+
+		BeforeEach(func() {
+			cm = newStoreConfigMap(
+				openIncident{Incident: IncidentNodeDrainFailed, Version: "4.14.2", Subject: "node-1", DedupKey: "key-1"},
+				openIncident{Incident: IncidentNodeDrainFailed, Version: "4.14.2", Subject: "node-2", DedupKey: "key-2"},
+				openIncident{Incident: IncidentUpgradeWindowBreached, Version: "4.14.1", DedupKey: "key-3"},
+			)
+		})
+
+		It("resolves the open incident with its dedup key", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, cm),
+				mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, cm *corev1.ConfigMap, _ ...interface{}) error {
+						Expect(cm.Data).To(HaveLen(2))
+						Expect(cm.Data).NotTo(HaveKey("NodeDrainFailed_4.14.2_node-1"))
+						return nil
+					}),
+			)
+			Expect(manager.Resolve(IncidentNodeDrainFailed, "4.14.2", "node-1")).To(Succeed())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].EventAction).To(Equal("resolve"))
+			Expect(events[0].DedupKey).To(Equal("key-1"))
+			Expect(events[0].Payload).To(BeNil())
+		})
+
+		It("does nothing when the incident is not open", func() {
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, cm)
+			Expect(manager.Resolve(IncidentWorkerTimeout, "4.14.2", "")).To(Succeed())
+			Expect(events).To(BeEmpty())
+		})
+
+		It("resolves every open incident of the kind", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, cm),
+				mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, cm *corev1.ConfigMap, _ ...interface{}) error {
+						Expect(cm.Data).To(HaveLen(1))
+						Expect(cm.Data).To(HaveKey("UpgradeWindowBreached_4.14.1"))
+						return nil
+					}),
+			)
+			Expect(manager.ResolveAll(IncidentNodeDrainFailed)).To(Succeed())
+			Expect(events).To(HaveLen(2))
+		})
+
+		It("resolves the open incidents of other versions", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, cm),
+				mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, cm *corev1.ConfigMap, _ ...interface{}) error {
+						Expect(cm.Data).To(HaveLen(2))
+						Expect(cm.Data).NotTo(HaveKey("UpgradeWindowBreached_4.14.1"))
+						return nil
+					}),
+			)
+			Expect(manager.ResolveOtherVersions("4.14.2")).To(Succeed())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].DedupKey).To(Equal("key-3"))
+		})
+
+		It("keeps the incident open when it can't be resolved", func() {
+			status = http.StatusInternalServerError
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, cm)
+			Expect(manager.Resolve(IncidentNodeDrainFailed, "4.14.2", "node-1")).NotTo(Succeed())
+		})
+
+		It("returns an error when the store can't be read", func() {
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error"))
+			Expect(manager.Resolve(IncidentNodeDrainFailed, "4.14.2", "node-1")).NotTo(Succeed())
+		})
+	})
+})
+
+// fakeIncidentManagerBuilder counts the incident managers it is asked to create
+type fakeIncidentManagerBuilder struct {
+	created int
+	manager IncidentManager
+	err     error
+}
+
+func (b *fakeIncidentManagerBuilder) New(client.Client, configmanager.ConfigManager) (IncidentManager, error) {
+	b.created++
+	return b.manager, b.err
+}
+
+var _ = Describe("Lazy PagerDuty incident manager", func() {
+	It("builds the incident manager on first use only", func() {
+		builder := &fakeIncidentManagerBuilder{manager: &noopIncidentManager{}}
+		manager := NewLazyIncidentManager(builder, nil, nil)
+		Expect(builder.created).To(Equal(0))
+		Expect(manager.Resolve(IncidentNodeDrainFailed, "4.14.2", "node-1")).To(Succeed())
+		Expect(manager.ResolveAll(IncidentNodeDrainFailed)).To(Succeed())
+		Expect(builder.created).To(Equal(1))
+	})
+
+	It("drops the incidents when the configuration can't be loaded", func() {
+		builder := &fakeIncidentManagerBuilder{err: fmt.Errorf("fake error")}
+		manager := NewLazyIncidentManager(builder, nil, nil)
+		Expect(manager.Trigger(IncidentNodeDrainFailed, "4.14.2", "node-1", "summary")).To(Succeed())
+		Expect(manager.ResolveOtherVersions("4.14.2")).To(Succeed())
+		Expect(builder.created).To(Equal(1))
+	})
+})
@@ -0,0 +1,108 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/managed-upgrade-operator/util"
+)
+
+// IncidentStoreConfigMap is the name of the ConfigMap recording the open PagerDuty incidents
+const IncidentStoreConfigMap = "managed-upgrade-operator-incidents"
+
+// openIncident is an incident triggered and not yet resolved
+type openIncident struct {
+	// Incident is the kind of the incident
+	Incident Incident `json:"incident"`
+	// Version is the desired version of the upgrade the incident is about
+	Version string `json:"version"`
+	// Subject is the object the incident is about, if any
+	Subject string `json:"subject,omitempty"`
+	// DedupKey is the key the incident was triggered with
+	DedupKey string `json:"dedupKey"`
+}
+
+// incidentKey returns the key of the incident in the store
+func incidentKey(incident Incident, version string, subject string) string {
+	parts := []string{string(incident), version}
+	if subject != "" {
+		parts = append(parts, subject)
+	}
+	return strings.Join(parts, "_")
+}
+
+// incidentRecords are the open incidents read from the store
+type incidentRecords struct {
+	cm    *corev1.ConfigMap
+	found bool
+	open  map[string]openIncident
+}
+
+// incidentStore records the open incidents in a ConfigMap in the operator namespace
+type incidentStore struct {
+	client client.Client
+}
+
+// get returns the open incidents
+func (s *incidentStore) get() (*incidentRecords, error) {
+	ns, err := util.GetOperatorNamespace()
+	if err != nil {
+		return nil, err
+	}
+	records := &incidentRecords{
+		cm:    &corev1.ConfigMap{},
+		found: true,
+		open:  map[string]openIncident{},
+	}
+	err = s.client.Get(context.TODO(), client.ObjectKey{Name: IncidentStoreConfigMap, Namespace: ns}, records.cm)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("can't read PagerDuty incident store: %v", err)
+		}
+		records.found = false
+		records.cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      IncidentStoreConfigMap,
+				Namespace: ns,
+			},
+		}
+	}
+	for key, value := range records.cm.Data {
+		record := openIncident{}
+		if err := json.Unmarshal([]byte(value), &record); err != nil {
+			return nil, fmt.Errorf("can't decode PagerDuty incident %s: %v", key, err)
+		}
+		records.open[key] = record
+	}
+	return records, nil
+}
+
+// update writes the open incidents to the store
+func (s *incidentStore) update(records *incidentRecords) error {
+	records.cm.Data = map[string]string{}
+	for key, record := range records.open {
+		value, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("can't encode PagerDuty incident %s: %v", key, err)
+		}
+		records.cm.Data[key] = string(value)
+	}
+
+	var err error
+	if records.found {
+		err = s.client.Update(context.TODO(), records.cm)
+	} else {
+		err = s.client.Create(context.TODO(), records.cm)
+	}
+	if err != nil {
+		return fmt.Errorf("can't update PagerDuty incident store: %v", err)
+	}
+	return nil
+}
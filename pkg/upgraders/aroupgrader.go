@@ -4,17 +4,20 @@ import (
 	"context"
 
 	"github.com/go-logr/logr"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
 	ac "github.com/openshift/managed-upgrade-operator/pkg/availabilitychecks"
 	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
 	"github.com/openshift/managed-upgrade-operator/pkg/configmanager"
+	"github.com/openshift/managed-upgrade-operator/pkg/consolebanner"
 	"github.com/openshift/managed-upgrade-operator/pkg/drain"
 	"github.com/openshift/managed-upgrade-operator/pkg/eventmanager"
 	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
 	"github.com/openshift/managed-upgrade-operator/pkg/maintenance"
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/pkg/pagerduty"
 	"github.com/openshift/managed-upgrade-operator/pkg/scaler"
 	"github.com/openshift/managed-upgrade-operator/pkg/upgradesteps"
 )
@@ -26,7 +29,7 @@ type aroUpgrader struct {
 }
 
 // NewAROUpgrader creates a new instance of an aroUpgrader
-func NewAROUpgrader(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metrics, notifier eventmanager.EventManager) (*aroUpgrader, error) {
+func NewAROUpgrader(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metrics, notifier eventmanager.EventManager, recorder record.EventRecorder) (*aroUpgrader, error) {
 	cfg := &upgraderConfig{}
 	err := cfm.Into(cfg)
 	if err != nil {
@@ -49,12 +52,15 @@ func NewAROUpgrader(c client.Client, cfm configmanager.ConfigManager, mc metrics
 			metrics:              mc,
 			cvClient:             cv.NewCVClient(c),
 			notifier:             notifier,
+			recorder:             recorder,
 			config:               cfg,
 			scaler:               scaler.NewScaler(),
 			drainstrategyBuilder: drain.NewBuilder(),
 			maintenance:          m,
 			machinery:            machinery.NewMachinery(),
 			availabilityCheckers: acs,
+			incidents:            pagerduty.NewLazyIncidentManager(pagerduty.NewBuilder(), c, cfm),
+			consoleBanner:        consolebanner.NewConsoleBanner(c, &cfg.ConsoleBanner),
 		},
 	}
 
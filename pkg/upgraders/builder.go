@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/openshift/managed-upgrade-operator/pkg/eventmanager"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
@@ -25,7 +26,7 @@ type ClusterUpgrader interface {
 //
 //go:generate mockgen -destination=mocks/cluster_upgrader_builder.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/upgraders ClusterUpgraderBuilder
 type ClusterUpgraderBuilder interface {
-	NewClient(client.Client, configmanager.ConfigManager, metrics.Metrics, eventmanager.EventManager, upgradev1alpha1.UpgradeType) (ClusterUpgrader, error)
+	NewClient(client.Client, configmanager.ConfigManager, metrics.Metrics, eventmanager.EventManager, record.EventRecorder, upgradev1alpha1.UpgradeType) (ClusterUpgrader, error)
 }
 
 // NewBuilder returns a clusterUpgraderBuilder
@@ -35,22 +36,22 @@ func NewBuilder() ClusterUpgraderBuilder {
 
 type clusterUpgraderBuilder struct{}
 
-func (cub *clusterUpgraderBuilder) NewClient(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metrics, nc eventmanager.EventManager, upgradeType upgradev1alpha1.UpgradeType) (ClusterUpgrader, error) {
+func (cub *clusterUpgraderBuilder) NewClient(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metrics, nc eventmanager.EventManager, recorder record.EventRecorder, upgradeType upgradev1alpha1.UpgradeType) (ClusterUpgrader, error) {
 	switch upgradeType {
 	case upgradev1alpha1.OSD:
-		cu, err := NewOSDUpgrader(c, cfm, mc, nc)
+		cu, err := NewOSDUpgrader(c, cfm, mc, nc, recorder)
 		if err != nil {
 			return nil, err
 		}
 		return cu, nil
 	case upgradev1alpha1.ARO:
-		cu, err := NewAROUpgrader(c, cfm, mc, nc)
+		cu, err := NewAROUpgrader(c, cfm, mc, nc, recorder)
 		if err != nil {
 			return nil, err
 		}
 		return cu, nil
 	default:
-		cu, err := NewOSDUpgrader(c, cfm, mc, nc)
+		cu, err := NewOSDUpgrader(c, cfm, mc, nc, recorder)
 		if err != nil {
 			return nil, err
 		}
@@ -5,7 +5,9 @@ import (
 	"path"
 	"time"
 
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
 	ac "github.com/openshift/managed-upgrade-operator/pkg/availabilitychecks"
+	"github.com/openshift/managed-upgrade-operator/pkg/consolebanner"
 	"github.com/openshift/managed-upgrade-operator/pkg/drain"
 )
 
@@ -18,6 +20,7 @@ type upgraderConfig struct {
 	UpgradeWindow                  upgradeWindow                     `yaml:"upgradeWindow"`
 	Environment                    environment                       `yaml:"environment"`
 	FeatureGate                    featureGate                       `yaml:"featureGate"`
+	ConsoleBanner                  consolebanner.Config              `yaml:"consoleBanner"`
 }
 
 type featureGate struct {
@@ -92,8 +95,129 @@ type scaleConfig struct {
 }
 
 type healthCheck struct {
-	IgnoredCriticals  []string `yaml:"ignoredCriticals"`
-	IgnoredNamespaces []string `yaml:"ignoredNamespaces"`
+	IgnoredCriticals  []string                                   `yaml:"ignoredCriticals"`
+	IgnoredNamespaces []string                                   `yaml:"ignoredNamespaces"`
+	Policies          map[HealthCheckName]healthCheckPhasePolicy `yaml:"policies"`
+	Certificates      certificatesHealthCheck                    `yaml:"certificates"`
+	// MonitoringFallback evaluates cluster health through the Kubernetes API when Prometheus cannot be queried
+	MonitoringFallback bool `yaml:"monitoringFallback"`
+	// AcknowledgeAPIRemovalsInUse acknowledges the API removals of the upgrade while removed APIs are still
+	// in use, when the deprecated APIs health check is not enforced
+	AcknowledgeAPIRemovalsInUse bool `yaml:"acknowledgeAPIRemovalsInUse"`
+}
+
+// certificatesHealthCheck holds the thresholds of the certificates health check
+type certificatesHealthCheck struct {
+	// PendingCSRAge is the time in minutes a kubelet CertificateSigningRequest may stay pending
+	PendingCSRAge int `yaml:"pendingCSRAge" default:"30"`
+	// ExpiryThreshold is the number of days before expiry at which a kubelet client or platform certificate is reported
+	ExpiryThreshold int `yaml:"expiryThreshold" default:"7"`
+}
+
+const (
+	defaultPendingCSRAge   = 30
+	defaultExpiryThreshold = 7
+)
+
+func (cfg *certificatesHealthCheck) GetPendingCSRAge() time.Duration {
+	if cfg.PendingCSRAge == 0 {
+		return defaultPendingCSRAge * time.Minute
+	}
+	return time.Duration(cfg.PendingCSRAge) * time.Minute
+}
+
+func (cfg *certificatesHealthCheck) GetExpiryThreshold() time.Duration {
+	if cfg.ExpiryThreshold == 0 {
+		return defaultExpiryThreshold * 24 * time.Hour
+	}
+	return time.Duration(cfg.ExpiryThreshold) * 24 * time.Hour
+}
+
+// healthCheckPolicy determines how the failure of a health check is treated
+type healthCheckPolicy string
+
+const (
+	// healthCheckPolicyEnforce runs the health check and blocks the upgrade step on failure
+	healthCheckPolicyEnforce healthCheckPolicy = "enforce"
+	// healthCheckPolicyWarn runs the health check and only notifies on failure
+	healthCheckPolicyWarn healthCheckPolicy = "warn"
+	// healthCheckPolicyOff skips the health check
+	healthCheckPolicyOff healthCheckPolicy = "off"
+)
+
+// healthCheckPhasePolicy holds the policy of a health check for each upgrade phase it runs in
+type healthCheckPhasePolicy struct {
+	New       healthCheckPolicy `yaml:"new"`
+	Upgrading healthCheckPolicy `yaml:"upgrading"`
+}
+
+// defaultHealthCheckPolicies are applied when a health check has no policy configured for a phase.
+// Only critical alerts, degraded cluster operators and an etcd at risk of losing quorum block an upgrade
+// that is in progress.
+var defaultHealthCheckPolicies = map[HealthCheckName]healthCheckPhasePolicy{
+	CriticalAlertsHealthCheck:          {New: healthCheckPolicyEnforce, Upgrading: healthCheckPolicyEnforce},
+	ClusterOperatorsHealthCheck:        {New: healthCheckPolicyEnforce, Upgrading: healthCheckPolicyEnforce},
+	CapacityReservationHealthCheck:     {New: healthCheckPolicyEnforce, Upgrading: healthCheckPolicyWarn},
+	ManuallyCordonedNodesHealthCheck:   {New: healthCheckPolicyEnforce, Upgrading: healthCheckPolicyWarn},
+	NodeUnschedulableTaintsHealthCheck: {New: healthCheckPolicyEnforce, Upgrading: healthCheckPolicyWarn},
+	PDBHealthCheck:                     {New: healthCheckPolicyEnforce, Upgrading: healthCheckPolicyWarn},
+	NodeConditionsHealthCheck:          {New: healthCheckPolicyEnforce, Upgrading: healthCheckPolicyWarn},
+	DrainFeasibilityHealthCheck:        {New: healthCheckPolicyEnforce, Upgrading: healthCheckPolicyWarn},
+	MachineConfigPoolsHealthCheck:      {New: healthCheckPolicyEnforce, Upgrading: healthCheckPolicyWarn},
+	CertificatesHealthCheck:            {New: healthCheckPolicyEnforce, Upgrading: healthCheckPolicyWarn},
+	KubeletVersionSkewHealthCheck:      {New: healthCheckPolicyEnforce, Upgrading: healthCheckPolicyWarn},
+	DeprecatedAPIsHealthCheck:          {New: healthCheckPolicyEnforce, Upgrading: healthCheckPolicyWarn},
+	OperatorCompatibilityHealthCheck:   {New: healthCheckPolicyEnforce, Upgrading: healthCheckPolicyWarn},
+	EtcdHealthCheck:                    {New: healthCheckPolicyEnforce, Upgrading: healthCheckPolicyEnforce},
+}
+
+func (p healthCheckPolicy) isValid() bool {
+	switch p {
+	case "", healthCheckPolicyEnforce, healthCheckPolicyWarn, healthCheckPolicyOff:
+		return true
+	}
+	return false
+}
+
+// IsValid returns an error if a threshold is negative or a policy refers to an unknown health check or policy value
+func (cfg *healthCheck) IsValid() error {
+	if cfg.Certificates.PendingCSRAge < 0 {
+		return fmt.Errorf("config healthCheck certificates pendingCSRAge is invalid")
+	}
+	if cfg.Certificates.ExpiryThreshold < 0 {
+		return fmt.Errorf("config healthCheck certificates expiryThreshold is invalid")
+	}
+	for name, policy := range cfg.Policies {
+		if _, ok := defaultHealthCheckPolicies[name]; !ok {
+			return fmt.Errorf("config healthCheck policies contains unknown health check %q", name)
+		}
+		if !policy.New.isValid() || !policy.Upgrading.isValid() {
+			return fmt.Errorf("config healthCheck policy for %s is invalid (Requires one of enforce, warn, off)", name)
+		}
+	}
+	return nil
+}
+
+// GetPolicy returns the policy of the named health check in the given upgrade phase.
+// Phases other than New and Upgrading always enforce the health check.
+func (cfg *healthCheck) GetPolicy(name HealthCheckName, phase upgradev1alpha1.UpgradePhase) healthCheckPolicy {
+	policy := defaultHealthCheckPolicies[name]
+	if configured, ok := cfg.Policies[name]; ok {
+		if configured.New != "" {
+			policy.New = configured.New
+		}
+		if configured.Upgrading != "" {
+			policy.Upgrading = configured.Upgrading
+		}
+	}
+
+	switch phase {
+	case upgradev1alpha1.UpgradePhaseNew:
+		return policy.New
+	case upgradev1alpha1.UpgradePhaseUpgrading:
+		return policy.Upgrading
+	}
+	return healthCheckPolicyEnforce
 }
 
 func (cfg *upgraderConfig) IsValid() error {
@@ -103,6 +227,12 @@ func (cfg *upgraderConfig) IsValid() error {
 	if err := cfg.Scale.IsValid(); err != nil {
 		return err
 	}
+	if err := cfg.HealthCheck.IsValid(); err != nil {
+		return err
+	}
+	if err := cfg.ConsoleBanner.IsValid(); err != nil {
+		return err
+	}
 	if cfg.NodeDrain.Timeout <= 0 {
 		return fmt.Errorf("config nodeDrain timeOut is invalid")
 	}
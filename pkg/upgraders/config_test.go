@@ -1,8 +1,12 @@
 package upgraders
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
 )
 
 var _ = Describe("scaleConfig", func() {
@@ -114,3 +118,93 @@ var _ = Describe("upgraderConfig", func() {
 		})
 	})
 })
+
+var _ = Describe("healthCheck", func() {
+	Describe("IsValid", func() {
+		It("returns no error when no policies are configured", func() {
+			cfg := &healthCheck{}
+			Expect(cfg.IsValid()).NotTo(HaveOccurred())
+		})
+
+		It("returns no error for valid policies", func() {
+			cfg := &healthCheck{
+				Policies: map[HealthCheckName]healthCheckPhasePolicy{
+					PDBHealthCheck:                   {New: healthCheckPolicyWarn, Upgrading: healthCheckPolicyOff},
+					ManuallyCordonedNodesHealthCheck: {Upgrading: healthCheckPolicyEnforce},
+				},
+			}
+			Expect(cfg.IsValid()).NotTo(HaveOccurred())
+		})
+
+		It("returns an error for an unknown health check", func() {
+			cfg := &healthCheck{
+				Policies: map[HealthCheckName]healthCheckPhasePolicy{
+					"NotAHealthCheck": {New: healthCheckPolicyWarn},
+				},
+			}
+			err := cfg.IsValid()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("NotAHealthCheck"))
+		})
+
+		It("returns an error for a negative certificates threshold", func() {
+			cfg := &healthCheck{Certificates: certificatesHealthCheck{PendingCSRAge: -1}}
+			err := cfg.IsValid()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("pendingCSRAge"))
+		})
+
+		It("returns an error for an unknown policy", func() {
+			cfg := &healthCheck{
+				Policies: map[HealthCheckName]healthCheckPhasePolicy{
+					PDBHealthCheck: {Upgrading: "block"},
+				},
+			}
+			err := cfg.IsValid()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("config healthCheck policy for PDB is invalid"))
+		})
+	})
+
+	Describe("Certificates", func() {
+		It("returns the default thresholds when none are configured", func() {
+			cfg := &certificatesHealthCheck{}
+			Expect(cfg.GetPendingCSRAge()).To(Equal(30 * time.Minute))
+			Expect(cfg.GetExpiryThreshold()).To(Equal(7 * 24 * time.Hour))
+		})
+
+		It("returns the configured thresholds", func() {
+			cfg := &certificatesHealthCheck{PendingCSRAge: 10, ExpiryThreshold: 2}
+			Expect(cfg.GetPendingCSRAge()).To(Equal(10 * time.Minute))
+			Expect(cfg.GetExpiryThreshold()).To(Equal(48 * time.Hour))
+		})
+	})
+
+	Describe("GetPolicy", func() {
+		It("returns the default policies when none are configured", func() {
+			cfg := &healthCheck{}
+			Expect(cfg.GetPolicy(CriticalAlertsHealthCheck, upgradev1alpha1.UpgradePhaseUpgrading)).To(Equal(healthCheckPolicyEnforce))
+			Expect(cfg.GetPolicy(PDBHealthCheck, upgradev1alpha1.UpgradePhaseNew)).To(Equal(healthCheckPolicyEnforce))
+			Expect(cfg.GetPolicy(PDBHealthCheck, upgradev1alpha1.UpgradePhaseUpgrading)).To(Equal(healthCheckPolicyWarn))
+		})
+
+		It("only overrides the phases that are configured", func() {
+			cfg := &healthCheck{
+				Policies: map[HealthCheckName]healthCheckPhasePolicy{
+					PDBHealthCheck: {Upgrading: healthCheckPolicyOff},
+				},
+			}
+			Expect(cfg.GetPolicy(PDBHealthCheck, upgradev1alpha1.UpgradePhaseNew)).To(Equal(healthCheckPolicyEnforce))
+			Expect(cfg.GetPolicy(PDBHealthCheck, upgradev1alpha1.UpgradePhaseUpgrading)).To(Equal(healthCheckPolicyOff))
+		})
+
+		It("enforces health checks outside of the New and Upgrading phases", func() {
+			cfg := &healthCheck{
+				Policies: map[HealthCheckName]healthCheckPhasePolicy{
+					PDBHealthCheck: {New: healthCheckPolicyOff, Upgrading: healthCheckPolicyOff},
+				},
+			}
+			Expect(cfg.GetPolicy(PDBHealthCheck, upgradev1alpha1.UpgradePhasePending)).To(Equal(healthCheckPolicyEnforce))
+		})
+	})
+})
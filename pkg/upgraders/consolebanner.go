@@ -0,0 +1,62 @@
+package upgraders
+
+import (
+	"github.com/go-logr/logr"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+)
+
+// bannerStages describes the stage of the upgrade shown in the console banner for each upgrade step
+var bannerStages = map[upgradev1alpha1.UpgradeConditionType]string{
+	upgradev1alpha1.SendStartedNotification:       "preparing the upgrade",
+	upgradev1alpha1.IsClusterUpgradable:           "preparing the upgrade",
+	upgradev1alpha1.UpgradePreHealthCheck:         "preparing the upgrade",
+	upgradev1alpha1.ExtDepAvailabilityCheck:       "preparing the upgrade",
+	upgradev1alpha1.UpgradeScaleUpExtraNodes:      "reserving compute capacity",
+	upgradev1alpha1.ControlPlaneMaintWindow:       "upgrading the control plane",
+	upgradev1alpha1.CommenceUpgrade:               "upgrading the control plane",
+	upgradev1alpha1.ControlPlaneUpgraded:          "upgrading the control plane",
+	upgradev1alpha1.RemoveControlPlaneMaintWindow: "upgrading worker nodes",
+	upgradev1alpha1.WorkersMaintWindow:            "upgrading worker nodes",
+	upgradev1alpha1.AllWorkerNodesUpgraded:        "upgrading worker nodes",
+	upgradev1alpha1.RemoveExtraScaledNodes:        "removing reserved compute capacity",
+	upgradev1alpha1.RemoveMaintWindow:             "verifying the upgrade",
+	upgradev1alpha1.PostClusterHealthCheck:        "verifying the upgrade",
+	upgradev1alpha1.PostUpgradeProcedures:         "verifying the upgrade",
+	upgradev1alpha1.SendCompletedNotification:     "verifying the upgrade",
+}
+
+// updateConsoleBanner shows the stage of the upgrade in the console banner while the cluster is
+// upgrading and removes the banner once the upgrade has completed or failed. Failures are only
+// logged as the banner must not hold the upgrade back.
+func (c *clusterUpgrader) updateConsoleBanner(phase upgradev1alpha1.UpgradePhase, logger logr.Logger) {
+	if c.consoleBanner == nil {
+		return
+	}
+
+	var err error
+	switch phase {
+	case upgradev1alpha1.UpgradePhaseUpgrading:
+		err = c.consoleBanner.Ensure(c.upgradeConfig.Spec.Desired.Version, c.currentStage(), c.workerMaintenanceEnd)
+	case upgradev1alpha1.UpgradePhaseUpgraded, upgradev1alpha1.UpgradePhaseFailed:
+		err = c.consoleBanner.Remove()
+	}
+	if err != nil {
+		logger.Error(err, "Failed to update the console banner")
+	}
+}
+
+// currentStage returns the stage of the first upgrade step that has not completed
+func (c *clusterUpgrader) currentStage() string {
+	h := c.upgradeConfig.Status.History.GetHistory(c.upgradeConfig.Spec.Desired.Version)
+	for _, step := range c.steps {
+		conditionType := upgradev1alpha1.UpgradeConditionType(step.String())
+		if h != nil && h.Conditions.IsTrueFor(conditionType) {
+			continue
+		}
+		if stage, ok := bannerStages[conditionType]; ok {
+			return stage
+		}
+	}
+	return "upgrading"
+}
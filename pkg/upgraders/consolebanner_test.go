@@ -0,0 +1,89 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	cbMocks "github.com/openshift/managed-upgrade-operator/pkg/consolebanner/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/upgradesteps"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("Console banner", func() {
+	var (
+		logger            logr.Logger
+		mockCtrl          *gomock.Controller
+		mockConsoleBanner *cbMocks.MockConsoleBanner
+		upgradeConfig     *upgradev1alpha1.UpgradeConfig
+		upgrader          *clusterUpgrader
+	)
+
+	noop := func(ctx context.Context, logger logr.Logger) (bool, error) { return true, nil }
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockConsoleBanner = cbMocks.NewMockConsoleBanner(mockCtrl)
+		logger = logf.Log.WithName("cluster upgrader test logger")
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithPhase(upgradev1alpha1.UpgradePhaseUpgrading).GetUpgradeConfig()
+		upgrader = &clusterUpgrader{
+			consoleBanner: mockConsoleBanner,
+			upgradeConfig: upgradeConfig,
+			steps: []upgradesteps.UpgradeStep{
+				upgradesteps.Action(string(upgradev1alpha1.CommenceUpgrade), noop),
+				upgradesteps.Action(string(upgradev1alpha1.ControlPlaneUpgraded), noop),
+				upgradesteps.Action(string(upgradev1alpha1.AllWorkerNodesUpgraded), noop),
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When the cluster is upgrading", func() {
+		It("shows the stage of the first step that has not completed", func() {
+			upgradeConfig.Status.History[0].Conditions = upgradev1alpha1.NewConditions(
+				upgradev1alpha1.UpgradeCondition{Type: upgradev1alpha1.CommenceUpgrade, Status: corev1.ConditionTrue},
+				upgradev1alpha1.UpgradeCondition{Type: upgradev1alpha1.ControlPlaneUpgraded, Status: corev1.ConditionTrue},
+				upgradev1alpha1.UpgradeCondition{Type: upgradev1alpha1.AllWorkerNodesUpgraded, Status: corev1.ConditionFalse},
+			)
+			Expect(upgrader.currentStage()).To(Equal("upgrading worker nodes"))
+			mockConsoleBanner.EXPECT().Ensure(upgradeConfig.Spec.Desired.Version, "upgrading worker nodes", nil)
+			upgrader.updateConsoleBanner(upgradev1alpha1.UpgradePhaseUpgrading, logger)
+		})
+
+		It("shows the expected completion of the worker maintenance", func() {
+			endTime := time.Now().Add(time.Hour)
+			upgrader.workerMaintenanceEnd = &endTime
+			mockConsoleBanner.EXPECT().Ensure(upgradeConfig.Spec.Desired.Version, "upgrading the control plane", &endTime)
+			upgrader.updateConsoleBanner(upgradev1alpha1.UpgradePhaseUpgrading, logger)
+		})
+
+		It("does not fail the upgrade when the banner can't be updated", func() {
+			mockConsoleBanner.EXPECT().Ensure(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error"))
+			upgrader.updateConsoleBanner(upgradev1alpha1.UpgradePhaseUpgrading, logger)
+		})
+	})
+
+	Context("When the upgrade has ended", func() {
+		It("removes the banner once the upgrade has completed", func() {
+			mockConsoleBanner.EXPECT().Remove()
+			upgrader.updateConsoleBanner(upgradev1alpha1.UpgradePhaseUpgraded, logger)
+		})
+
+		It("removes the banner when the upgrade has failed", func() {
+			mockConsoleBanner.EXPECT().Remove()
+			upgrader.updateConsoleBanner(upgradev1alpha1.UpgradePhaseFailed, logger)
+		})
+	})
+})
@@ -10,6 +10,7 @@ import (
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
 	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
 	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
+	"github.com/openshift/managed-upgrade-operator/pkg/pagerduty"
 )
 
 // CommenceUpgrade will update the clusterversion object to apply the desired version to trigger real OCP upgrade
@@ -57,6 +58,7 @@ func (c *clusterUpgrader) ControlPlaneUpgraded(ctx context.Context, logger logr.
 			return false, err
 		}
 		c.metrics.ResetMetricUpgradeControlPlaneTimeout(c.upgradeConfig.Name, c.upgradeConfig.Spec.Desired.Version)
+		c.resolveIncident(pagerduty.IncidentControlPlaneTimeout, "", logger)
 		clusterid := c.cvClient.GetClusterId()
 		c.metrics.UpdateMetricControlplaneUpgradeCompletedTimestamp(clusterid, c.upgradeConfig.Name, c.upgradeConfig.Spec.Desired.Version, time.Now())
 		c.metrics.UpdateMetricWorkernodeUpgradeStartedTimestamp(clusterid, c.upgradeConfig.Name, c.upgradeConfig.Spec.Desired.Version, time.Now())
@@ -78,6 +80,7 @@ func (c *clusterUpgrader) ControlPlaneUpgraded(ctx context.Context, logger logr.
 	if !upgradeStartTime.IsZero() && time.Now().After(upgradeStartTime.Add(upgradeTimeout)) {
 		logger.Info("Control plane upgrade timeout")
 		c.metrics.UpdateMetricUpgradeControlPlaneTimeout(c.upgradeConfig.Name, c.upgradeConfig.Spec.Desired.Version)
+		c.triggerIncident(pagerduty.IncidentControlPlaneTimeout, "", fmt.Sprintf("Control plane upgrade to %s has not completed within %s", c.upgradeConfig.Spec.Desired.Version, upgradeTimeout), logger)
 	}
 
 	return false, nil
@@ -22,6 +22,8 @@ import (
 	mockMachinery "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
 	mockMaintenance "github.com/openshift/managed-upgrade-operator/pkg/maintenance/mocks"
 	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/pagerduty"
+	mockPagerDuty "github.com/openshift/managed-upgrade-operator/pkg/pagerduty/mocks"
 	mockScaler "github.com/openshift/managed-upgrade-operator/pkg/scaler/mocks"
 	"github.com/openshift/managed-upgrade-operator/util/mocks"
 	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
@@ -40,6 +42,7 @@ var _ = Describe("ControlPlaneStep", func() {
 		mockCVClient             *cvMocks.MockClusterVersion
 		mockDrainStrategyBuilder *mockDrain.MockNodeDrainStrategyBuilder
 		mockEMClient             *emMocks.MockEventManager
+		mockIncidents            *mockPagerDuty.MockIncidentManager
 		// upgradeconfig to be used during tests
 		upgradeConfigName types.NamespacedName
 		upgradeConfig     *upgradev1alpha1.UpgradeConfig
@@ -64,6 +67,7 @@ var _ = Describe("ControlPlaneStep", func() {
 		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
 		mockDrainStrategyBuilder = mockDrain.NewMockNodeDrainStrategyBuilder(mockCtrl)
 		mockEMClient = emMocks.NewMockEventManager(mockCtrl)
+		mockIncidents = mockPagerDuty.NewMockIncidentManager(mockCtrl)
 		logger = logf.Log.WithName("cluster upgrader test logger")
 		config = buildTestUpgraderConfig(90, 30, 8, 120, 30)
 		upgrader = &clusterUpgrader{
@@ -71,6 +75,7 @@ var _ = Describe("ControlPlaneStep", func() {
 			metrics:              mockMetricsClient,
 			cvClient:             mockCVClient,
 			notifier:             mockEMClient,
+			incidents:            mockIncidents,
 			config:               config,
 			scaler:               mockScalerClient,
 			drainstrategyBuilder: mockDrainStrategyBuilder,
@@ -129,6 +134,7 @@ var _ = Describe("ControlPlaneStep", func() {
 					mockCVClient.EXPECT().HasUpgradeCompleted(gomock.Any(), gomock.Any()).Return(true),
 					mockEMClient.EXPECT().Notify(gomock.Any()),
 					mockMetricsClient.EXPECT().ResetMetricUpgradeControlPlaneTimeout(upgradeConfig.Name, upgradeConfig.Spec.Desired.Version),
+					mockIncidents.EXPECT().Resolve(pagerduty.IncidentControlPlaneTimeout, upgradeConfig.Spec.Desired.Version, ""),
 					mockCVClient.EXPECT().GetClusterId(),
 					mockMetricsClient.EXPECT().UpdateMetricControlplaneUpgradeCompletedTimestamp(gomock.Any(), upgradeConfig.Name, gomock.Any(), gomock.Any()),
 					mockMetricsClient.EXPECT().UpdateMetricWorkernodeUpgradeStartedTimestamp(gomock.Any(), upgradeConfig.Name, gomock.Any(), gomock.Any()),
@@ -161,6 +167,7 @@ var _ = Describe("ControlPlaneStep", func() {
 						mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
 						mockCVClient.EXPECT().HasUpgradeCompleted(gomock.Any(), gomock.Any()).Return(false),
 						mockMetricsClient.EXPECT().UpdateMetricUpgradeControlPlaneTimeout(upgradeConfig.Name, upgradeConfig.Spec.Desired.Version),
+						mockIncidents.EXPECT().Trigger(pagerduty.IncidentControlPlaneTimeout, upgradeConfig.Spec.Desired.Version, "", gomock.Any()),
 					)
 					result, err := upgrader.ControlPlaneUpgraded(context.TODO(), logger)
 					Expect(err).NotTo(HaveOccurred())
@@ -201,6 +208,7 @@ var _ = Describe("ControlPlaneStep", func() {
 					mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
 					mockCVClient.EXPECT().HasUpgradeCompleted(gomock.Any(), gomock.Any()).Return(false),
 					mockMetricsClient.EXPECT().UpdateMetricUpgradeControlPlaneTimeout(upgradeConfig.Name, upgradeConfig.Spec.Desired.Version),
+					mockIncidents.EXPECT().Trigger(pagerduty.IncidentControlPlaneTimeout, upgradeConfig.Spec.Desired.Version, "", gomock.Any()).Return(fmt.Errorf("fake error")),
 				)
 				result, err := upgrader.ControlPlaneUpgraded(context.TODO(), logger)
 				Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,184 @@
+package upgraders
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+)
+
+// platformCertificates are the secrets holding the certificates nodes and clients rely on to reach the cluster
+var platformCertificates = []types.NamespacedName{
+	{Namespace: "openshift-kube-apiserver", Name: "external-loadbalancer-serving-certkey"},
+	{Namespace: "openshift-kube-apiserver", Name: "internal-loadbalancer-serving-certkey"},
+	{Namespace: "openshift-kube-apiserver", Name: "kubelet-client"},
+	{Namespace: "openshift-ingress", Name: "router-certs-default"},
+}
+
+// Certificates function will report the kubelet CertificateSigningRequests pending for longer than the
+// configured age, and the kubelet client and platform certificates that expire within the configured threshold.
+func Certificates(metricsClient metrics.Metrics, c client.Client, cfg *upgraderConfig, ug *upgradev1alpha1.UpgradeConfig, logger logr.Logger, version string) ([]string, error) {
+	// Get current upgrade state
+	history := ug.Status.History.GetHistory(ug.Spec.Desired.Version)
+	state := string(history.Phase)
+
+	csrs := &certificatesv1.CertificateSigningRequestList{}
+	err := c.List(context.TODO(), csrs)
+	if err != nil {
+		logger.Info("Unable to fetch CertificateSigningRequest list")
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.CertificateQueryFailed, version, state)
+		return nil, err
+	}
+
+	now := time.Now()
+	var affected []string
+	var reasons []string
+	for _, csr := range csrs.Items {
+		if !isKubeletCSR(csr) || !isPendingCSR(csr) {
+			continue
+		}
+		age := now.Sub(csr.CreationTimestamp.Time)
+		if age < cfg.HealthCheck.Certificates.GetPendingCSRAge() {
+			continue
+		}
+		affected = append(affected, csr.Name)
+		reasons = append(reasons, fmt.Sprintf("CSR %s for %s pending for %dm", csr.Name, csr.Spec.Username, int(age.Minutes())))
+	}
+
+	// The kubelet client certificates are issued in the status of the approved CSRs. Only the latest
+	// certificate of each kubelet is checked, as the earlier ones have been renewed.
+	kubeletCerts := map[string]kubeletCertificate{}
+	for _, csr := range csrs.Items {
+		if csr.Spec.SignerName != certificatesv1.KubeAPIServerClientKubeletSignerName || !isApprovedCSR(csr) || len(csr.Status.Certificate) == 0 {
+			continue
+		}
+		notAfter, err := certificateExpiry(csr.Status.Certificate)
+		if err != nil {
+			affected = append(affected, csr.Name)
+			reasons = append(reasons, fmt.Sprintf("certificate of CSR %s for %s can't be parsed: %s", csr.Name, csr.Spec.Username, err))
+			continue
+		}
+		if latest, ok := kubeletCerts[csr.Spec.Username]; ok && !notAfter.After(latest.notAfter) {
+			continue
+		}
+		kubeletCerts[csr.Spec.Username] = kubeletCertificate{csr: csr.Name, notAfter: notAfter}
+	}
+	usernames := make([]string, 0, len(kubeletCerts))
+	for username := range kubeletCerts {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+	for _, username := range usernames {
+		cert := kubeletCerts[username]
+		if cert.notAfter.Sub(now) > cfg.HealthCheck.Certificates.GetExpiryThreshold() {
+			continue
+		}
+		affected = append(affected, cert.csr)
+		reasons = append(reasons, expiryReason(fmt.Sprintf("kubelet client certificate of %s", username), cert.notAfter, now))
+	}
+
+	for _, name := range platformCertificates {
+		secret := &corev1.Secret{}
+		err := c.Get(context.TODO(), name, secret)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			logger.Info(fmt.Sprintf("Unable to fetch certificate %s", name))
+			metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.CertificateQueryFailed, version, state)
+			return nil, err
+		}
+		notAfter, err := certificateExpiry(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			affected = append(affected, name.String())
+			reasons = append(reasons, fmt.Sprintf("certificate %s can't be parsed: %s", name, err))
+			continue
+		}
+		if notAfter.Sub(now) > cfg.HealthCheck.Certificates.GetExpiryThreshold() {
+			continue
+		}
+		affected = append(affected, name.String())
+		reasons = append(reasons, expiryReason(fmt.Sprintf("certificate %s", name), notAfter, now))
+	}
+
+	if len(affected) > 0 {
+		logger.Info(fmt.Sprintf("Certificates at risk: %s", strings.Join(reasons, ", ")))
+		metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.CertificateQueryFailed, version, state)
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.CertificatesAtRisk, version, state)
+		return affected, fmt.Errorf("certificates at risk: %s", strings.Join(reasons, ", "))
+	}
+	logger.Info("Prehealth check for certificates passed")
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.CertificateQueryFailed, version, state)
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.CertificatesAtRisk, version, state)
+	return nil, nil
+}
+
+// isKubeletCSR returns true if the CSR requests a kubelet client or serving certificate
+func isKubeletCSR(csr certificatesv1.CertificateSigningRequest) bool {
+	return csr.Spec.SignerName == certificatesv1.KubeAPIServerClientKubeletSignerName || csr.Spec.SignerName == certificatesv1.KubeletServingSignerName
+}
+
+// kubeletCertificate is the latest client certificate issued to a kubelet and the CSR it was issued in
+type kubeletCertificate struct {
+	csr      string
+	notAfter time.Time
+}
+
+// expiryReason describes a certificate that expires within the threshold
+func expiryReason(certificate string, notAfter time.Time, now time.Time) string {
+	if notAfter.Before(now) {
+		return fmt.Sprintf("%s expired on %s", certificate, notAfter.UTC().Format(time.RFC3339))
+	}
+	return fmt.Sprintf("%s expires on %s", certificate, notAfter.UTC().Format(time.RFC3339))
+}
+
+// isApprovedCSR returns true if the CSR has been approved and has neither been denied nor failed
+func isApprovedCSR(csr certificatesv1.CertificateSigningRequest) bool {
+	approved := false
+	for _, condition := range csr.Status.Conditions {
+		switch condition.Type {
+		case certificatesv1.CertificateApproved:
+			approved = true
+		case certificatesv1.CertificateDenied, certificatesv1.CertificateFailed:
+			return false
+		}
+	}
+	return approved
+}
+
+// isPendingCSR returns true if the CSR has been neither approved, denied nor failed
+func isPendingCSR(csr certificatesv1.CertificateSigningRequest) bool {
+	for _, condition := range csr.Status.Conditions {
+		switch condition.Type {
+		case certificatesv1.CertificateApproved, certificatesv1.CertificateDenied, certificatesv1.CertificateFailed:
+			return false
+		}
+	}
+	return true
+}
+
+// certificateExpiry returns the expiry of the first certificate in the PEM encoded data
+func certificateExpiry(data []byte) (time.Time, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return time.Time{}, fmt.Errorf("no PEM encoded certificate found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cert.NotAfter, nil
+}
@@ -0,0 +1,201 @@
+package upgraders
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	gomock "go.uber.org/mock/gomock"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("HealthCheck Certificates", func() {
+	var (
+		logger            logr.Logger
+		mockCtrl          *gomock.Controller
+		mockKubeClient    *mocks.MockClient
+		mockMetricsClient *mockMetrics.MockMetrics
+
+		// upgradeconfig to be used during tests
+		upgradeConfigName types.NamespacedName
+		upgradeConfig     *upgradev1alpha1.UpgradeConfig
+
+		config  *upgraderConfig
+		version string
+		csrs    *certificatesv1.CertificateSigningRequestList
+		valid   *corev1.Secret
+	)
+
+	certificatePEM := func(notAfter time.Time) []byte {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "test"},
+			NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+			NotAfter:     notAfter,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		Expect(err).NotTo(HaveOccurred())
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}
+
+	certificateSecret := func(notAfter time.Time) *corev1.Secret {
+		return &corev1.Secret{
+			Data: map[string][]byte{corev1.TLSCertKey: certificatePEM(notAfter)},
+		}
+	}
+
+	newCSR := func(name string, signerName string, age time.Duration, conditions ...certificatesv1.RequestConditionType) certificatesv1.CertificateSigningRequest {
+		csr := certificatesv1.CertificateSigningRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(time.Now().Add(-age))},
+			Spec:       certificatesv1.CertificateSigningRequestSpec{SignerName: signerName, Username: "system:node:worker-a"},
+		}
+		for _, c := range conditions {
+			csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{Type: c, Status: corev1.ConditionTrue})
+		}
+		return csr
+	}
+
+	BeforeEach(func() {
+		upgradeConfigName = types.NamespacedName{
+			Name:      "test-upgradeconfig",
+			Namespace: "test-namespace",
+		}
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseNew).GetUpgradeConfig()
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+		logger = logf.Log.WithName("cluster upgrader test logger")
+		config = buildTestUpgraderConfig(90, 30, 8, 120, 30)
+		version = "mockVersion"
+
+		csrs = &certificatesv1.CertificateSigningRequestList{
+			Items: []certificatesv1.CertificateSigningRequest{
+				newCSR("csr-approved", certificatesv1.KubeAPIServerClientKubeletSignerName, 2*time.Hour, certificatesv1.CertificateApproved),
+				newCSR("csr-recent", certificatesv1.KubeletServingSignerName, 5*time.Minute),
+				newCSR("csr-other-signer", "example.com/signer", 2*time.Hour),
+			},
+		}
+		valid = certificateSecret(time.Now().Add(90 * 24 * time.Hour))
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When no CSR is stuck and no certificate is close to expiry", func() {
+		It("Prehealth check will pass", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *csrs),
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, *valid).Times(len(platformCertificates)),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.CertificateQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.CertificatesAtRisk, version, gomock.Any()),
+			)
+			affected, err := Certificates(mockMetricsClient, mockKubeClient, config, upgradeConfig, logger, version)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(affected).To(BeNil())
+		})
+	})
+
+	Context("When a kubelet CSR is stuck and a certificate is close to expiry", func() {
+		It("Prehealth check will fail and report them", func() {
+			csrs.Items = append(csrs.Items, newCSR("csr-stuck", certificatesv1.KubeAPIServerClientKubeletSignerName, 45*time.Minute))
+			notAfter := time.Now().Add(72 * time.Hour).Truncate(time.Second)
+			expiring := certificateSecret(notAfter)
+			notFound := kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "router-certs-default")
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *csrs),
+				mockKubeClient.EXPECT().Get(gomock.Any(), platformCertificates[0], gomock.Any()).Return(nil).SetArg(2, *expiring),
+				mockKubeClient.EXPECT().Get(gomock.Any(), platformCertificates[1], gomock.Any()).Return(nil).SetArg(2, *valid),
+				mockKubeClient.EXPECT().Get(gomock.Any(), platformCertificates[2], gomock.Any()).Return(nil).SetArg(2, *valid),
+				mockKubeClient.EXPECT().Get(gomock.Any(), platformCertificates[3], gomock.Any()).Return(notFound),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.CertificateQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.CertificatesAtRisk, version, gomock.Any()),
+			)
+			affected, err := Certificates(mockMetricsClient, mockKubeClient, config, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(Equal("certificates at risk: CSR csr-stuck for system:node:worker-a pending for 45m, " +
+				"certificate openshift-kube-apiserver/external-loadbalancer-serving-certkey expires on " + notAfter.UTC().Format(time.RFC3339)))
+			Expect(affected).To(Equal([]string{"csr-stuck", "openshift-kube-apiserver/external-loadbalancer-serving-certkey"}))
+		})
+	})
+
+	Context("When a kubelet client certificate is close to expiry", func() {
+		It("Prehealth check will fail and report the latest certificate of the kubelet only", func() {
+			notAfter := time.Now().Add(72 * time.Hour).Truncate(time.Second)
+			renewed := newCSR("csr-renewed", certificatesv1.KubeAPIServerClientKubeletSignerName, 30*24*time.Hour, certificatesv1.CertificateApproved)
+			renewed.Status.Certificate = certificatePEM(time.Now().Add(24 * time.Hour))
+			renewal := newCSR("csr-renewal", certificatesv1.KubeAPIServerClientKubeletSignerName, time.Hour, certificatesv1.CertificateApproved)
+			renewal.Status.Certificate = certificatePEM(time.Now().Add(30 * 24 * time.Hour))
+			expiring := newCSR("csr-expiring", certificatesv1.KubeAPIServerClientKubeletSignerName, 30*24*time.Hour, certificatesv1.CertificateApproved)
+			expiring.Spec.Username = "system:node:worker-b"
+			expiring.Status.Certificate = certificatePEM(notAfter)
+			csrs.Items = append(csrs.Items, renewed, renewal, expiring)
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *csrs),
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, *valid).Times(len(platformCertificates)),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.CertificateQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.CertificatesAtRisk, version, gomock.Any()),
+			)
+			affected, err := Certificates(mockMetricsClient, mockKubeClient, config, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(Equal("certificates at risk: kubelet client certificate of system:node:worker-b expires on " + notAfter.UTC().Format(time.RFC3339)))
+			Expect(affected).To(Equal([]string{"csr-expiring"}))
+		})
+	})
+
+	Context("When a certificate can't be parsed", func() {
+		It("Prehealth check will fail and report it", func() {
+			corrupt := &corev1.Secret{Data: map[string][]byte{}}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *csrs),
+				mockKubeClient.EXPECT().Get(gomock.Any(), platformCertificates[0], gomock.Any()).Return(nil).SetArg(2, *valid),
+				mockKubeClient.EXPECT().Get(gomock.Any(), platformCertificates[1], gomock.Any()).Return(nil).SetArg(2, *corrupt),
+				mockKubeClient.EXPECT().Get(gomock.Any(), platformCertificates[2], gomock.Any()).Return(nil).SetArg(2, *valid),
+				mockKubeClient.EXPECT().Get(gomock.Any(), platformCertificates[3], gomock.Any()).Return(nil).SetArg(2, *valid),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.CertificateQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.CertificatesAtRisk, version, gomock.Any()),
+			)
+			affected, err := Certificates(mockMetricsClient, mockKubeClient, config, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(Equal("certificates at risk: certificate openshift-kube-apiserver/internal-loadbalancer-serving-certkey can't be parsed: no PEM encoded certificate found"))
+			Expect(affected).To(Equal([]string{"openshift-kube-apiserver/internal-loadbalancer-serving-certkey"}))
+		})
+	})
+
+	Context("When unable to fetch the CSRs", func() {
+		It("Prehealth check will fail", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake cannot fetch csrs")),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.CertificateQueryFailed, version, gomock.Any()),
+			)
+			affected, err := Certificates(mockMetricsClient, mockKubeClient, config, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(affected).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,196 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	apiserverv1 "github.com/openshift/api/apiserver/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+)
+
+const (
+	// adminGatesNamespace and adminGatesConfigMap hold the admin acknowledgements OpenShift requires before a y-stream upgrade
+	adminGatesNamespace = "openshift-config-managed"
+	adminGatesConfigMap = "admin-gates"
+	// adminAcksNamespace and adminAcksConfigMap hold the acknowledgements given by the cluster administrator
+	adminAcksNamespace = "openshift-config"
+	adminAcksConfigMap = "admin-acks"
+	// apiRemovalsGateInfix precedes the OpenShift version in which the APIs of an admin gate are removed
+	apiRemovalsGateInfix = "-api-removals-in-"
+	// kubernetesMinorOffset is the difference between an OpenShift 4 minor version and the Kubernetes 1 minor version it ships
+	kubernetesMinorOffset = 13
+	// removedAPITopUsers is the number of users reported for each removed API still in use
+	removedAPITopUsers = 3
+)
+
+// DeprecatedAPIs function will, for y-stream upgrades, report the APIs removed in the Kubernetes
+// release of the desired version that have been requested in the last 24 hours, along with their top users.
+func DeprecatedAPIs(metricsClient metrics.Metrics, c client.Client, ug *upgradev1alpha1.UpgradeConfig, logger logr.Logger, version string) ([]string, error) {
+	// Get current upgrade state
+	history := ug.Status.History.GetHistory(ug.Spec.Desired.Version)
+	state := string(history.Phase)
+
+	currentKube, targetKube, ok := kubernetesMinorRange(version, ug.Spec.Desired.Version)
+	if !ok {
+		logger.Info("Skipping deprecated API check as the upgrade is not a y-stream upgrade")
+		return nil, nil
+	}
+
+	requestCounts := &apiserverv1.APIRequestCountList{}
+	err := c.List(context.TODO(), requestCounts)
+	if err != nil {
+		logger.Info("Unable to fetch APIRequestCount list")
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.DeprecatedAPIQueryFailed, version, state)
+		return nil, err
+	}
+
+	var apis []string
+	var reasons []string
+	for _, rc := range requestCounts.Items {
+		if rc.Status.RemovedInRelease == "" || rc.Status.RequestCount == 0 {
+			continue
+		}
+		removed, err := semver.ParseTolerant(rc.Status.RemovedInRelease)
+		if err != nil || removed.Minor <= currentKube || removed.Minor > targetKube {
+			continue
+		}
+		apis = append(apis, rc.Name)
+		reason := fmt.Sprintf("%s removed in %s (%d requests", rc.Name, rc.Status.RemovedInRelease, rc.Status.RequestCount)
+		if users := topAPIUsers(rc, removedAPITopUsers); len(users) > 0 {
+			reason += " by " + strings.Join(users, ", ")
+		}
+		reasons = append(reasons, reason+")")
+	}
+
+	if len(apis) > 0 {
+		logger.Info(fmt.Sprintf("APIs removed in the desired version are still in use: %s", strings.Join(reasons, "; ")))
+		metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.DeprecatedAPIQueryFailed, version, state)
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.DeprecatedAPIsInUse, version, state)
+		return apis, fmt.Errorf("APIs removed in %s are still in use: %s", ug.Spec.Desired.Version, strings.Join(reasons, "; "))
+	}
+	logger.Info("Prehealth check for deprecated APIs passed")
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.DeprecatedAPIQueryFailed, version, state)
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.DeprecatedAPIsInUse, version, state)
+	return nil, nil
+}
+
+// AcknowledgeAPIRemovals sets the admin acknowledgements OpenShift requires for the API removals
+// between the current and the desired version of a y-stream upgrade.
+func AcknowledgeAPIRemovals(c client.Client, ug *upgradev1alpha1.UpgradeConfig, logger logr.Logger, version string) error {
+	current, desired, ok := ocpMinorRange(version, ug.Spec.Desired.Version)
+	if !ok {
+		return nil
+	}
+
+	gates := &corev1.ConfigMap{}
+	err := c.Get(context.TODO(), types.NamespacedName{Namespace: adminGatesNamespace, Name: adminGatesConfigMap}, gates)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	var required []string
+	for gate := range gates.Data {
+		i := strings.Index(gate, apiRemovalsGateInfix)
+		if i < 0 {
+			continue
+		}
+		removedIn, err := semver.ParseTolerant(gate[i+len(apiRemovalsGateInfix):])
+		if err != nil || removedIn.Minor <= current || removedIn.Minor > desired {
+			continue
+		}
+		required = append(required, gate)
+	}
+	if len(required) == 0 {
+		return nil
+	}
+
+	acks := &corev1.ConfigMap{}
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: adminAcksNamespace, Name: adminAcksConfigMap}, acks)
+	if err != nil {
+		return err
+	}
+	if acks.Data == nil {
+		acks.Data = map[string]string{}
+	}
+	sort.Strings(required)
+	var acknowledged []string
+	for _, gate := range required {
+		if acks.Data[gate] != "true" {
+			acks.Data[gate] = "true"
+			acknowledged = append(acknowledged, gate)
+		}
+	}
+	if len(acknowledged) == 0 {
+		return nil
+	}
+	logger.Info(fmt.Sprintf("Acknowledging API removals: %s", strings.Join(acknowledged, ", ")))
+	return c.Update(context.TODO(), acks)
+}
+
+// ocpMinorRange returns the minor versions of the current and desired versions if the upgrade is a y-stream upgrade
+func ocpMinorRange(version string, desiredVersion string) (uint64, uint64, bool) {
+	minorUpgrade, err := cv.GetMinorUpgrade(version, desiredVersion)
+	if err != nil || minorUpgrade != "y" {
+		return 0, 0, false
+	}
+	current, err := semver.ParseTolerant(version)
+	if err != nil {
+		return 0, 0, false
+	}
+	desired, err := semver.ParseTolerant(desiredVersion)
+	if err != nil || desired.Minor <= current.Minor {
+		return 0, 0, false
+	}
+	return current.Minor, desired.Minor, true
+}
+
+// kubernetesMinorRange returns the Kubernetes minor versions shipped by the current and desired
+// versions if the upgrade is a y-stream upgrade
+func kubernetesMinorRange(version string, desiredVersion string) (uint64, uint64, bool) {
+	current, desired, ok := ocpMinorRange(version, desiredVersion)
+	if !ok {
+		return 0, 0, false
+	}
+	return current + kubernetesMinorOffset, desired + kubernetesMinorOffset, true
+}
+
+// topAPIUsers returns the users with the most requests to the API over the last 24 hours
+func topAPIUsers(rc apiserverv1.APIRequestCount, n int) []string {
+	counts := map[string]int64{}
+	for _, hour := range rc.Status.Last24h {
+		for _, node := range hour.ByNode {
+			for _, user := range node.ByUser {
+				counts[user.UserName] += user.RequestCount
+			}
+		}
+	}
+
+	users := make([]string, 0, len(counts))
+	for user := range counts {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if counts[users[i]] != counts[users[j]] {
+			return counts[users[i]] > counts[users[j]]
+		}
+		return users[i] < users[j]
+	})
+	if len(users) > n {
+		users = users[:n]
+	}
+	return users
+}
@@ -0,0 +1,184 @@
+package upgraders
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	apiserverv1 "github.com/openshift/api/apiserver/v1"
+	gomock "go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("HealthCheck Deprecated APIs", func() {
+	var (
+		logger            logr.Logger
+		mockCtrl          *gomock.Controller
+		mockKubeClient    *mocks.MockClient
+		mockMetricsClient *mockMetrics.MockMetrics
+
+		// upgradeconfig to be used during tests
+		upgradeConfigName types.NamespacedName
+		upgradeConfig     *upgradev1alpha1.UpgradeConfig
+
+		version       string
+		requestCounts *apiserverv1.APIRequestCountList
+	)
+
+	requestCount := func(name string, removedInRelease string, count int64, users map[string]int64) apiserverv1.APIRequestCount {
+		node := apiserverv1.PerNodeAPIRequestLog{NodeName: "master-0", RequestCount: count}
+		for user, c := range users {
+			node.ByUser = append(node.ByUser, apiserverv1.PerUserAPIRequestCount{UserName: user, RequestCount: c})
+		}
+		return apiserverv1.APIRequestCount{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: apiserverv1.APIRequestCountStatus{
+				RemovedInRelease: removedInRelease,
+				RequestCount:     count,
+				Last24h:          []apiserverv1.PerResourceAPIRequestLog{{ByNode: []apiserverv1.PerNodeAPIRequestLog{node}, RequestCount: count}},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		upgradeConfigName = types.NamespacedName{
+			Name:      "test-upgradeconfig",
+			Namespace: "test-namespace",
+		}
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseNew).GetUpgradeConfig()
+		upgradeConfig.Spec.Desired.Version = "4.13.1"
+		upgradeConfig.Status.History[0].Version = "4.13.1"
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+		logger = logf.Log.WithName("cluster upgrader test logger")
+		version = "4.12.20"
+
+		requestCounts = &apiserverv1.APIRequestCountList{
+			Items: []apiserverv1.APIRequestCount{
+				requestCount("pods.v1", "", 5000, nil),
+				requestCount("flowschemas.v1beta1.flowcontrol.apiserver.k8s.io", "1.26", 0, nil),
+				requestCount("podsecuritypolicies.v1beta1.policy", "1.25", 40, nil),
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When the upgrade is not a y-stream upgrade", func() {
+		It("Prehealth check will be skipped", func() {
+			upgradeConfig.Spec.Desired.Version = "4.12.21"
+			upgradeConfig.Status.History[0].Version = "4.12.21"
+			affected, err := DeprecatedAPIs(mockMetricsClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(affected).To(BeNil())
+		})
+	})
+
+	Context("When no API removed in the desired version is in use", func() {
+		It("Prehealth check will pass", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *requestCounts),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DeprecatedAPIQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DeprecatedAPIsInUse, version, gomock.Any()),
+			)
+			affected, err := DeprecatedAPIs(mockMetricsClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(affected).To(BeNil())
+		})
+	})
+
+	Context("When an API removed in the desired version is in use", func() {
+		It("Prehealth check will fail and report the API with its top users", func() {
+			requestCounts.Items = append(requestCounts.Items, requestCount("horizontalpodautoscalers.v2beta2.autoscaling", "1.26", 120, map[string]int64{
+				"system:serviceaccount:app:autoscaler": 90,
+				"admin":                                20,
+				"system:serviceaccount:app:metrics":    6,
+				"developer":                            4,
+			}))
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *requestCounts),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DeprecatedAPIQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.DeprecatedAPIsInUse, version, gomock.Any()),
+			)
+			affected, err := DeprecatedAPIs(mockMetricsClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(Equal("APIs removed in 4.13.1 are still in use: horizontalpodautoscalers.v2beta2.autoscaling removed in 1.26 " +
+				"(120 requests by system:serviceaccount:app:autoscaler, admin, system:serviceaccount:app:metrics)"))
+			Expect(affected).To(Equal([]string{"horizontalpodautoscalers.v2beta2.autoscaling"}))
+		})
+	})
+
+	Context("When unable to fetch the APIRequestCounts", func() {
+		It("Prehealth check will fail", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake cannot fetch apirequestcounts")),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.DeprecatedAPIQueryFailed, version, gomock.Any()),
+			)
+			affected, err := DeprecatedAPIs(mockMetricsClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(affected).To(BeNil())
+		})
+	})
+
+	Context("When acknowledging API removals", func() {
+		var gates *corev1.ConfigMap
+
+		BeforeEach(func() {
+			gates = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: adminGatesNamespace, Name: adminGatesConfigMap},
+				Data: map[string]string{
+					"ack-4.11-kube-1.25-api-removals-in-4.12": "PodSecurityPolicy is removed in 4.12",
+					"ack-4.12-kube-1.26-api-removals-in-4.13": "Several beta APIs are removed in 4.13",
+				},
+			}
+		})
+
+		It("acknowledges the gates of the desired version", func() {
+			acks := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: adminAcksNamespace, Name: adminAcksConfigMap}}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Namespace: adminGatesNamespace, Name: adminGatesConfigMap}, gomock.Any()).SetArg(2, *gates).Return(nil),
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Namespace: adminAcksNamespace, Name: adminAcksConfigMap}, gomock.Any()).SetArg(2, *acks).Return(nil),
+				mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, cm *corev1.ConfigMap, _ ...interface{}) error {
+						Expect(cm.Data).To(Equal(map[string]string{"ack-4.12-kube-1.26-api-removals-in-4.13": "true"}))
+						return nil
+					}),
+			)
+			Expect(AcknowledgeAPIRemovals(mockKubeClient, upgradeConfig, logger, version)).To(Succeed())
+		})
+
+		It("does not update gates that are already acknowledged", func() {
+			acks := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: adminAcksNamespace, Name: adminAcksConfigMap},
+				Data:       map[string]string{"ack-4.12-kube-1.26-api-removals-in-4.13": "true"},
+			}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *gates).Return(nil),
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *acks).Return(nil),
+			)
+			Expect(AcknowledgeAPIRemovals(mockKubeClient, upgradeConfig, logger, version)).To(Succeed())
+		})
+
+		It("does nothing when there are no admin gates", func() {
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, adminGatesConfigMap))
+			Expect(AcknowledgeAPIRemovals(mockKubeClient, upgradeConfig, logger, version)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,95 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/drain"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+)
+
+const workerLabel = "node-role.kubernetes.io/worker"
+
+// DrainFeasibility function will simulate draining each worker node in turn and report the workloads
+// whose pods could not be rescheduled on the remaining schedulable nodes.
+func DrainFeasibility(metricsClient metrics.Metrics, c client.Client, cfg *upgraderConfig, ug *upgradev1alpha1.UpgradeConfig, logger logr.Logger, version string) ([]string, error) {
+	// Get current upgrade state
+	history := ug.Status.History.GetHistory(ug.Spec.Desired.Version)
+	state := string(history.Phase)
+
+	nodes := &corev1.NodeList{}
+	err := c.List(context.TODO(), nodes)
+	if err != nil {
+		logger.Info("Unable to fetch node list")
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.ClusterDrainSimulationFailed, version, state)
+		return nil, err
+	}
+
+	pods := &corev1.PodList{}
+	err = c.List(context.TODO(), pods)
+	if err != nil {
+		logger.Info("Unable to fetch pod list")
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.ClusterDrainSimulationFailed, version, state)
+		return nil, err
+	}
+
+	var workloads []string
+	var reasons []string
+	for _, node := range nodes.Items {
+		node := node
+		_, isWorker := node.Labels[workerLabel]
+		_, isMaster := node.Labels[machinery.MasterLabel]
+		if !isWorker || isMaster {
+			continue
+		}
+
+		var nodeWorkloads []string
+		for _, p := range drain.SimulateNodeDrain(&node, nodes.Items, pods.Items, cfg.NodeDrain.IgnoredNamespacePatterns) {
+			w := workloadName(p)
+			if !slices.Contains(nodeWorkloads, w) {
+				nodeWorkloads = append(nodeWorkloads, w)
+			}
+			if !slices.Contains(workloads, w) {
+				workloads = append(workloads, w)
+			}
+		}
+		if len(nodeWorkloads) > 0 {
+			reasons = append(reasons, fmt.Sprintf("%s (%s)", node.Name, strings.Join(nodeWorkloads, ", ")))
+		}
+	}
+
+	if len(workloads) > 0 {
+		logger.Info(fmt.Sprintf("Workloads cannot be rescheduled when draining: %s", strings.Join(reasons, ", ")))
+		metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.ClusterDrainSimulationFailed, version, state)
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.ClusterDrainInfeasible, version, state)
+		return workloads, fmt.Errorf("workloads cannot be rescheduled when draining: %s", strings.Join(reasons, ", "))
+	}
+	logger.Info("Prehealth check for drain feasibility passed")
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.ClusterDrainSimulationFailed, version, state)
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.ClusterDrainInfeasible, version, state)
+	return nil, nil
+}
+
+// workloadName returns the namespace, kind and name of the workload that owns the pod.
+// Pods owned by a ReplicaSet are attributed to its Deployment.
+func workloadName(p corev1.Pod) string {
+	owner := metav1.GetControllerOf(&p)
+	if owner == nil {
+		return fmt.Sprintf("%s/pod/%s", p.Namespace, p.Name)
+	}
+	kind, name := owner.Kind, owner.Name
+	if hash, ok := p.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; ok && kind == "ReplicaSet" && strings.HasSuffix(name, "-"+hash) {
+		kind, name = "Deployment", strings.TrimSuffix(name, "-"+hash)
+	}
+	return fmt.Sprintf("%s/%s/%s", p.Namespace, strings.ToLower(kind), name)
+}
@@ -0,0 +1,157 @@
+package upgraders
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	gomock "go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("HealthCheck Drain Feasibility", func() {
+	var (
+		logger            logr.Logger
+		mockCtrl          *gomock.Controller
+		mockKubeClient    *mocks.MockClient
+		mockMetricsClient *mockMetrics.MockMetrics
+
+		// upgradeconfig to be used during tests
+		upgradeConfigName types.NamespacedName
+		upgradeConfig     *upgradev1alpha1.UpgradeConfig
+
+		config  *upgraderConfig
+		version string
+
+		nodes *corev1.NodeList
+		pods  *corev1.PodList
+	)
+
+	newNode := func(name string, role string) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{role: ""}},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+
+	newPod := func(name string, nodeName string, cpu string) corev1.Pod {
+		isController := true
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "test-namespace",
+				Labels:          map[string]string{"pod-template-hash": "5d8f7c"},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-5d8f7c", Controller: &isController}},
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{
+					{Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}}},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		upgradeConfigName = types.NamespacedName{
+			Name:      "test-upgradeconfig",
+			Namespace: "test-namespace",
+		}
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseNew).GetUpgradeConfig()
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+		logger = logf.Log.WithName("cluster upgrader test logger")
+		config = buildTestUpgraderConfig(90, 30, 8, 120, 30)
+		version = "mockVersion"
+
+		nodes = &corev1.NodeList{
+			Items: []corev1.Node{
+				newNode("master-0", "node-role.kubernetes.io/master"),
+				newNode("worker-a", workerLabel),
+				newNode("worker-b", workerLabel),
+			},
+		}
+		// Masters are not schedulable for workloads
+		nodes.Items[0].Spec.Taints = []corev1.Taint{{Key: "node-role.kubernetes.io/master", Effect: corev1.TaintEffectNoSchedule}}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When every worker can be drained", func() {
+		It("Prehealth check will pass", func() {
+			pods = &corev1.PodList{Items: []corev1.Pod{newPod("web-1", "worker-a", "1"), newPod("web-2", "worker-b", "1")}}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *pods),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterDrainSimulationFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterDrainInfeasible, version, gomock.Any()),
+			)
+			result, err := DrainFeasibility(mockMetricsClient, mockKubeClient, config, upgradeConfig, logger, version)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result).Should(BeNil())
+		})
+	})
+
+	Context("When the pods of a worker do not fit on the remaining nodes", func() {
+		It("Prehealth check will fail and report the workload", func() {
+			pods = &corev1.PodList{Items: []corev1.Pod{newPod("web-1", "worker-a", "1500m"), newPod("web-2", "worker-b", "1500m")}}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *pods),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterDrainSimulationFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.ClusterDrainInfeasible, version, gomock.Any()),
+			)
+			result, err := DrainFeasibility(mockMetricsClient, mockKubeClient, config, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(Equal("workloads cannot be rescheduled when draining: worker-a (test-namespace/deployment/web), worker-b (test-namespace/deployment/web)"))
+			Expect(result).To(Equal([]string{"test-namespace/deployment/web"}))
+		})
+	})
+
+	Context("When unable to fetch pods", func() {
+		It("Prehealth check will fail", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake cannot fetch pods")),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.ClusterDrainSimulationFailed, version, gomock.Any()),
+			)
+			result, err := DrainFeasibility(mockMetricsClient, mockKubeClient, config, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(result).Should(BeNil())
+		})
+	})
+
+	Context("When naming the workload of a pod", func() {
+		It("uses the pod name for pods without a controller", func() {
+			Expect(workloadName(corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "standalone", Namespace: "ns"}})).To(Equal("ns/pod/standalone"))
+		})
+		It("uses the controller of the pod", func() {
+			isController := true
+			p := corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name:            "db-0",
+				Namespace:       "ns",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "StatefulSet", Name: "db", Controller: &isController}},
+			}}
+			Expect(workloadName(p)).To(Equal("ns/statefulset/db"))
+		})
+	})
+})
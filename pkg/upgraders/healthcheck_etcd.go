@@ -0,0 +1,205 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+)
+
+const (
+	etcdNamespace    = "openshift-etcd"
+	etcdOperatorName = "etcd"
+	etcdPodLabel     = "app"
+	etcdPodLabelApp  = "etcd"
+)
+
+// etcdAlerts are the etcd alerts that indicate the etcd cluster is unable to tolerate
+// the loss of a member while the control plane is upgraded
+var etcdAlerts = []string{
+	"etcdMembersDown",
+	"etcdNoLeader",
+	"etcdInsufficientMembers",
+	"etcdHighNumberOfLeaderChanges",
+	"etcdHighFsyncDurations",
+	"etcdDatabaseQuotaLowSpace",
+}
+
+// etcdSignal is a query against the etcd metrics which returns a series for each
+// etcd member that is outside of its healthy range
+type etcdSignal struct {
+	description string
+	query       string
+}
+
+var etcdSignals = []etcdSignal{
+	{
+		description: "frequent leader changes",
+		query:       `increase(etcd_server_leader_changes_seen_total{namespace="openshift-etcd"}[15m]) > 3`,
+	},
+	{
+		description: "high WAL fsync latency",
+		query:       `histogram_quantile(0.99, rate(etcd_disk_wal_fsync_duration_seconds_bucket{namespace="openshift-etcd"}[5m])) > 0.5`,
+	},
+	{
+		description: "database size close to quota",
+		query:       `(etcd_mvcc_db_total_size_in_bytes{namespace="openshift-etcd"} / etcd_server_quota_backend_bytes{namespace="openshift-etcd"}) > 0.8`,
+	},
+}
+
+// EtcdHealth function will check the etcd ClusterOperator, the readiness of the etcd member pods,
+// and the etcd alerts and metrics. If etcd is unable to keep quorum during a control plane upgrade
+// the affected objects are returned along with an error describing the problems.
+func EtcdHealth(metricsClient metrics.Metrics, c client.Client, ug *upgradev1alpha1.UpgradeConfig, logger logr.Logger, version string) ([]string, error) {
+	return etcdHealth(metricsClient, c, ug, logger, version, true)
+}
+
+// EtcdMemberHealth function will check the etcd ClusterOperator and the readiness of the etcd member pods
+// only. It is used in place of EtcdHealth when the monitoring stack is unavailable.
+func EtcdMemberHealth(metricsClient metrics.Metrics, c client.Client, ug *upgradev1alpha1.UpgradeConfig, logger logr.Logger, version string) ([]string, error) {
+	return etcdHealth(metricsClient, c, ug, logger, version, false)
+}
+
+func etcdHealth(metricsClient metrics.Metrics, c client.Client, ug *upgradev1alpha1.UpgradeConfig, logger logr.Logger, version string, queryMetrics bool) ([]string, error) {
+	// Get current upgrade state
+	history := ug.Status.History.GetHistory(ug.Spec.Desired.Version)
+	state := string(history.Phase)
+
+	affected, problems, err := etcdMembers(c)
+	if err != nil {
+		logger.Info(fmt.Sprintf("Unable to check etcd members: %s", err))
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.EtcdQueryFailed, version, state)
+		return nil, err
+	}
+
+	if queryMetrics {
+		alertQuery := `ALERTS{alertstate="firing",namespace="` + etcdNamespace + `",alertname=~"` + strings.Join(etcdAlerts, "|") + `"}`
+		alerts, err := metricsClient.Query(alertQuery)
+		if err != nil {
+			logger.Info("Unable to query etcd alerts")
+			metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.EtcdQueryFailed, version, state)
+			return nil, fmt.Errorf("unable to query etcd alerts: %v", err)
+		}
+		firing := map[string]bool{}
+		for _, r := range alerts.Data.Result {
+			a := r.Metric["alertname"]
+			if firing[a] {
+				continue
+			}
+			firing[a] = true
+			affected = append(affected, a)
+			problems = append(problems, fmt.Sprintf("alert %s firing", a))
+		}
+
+		for _, signal := range etcdSignals {
+			result, err := metricsClient.Query(signal.query)
+			if err != nil {
+				logger.Info(fmt.Sprintf("Unable to query etcd metrics for %s", signal.description))
+				metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.EtcdQueryFailed, version, state)
+				return nil, fmt.Errorf("unable to query etcd metrics: %v", err)
+			}
+			for _, r := range result.Data.Result {
+				member := r.Metric["pod"]
+				object := etcdNamespace + "/" + member
+				if member == "" {
+					member = r.Metric["instance"]
+					object = member
+				}
+				if !slices.Contains(affected, object) {
+					affected = append(affected, object)
+				}
+				problems = append(problems, fmt.Sprintf("%s on %s", signal.description, member))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		logger.Info(fmt.Sprintf("etcd quorum at risk: %s", strings.Join(problems, ", ")))
+		metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.EtcdQueryFailed, version, state)
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.EtcdUnhealthy, version, state)
+		return affected, fmt.Errorf("etcd quorum at risk: %s", strings.Join(problems, ", "))
+	}
+
+	logger.Info("Prehealth check for etcd passed")
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.EtcdQueryFailed, version, state)
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.EtcdUnhealthy, version, state)
+	return nil, nil
+}
+
+// etcdMembers checks the etcd ClusterOperator and the readiness of the etcd member pod of each master
+// through the Kubernetes API, and returns the affected objects and the problems found
+func etcdMembers(c client.Client) ([]string, []string, error) {
+	var affected []string
+	var problems []string
+
+	co := &configv1.ClusterOperator{}
+	err := c.Get(context.TODO(), types.NamespacedName{Name: etcdOperatorName}, co)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to fetch etcd clusteroperator: %v", err)
+	}
+	for _, condition := range co.Status.Conditions {
+		if (condition.Type == configv1.OperatorDegraded && condition.Status == configv1.ConditionTrue) || (condition.Type == configv1.OperatorAvailable && condition.Status == configv1.ConditionFalse) {
+			affected = append(affected, etcdOperatorName)
+			problems = append(problems, fmt.Sprintf("etcd operator is %s: %s", condition.Type, condition.Message))
+			break
+		}
+	}
+
+	pods := &corev1.PodList{}
+	err = c.List(context.TODO(), pods, client.InNamespace(etcdNamespace), client.MatchingLabels{etcdPodLabel: etcdPodLabelApp})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to fetch etcd pods: %v", err)
+	}
+	masters := &corev1.NodeList{}
+	err = c.List(context.TODO(), masters, client.HasLabels{machinery.MasterLabel})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to fetch master nodes: %v", err)
+	}
+
+	// Each master runs an etcd member, so that a master without a ready member counts against quorum
+	members := len(masters.Items)
+	ready := 0
+	for _, master := range masters.Items {
+		var member *corev1.Pod
+		for i := range pods.Items {
+			if pods.Items[i].Spec.NodeName == master.Name {
+				member = &pods.Items[i]
+				break
+			}
+		}
+		if member == nil {
+			affected = append(affected, master.Name)
+			problems = append(problems, fmt.Sprintf("master %s runs no etcd member", master.Name))
+			continue
+		}
+		if isPodReady(member) {
+			ready++
+			continue
+		}
+		affected = append(affected, member.Namespace+"/"+member.Name)
+	}
+	// Every member must be ready so that quorum survives while each master is rebooted in turn
+	if members == 0 || ready < members {
+		problems = append(problems, fmt.Sprintf("%d of %d etcd members ready, quorum requires %d", ready, members, members/2+1))
+	}
+	return affected, problems, nil
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,240 @@
+package upgraders
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	configv1 "github.com/openshift/api/config/v1"
+	gomock "go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("HealthCheck Etcd", func() {
+	var (
+		logger            logr.Logger
+		mockCtrl          *gomock.Controller
+		mockKubeClient    *mocks.MockClient
+		mockMetricsClient *mockMetrics.MockMetrics
+
+		// upgradeconfig to be used during tests
+		upgradeConfigName types.NamespacedName
+		upgradeConfig     *upgradev1alpha1.UpgradeConfig
+
+		version string
+
+		etcdOperator *configv1.ClusterOperator
+		etcdPods     *corev1.PodList
+		masterNodes  *corev1.NodeList
+		noResults    *metrics.AlertResponse
+	)
+
+	readyPod := func(name string, ready corev1.ConditionStatus) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: etcdNamespace},
+			Spec:       corev1.PodSpec{NodeName: strings.TrimPrefix(name, "etcd-")},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		upgradeConfigName = types.NamespacedName{
+			Name:      "test-upgradeconfig",
+			Namespace: "test-namespace",
+		}
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseNew).GetUpgradeConfig()
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+		logger = logf.Log.WithName("cluster upgrader test logger")
+		version = "mockVersion"
+
+		etcdOperator = &configv1.ClusterOperator{
+			ObjectMeta: metav1.ObjectMeta{Name: etcdOperatorName},
+			Status: configv1.ClusterOperatorStatus{
+				Conditions: []configv1.ClusterOperatorStatusCondition{
+					{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+					{Type: configv1.OperatorDegraded, Status: configv1.ConditionFalse},
+				},
+			},
+		}
+		etcdPods = &corev1.PodList{
+			Items: []corev1.Pod{
+				readyPod("etcd-master-0", corev1.ConditionTrue),
+				readyPod("etcd-master-1", corev1.ConditionTrue),
+				readyPod("etcd-master-2", corev1.ConditionTrue),
+			},
+		}
+		masterNodes = &corev1.NodeList{}
+		for _, name := range []string{"master-0", "master-1", "master-2"} {
+			masterNodes.Items = append(masterNodes.Items, corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})
+		}
+		noResults = &metrics.AlertResponse{}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When etcd is healthy", func() {
+		It("Prehealth check will pass", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: etcdOperatorName}, gomock.Any()).SetArg(2, *etcdOperator).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, *etcdPods).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, *masterNodes).Return(nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(noResults, nil).Times(1+len(etcdSignals)),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.EtcdQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.EtcdUnhealthy, version, gomock.Any()),
+			)
+			affected, err := EtcdHealth(mockMetricsClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(affected).To(BeEmpty())
+		})
+	})
+
+	Context("When the etcd operator is degraded", func() {
+		It("Prehealth check will fail", func() {
+			etcdOperator.Status.Conditions[1].Status = configv1.ConditionTrue
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *etcdOperator).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, *etcdPods).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, *masterNodes).Return(nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(noResults, nil).Times(1+len(etcdSignals)),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.EtcdQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.EtcdUnhealthy, version, gomock.Any()),
+			)
+			affected, err := EtcdHealth(mockMetricsClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(affected).To(Equal([]string{etcdOperatorName}))
+		})
+	})
+
+	Context("When an etcd member is not ready", func() {
+		It("Prehealth check will fail and report the member", func() {
+			etcdPods.Items[2] = readyPod("etcd-master-2", corev1.ConditionFalse)
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *etcdOperator).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, *etcdPods).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, *masterNodes).Return(nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(noResults, nil).Times(1+len(etcdSignals)),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.EtcdQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.EtcdUnhealthy, version, gomock.Any()),
+			)
+			affected, err := EtcdHealth(mockMetricsClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("2 of 3 etcd members ready, quorum requires 2"))
+			Expect(affected).To(Equal([]string{"openshift-etcd/etcd-master-2"}))
+		})
+	})
+
+	Context("When a master runs no etcd member", func() {
+		It("Prehealth check will fail and report the master", func() {
+			etcdPods.Items = etcdPods.Items[:2]
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *etcdOperator).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, *etcdPods).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, *masterNodes).Return(nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(noResults, nil).Times(1+len(etcdSignals)),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.EtcdQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.EtcdUnhealthy, version, gomock.Any()),
+			)
+			affected, err := EtcdHealth(mockMetricsClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("master master-2 runs no etcd member"))
+			Expect(err.Error()).To(ContainSubstring("2 of 3 etcd members ready, quorum requires 2"))
+			Expect(affected).To(Equal([]string{"master-2"}))
+		})
+	})
+
+	Context("When etcd alerts are firing and a member has slow disks", func() {
+		It("Prehealth check will fail and report the alerts and the member", func() {
+			alerts := &metrics.AlertResponse{
+				Data: metrics.AlertData{
+					Result: []metrics.AlertResult{
+						{Metric: map[string]string{"alertname": "etcdHighFsyncDurations"}},
+						{Metric: map[string]string{"alertname": "etcdHighFsyncDurations"}},
+					},
+				},
+			}
+			slowMember := &metrics.AlertResponse{
+				Data: metrics.AlertData{
+					Result: []metrics.AlertResult{
+						{Metric: map[string]string{"pod": "etcd-master-1"}},
+					},
+				},
+			}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *etcdOperator).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, *etcdPods).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, *masterNodes).Return(nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(alerts, nil),
+				mockMetricsClient.EXPECT().Query(etcdSignals[0].query).Return(noResults, nil),
+				mockMetricsClient.EXPECT().Query(etcdSignals[1].query).Return(slowMember, nil),
+				mockMetricsClient.EXPECT().Query(etcdSignals[2].query).Return(noResults, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.EtcdQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.EtcdUnhealthy, version, gomock.Any()),
+			)
+			affected, err := EtcdHealth(mockMetricsClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("high WAL fsync latency on etcd-master-1"))
+			Expect(affected).To(Equal([]string{"etcdHighFsyncDurations", "openshift-etcd/etcd-master-1"}))
+		})
+	})
+
+	Context("When a member signal has no pod label", func() {
+		It("Prehealth check will fail and report the instance", func() {
+			noLeader := &metrics.AlertResponse{
+				Data: metrics.AlertData{
+					Result: []metrics.AlertResult{
+						{Metric: map[string]string{"instance": "10.0.0.3:9979"}},
+					},
+				},
+			}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *etcdOperator).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, *etcdPods).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, *masterNodes).Return(nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(noResults, nil),
+				mockMetricsClient.EXPECT().Query(etcdSignals[0].query).Return(noLeader, nil),
+				mockMetricsClient.EXPECT().Query(etcdSignals[1].query).Return(noResults, nil),
+				mockMetricsClient.EXPECT().Query(etcdSignals[2].query).Return(noResults, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.EtcdQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.EtcdUnhealthy, version, gomock.Any()),
+			)
+			affected, err := EtcdHealth(mockMetricsClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("on 10.0.0.3:9979"))
+			Expect(affected).To(Equal([]string{"10.0.0.3:9979"}))
+		})
+	})
+
+	Context("When unable to query etcd alerts", func() {
+		It("Prehealth check will fail", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *etcdOperator).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, *etcdPods).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, *masterNodes).Return(nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(nil, fmt.Errorf("fake query error")),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.EtcdQueryFailed, version, gomock.Any()),
+			)
+			affected, err := EtcdHealth(mockMetricsClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(affected).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,98 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+)
+
+// monitoringProbeQuery is a query that any reachable Prometheus answers
+const monitoringProbeQuery = "vector(1)"
+
+// MonitoringAvailable function will check whether the monitoring stack can be queried
+func MonitoringAvailable(metricsClient metrics.Metrics, ug *upgradev1alpha1.UpgradeConfig, logger logr.Logger, version string) bool {
+	// Get current upgrade state
+	history := ug.Status.History.GetHistory(ug.Spec.Desired.Version)
+	state := string(history.Phase)
+
+	_, err := metricsClient.Query(monitoringProbeQuery)
+	if err != nil {
+		logger.Info(fmt.Sprintf("Monitoring stack is unavailable, falling back to the Kubernetes API: %s", err))
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.MonitoringUnavailable, version, state)
+		return false
+	}
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.MonitoringUnavailable, version, state)
+	return true
+}
+
+// KubernetesHealth function will check the ClusterOperators, the node conditions and the etcd members
+// through the Kubernetes API. It stands in for the critical alerts health check when the monitoring stack
+// is unavailable.
+func KubernetesHealth(metricsClient metrics.Metrics, cvClient cv.ClusterVersion, machinery machinery.Machinery, c client.Client, ug *upgradev1alpha1.UpgradeConfig, logger logr.Logger, version string) ([]string, error) {
+	// Get current upgrade state
+	history := ug.Status.History.GetHistory(ug.Spec.Desired.Version)
+	state := string(history.Phase)
+
+	var affected []string
+	var problems []string
+
+	operators, err := cvClient.HasDegradedOperators()
+	if err != nil {
+		logger.Info("Unable to fetch status of clusteroperators")
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.FallbackQueryFailed, version, state)
+		return nil, err
+	}
+	for _, operator := range operators.Degraded {
+		affected = append(affected, operator)
+		problems = append(problems, fmt.Sprintf("operator %s degraded", operator))
+	}
+
+	nodes := &corev1.NodeList{}
+	err = c.List(context.TODO(), nodes)
+	if err != nil {
+		logger.Info("Unable to fetch node list")
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.FallbackQueryFailed, version, state)
+		return nil, err
+	}
+	for _, node := range nodes.Items {
+		node := node
+		result := machinery.GetNodeConditions(&node)
+		switch {
+		case result.IsUnknown:
+			affected = append(affected, node.Name)
+			problems = append(problems, fmt.Sprintf("node %s kubelet status unknown", node.Name))
+		case !result.IsReady:
+			affected = append(affected, node.Name)
+			problems = append(problems, fmt.Sprintf("node %s NotReady", node.Name))
+		}
+	}
+
+	etcdAffected, etcdProblems, err := etcdMembers(c)
+	if err != nil {
+		logger.Info(fmt.Sprintf("Unable to check etcd members: %s", err))
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.FallbackQueryFailed, version, state)
+		return nil, err
+	}
+	affected = append(affected, etcdAffected...)
+	problems = append(problems, etcdProblems...)
+
+	if len(problems) > 0 {
+		logger.Info(fmt.Sprintf("Cluster is unhealthy: %s", strings.Join(problems, ", ")))
+		metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.FallbackQueryFailed, version, state)
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.FallbackClusterUnhealthy, version, state)
+		return affected, fmt.Errorf("alerts unavailable and cluster is unhealthy: %s", strings.Join(problems, ", "))
+	}
+	logger.Info("Prehealth check through the Kubernetes API passed")
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.FallbackQueryFailed, version, state)
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.FallbackClusterUnhealthy, version, state)
+	return nil, nil
+}
@@ -0,0 +1,157 @@
+package upgraders
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	configv1 "github.com/openshift/api/config/v1"
+	gomock "go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	cvMocks "github.com/openshift/managed-upgrade-operator/pkg/clusterversion/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+	machineryMocks "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("HealthCheck Monitoring Fallback", func() {
+	var (
+		logger              logr.Logger
+		mockCtrl            *gomock.Controller
+		mockKubeClient      *mocks.MockClient
+		mockMetricsClient   *mockMetrics.MockMetrics
+		mockCVClient        *cvMocks.MockClusterVersion
+		mockMachineryClient *machineryMocks.MockMachinery
+
+		// upgradeconfig to be used during tests
+		upgradeConfigName types.NamespacedName
+		upgradeConfig     *upgradev1alpha1.UpgradeConfig
+
+		version  string
+		nodes    *corev1.NodeList
+		etcdPods *corev1.PodList
+		masters  *corev1.NodeList
+	)
+
+	BeforeEach(func() {
+		upgradeConfigName = types.NamespacedName{
+			Name:      "test-upgradeconfig",
+			Namespace: "test-namespace",
+		}
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseNew).GetUpgradeConfig()
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
+		mockMachineryClient = machineryMocks.NewMockMachinery(mockCtrl)
+		logger = logf.Log.WithName("cluster upgrader test logger")
+		version = "mockVersion"
+
+		nodes = &corev1.NodeList{
+			Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "worker-a"}}},
+		}
+		etcdPods = &corev1.PodList{
+			Items: []corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "etcd-master-0", Namespace: etcdNamespace},
+					Spec:       corev1.PodSpec{NodeName: "master-0"},
+					Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}},
+				},
+			},
+		}
+		masters = &corev1.NodeList{
+			Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "master-0"}}},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When probing the monitoring stack", func() {
+		It("reports it as available when Prometheus answers", func() {
+			gomock.InOrder(
+				mockMetricsClient.EXPECT().Query(monitoringProbeQuery).Return(&metrics.AlertResponse{}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.MonitoringUnavailable, version, gomock.Any()),
+			)
+			Expect(MonitoringAvailable(mockMetricsClient, upgradeConfig, logger, version)).To(BeTrue())
+		})
+
+		It("reports it as unavailable when Prometheus cannot be queried", func() {
+			gomock.InOrder(
+				mockMetricsClient.EXPECT().Query(monitoringProbeQuery).Return(nil, fmt.Errorf("fake prometheus unavailable")),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.MonitoringUnavailable, version, gomock.Any()),
+			)
+			Expect(MonitoringAvailable(mockMetricsClient, upgradeConfig, logger, version)).To(BeFalse())
+		})
+	})
+
+	Context("When the cluster is healthy", func() {
+		It("Prehealth check will pass", func() {
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockMachineryClient.EXPECT().GetNodeConditions(gomock.Any()).Return(&machinery.NodeConditionsResult{IsReady: true}),
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *etcdPods),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *masters),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.FallbackQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.FallbackClusterUnhealthy, version, gomock.Any()),
+			)
+			affected, err := KubernetesHealth(mockMetricsClient, mockCVClient, mockMachineryClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(affected).To(BeNil())
+		})
+	})
+
+	Context("When operators are degraded, nodes are not ready and etcd is unavailable", func() {
+		It("Prehealth check will fail and report them", func() {
+			etcdOperator := &configv1.ClusterOperator{
+				Status: configv1.ClusterOperatorStatus{
+					Conditions: []configv1.ClusterOperatorStatusCondition{
+						{Type: configv1.OperatorAvailable, Status: configv1.ConditionFalse, Message: "members unavailable"},
+					},
+				},
+			}
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{"monitoring"}}, nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockMachineryClient.EXPECT().GetNodeConditions(gomock.Any()).Return(&machinery.NodeConditionsResult{IsReady: false}),
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, *etcdOperator),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *etcdPods),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *masters),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.FallbackQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.FallbackClusterUnhealthy, version, gomock.Any()),
+			)
+			affected, err := KubernetesHealth(mockMetricsClient, mockCVClient, mockMachineryClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(Equal("alerts unavailable and cluster is unhealthy: operator monitoring degraded, node worker-a NotReady, " +
+				"etcd operator is Available: members unavailable"))
+			Expect(affected).To(Equal([]string{"monitoring", "worker-a", "etcd"}))
+		})
+	})
+
+	Context("When unable to fetch the status of the ClusterOperators", func() {
+		It("Prehealth check will fail", func() {
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasDegradedOperators().Return(nil, fmt.Errorf("fake cannot fetch clusteroperators")),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.FallbackQueryFailed, version, gomock.Any()),
+			)
+			affected, err := KubernetesHealth(mockMetricsClient, mockCVClient, mockMachineryClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(affected).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,72 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+)
+
+// MachineConfigPools function will report the MachineConfigPools that are degraded or still rolling out
+// a configuration, and the nodes the machine config daemon failed to update.
+func MachineConfigPools(metricsClient metrics.Metrics, machinery machinery.Machinery, c client.Client, ug *upgradev1alpha1.UpgradeConfig, logger logr.Logger, version string) ([]string, error) {
+	// Get current upgrade state
+	history := ug.Status.History.GetHistory(ug.Spec.Desired.Version)
+	state := string(history.Phase)
+
+	pools, err := machinery.IsUpgradingPools(c)
+	if err != nil {
+		logger.Info("Unable to fetch MachineConfigPool list")
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.MachineConfigPoolQueryFailed, version, state)
+		return nil, err
+	}
+
+	nodes := &corev1.NodeList{}
+	err = c.List(context.TODO(), nodes)
+	if err != nil {
+		logger.Info("Unable to fetch node list")
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.MachineConfigPoolQueryFailed, version, state)
+		return nil, err
+	}
+
+	var affected []string
+	var reasons []string
+	for _, pool := range pools {
+		var poolReasons []string
+		if pool.IsDegraded {
+			poolReasons = append(poolReasons, "degraded")
+		}
+		if pool.IsUpgrading {
+			poolReasons = append(poolReasons, fmt.Sprintf("%d of %d machines updated", pool.UpdatedCount, pool.MachineCount))
+		}
+		if len(poolReasons) > 0 {
+			affected = append(affected, pool.Name)
+			reasons = append(reasons, fmt.Sprintf("pool %s (%s)", pool.Name, strings.Join(poolReasons, ", ")))
+		}
+	}
+	for _, node := range nodes.Items {
+		node := node
+		if machinery.IsNodeDegraded(&node) {
+			affected = append(affected, node.Name)
+			reasons = append(reasons, fmt.Sprintf("node %s (machine config degraded)", node.Name))
+		}
+	}
+
+	if len(affected) > 0 {
+		logger.Info(fmt.Sprintf("MachineConfigPools are not settled: %s", strings.Join(reasons, ", ")))
+		metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.MachineConfigPoolQueryFailed, version, state)
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.MachineConfigPoolsNotSettled, version, state)
+		return affected, fmt.Errorf("machine config pools are not settled: %s", strings.Join(reasons, ", "))
+	}
+	logger.Info("Prehealth check for MachineConfigPools passed")
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.MachineConfigPoolQueryFailed, version, state)
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.MachineConfigPoolsNotSettled, version, state)
+	return nil, nil
+}
@@ -0,0 +1,112 @@
+package upgraders
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	gomock "go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+	machineryMocks "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("HealthCheck MachineConfigPools", func() {
+	var (
+		logger              logr.Logger
+		mockCtrl            *gomock.Controller
+		mockKubeClient      *mocks.MockClient
+		mockMetricsClient   *mockMetrics.MockMetrics
+		mockMachineryClient *machineryMocks.MockMachinery
+
+		// upgradeconfig to be used during tests
+		upgradeConfigName types.NamespacedName
+		upgradeConfig     *upgradev1alpha1.UpgradeConfig
+
+		version string
+		nodes   *corev1.NodeList
+		pools   []*machinery.UpgradingResult
+	)
+
+	BeforeEach(func() {
+		upgradeConfigName = types.NamespacedName{
+			Name:      "test-upgradeconfig",
+			Namespace: "test-namespace",
+		}
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseNew).GetUpgradeConfig()
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+		mockMachineryClient = machineryMocks.NewMockMachinery(mockCtrl)
+		logger = logf.Log.WithName("cluster upgrader test logger")
+		version = "mockVersion"
+
+		nodes = &corev1.NodeList{
+			Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "worker-a"}}},
+		}
+		pools = []*machinery.UpgradingResult{
+			{Name: "master", MachineCount: 3, UpdatedCount: 3},
+			{Name: "worker", MachineCount: 3, UpdatedCount: 3},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When every MachineConfigPool is settled", func() {
+		It("Prehealth check will pass", func() {
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsUpgradingPools(mockKubeClient).Return(pools, nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockMachineryClient.EXPECT().IsNodeDegraded(gomock.Any()).Return(false),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.MachineConfigPoolQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.MachineConfigPoolsNotSettled, version, gomock.Any()),
+			)
+			affected, err := MachineConfigPools(mockMetricsClient, mockMachineryClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(affected).To(BeNil())
+		})
+	})
+
+	Context("When a MachineConfigPool is degraded or rolling out and a node is degraded", func() {
+		It("Prehealth check will fail and report the pools and nodes", func() {
+			pools = append(pools, &machinery.UpgradingResult{Name: "infra", IsUpgrading: true, IsDegraded: true, MachineCount: 3, UpdatedCount: 1})
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsUpgradingPools(mockKubeClient).Return(pools, nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockMachineryClient.EXPECT().IsNodeDegraded(gomock.Any()).Return(true),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.MachineConfigPoolQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.MachineConfigPoolsNotSettled, version, gomock.Any()),
+			)
+			affected, err := MachineConfigPools(mockMetricsClient, mockMachineryClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(Equal("machine config pools are not settled: pool infra (degraded, 1 of 3 machines updated), node worker-a (machine config degraded)"))
+			Expect(affected).To(Equal([]string{"infra", "worker-a"}))
+		})
+	})
+
+	Context("When unable to fetch the MachineConfigPools", func() {
+		It("Prehealth check will fail", func() {
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsUpgradingPools(mockKubeClient).Return(nil, fmt.Errorf("fake cannot fetch pools")),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.MachineConfigPoolQueryFailed, version, gomock.Any()),
+			)
+			affected, err := MachineConfigPools(mockMetricsClient, mockMachineryClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(affected).To(BeNil())
+		})
+	})
+})
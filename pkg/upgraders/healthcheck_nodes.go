@@ -132,3 +132,54 @@ func NodeUnschedulableTaints(metricsClient metrics.Metrics, machinery machinery.
 	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.ClusterNodesTaintedUnschedulable, version, state)
 	return nil, nil
 }
+
+// NodeConditions function will check the status conditions of all nodes and report the nodes that
+// are NotReady, whose kubelet status is unknown or that are under memory, disk or PID pressure.
+func NodeConditions(metricsClient metrics.Metrics, machinery machinery.Machinery, c client.Client, ug *upgradev1alpha1.UpgradeConfig, logger logr.Logger, version string) ([]string, error) {
+	nodes := &corev1.NodeList{}
+	cops := &client.ListOptions{}
+
+	// Get current upgrade state
+	history := ug.Status.History.GetHistory(ug.Spec.Desired.Version)
+	state := string(history.Phase)
+
+	err := c.List(context.TODO(), nodes, cops)
+	if err != nil {
+		logger.Info("Unable to fetch node list")
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.ClusterNodeQueryFailed, version, state)
+		return nil, err
+	}
+
+	var unhealthyNodes []string
+	var reasons []string
+	for _, node := range nodes.Items {
+		node := node
+		result := machinery.GetNodeConditions(&node)
+		if result.IsHealthy() {
+			continue
+		}
+
+		var conditions []string
+		switch {
+		case result.IsUnknown:
+			conditions = append(conditions, "kubelet status unknown")
+		case !result.IsReady:
+			conditions = append(conditions, "NotReady")
+		}
+		for _, p := range result.Pressure {
+			conditions = append(conditions, string(p))
+		}
+		unhealthyNodes = append(unhealthyNodes, node.Name)
+		reasons = append(reasons, fmt.Sprintf("%s (%s)", node.Name, strings.Join(conditions, ", ")))
+	}
+
+	if len(unhealthyNodes) > 0 {
+		logger.Info(fmt.Sprintf("Unhealthy node conditions: %s", strings.Join(reasons, ", ")))
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.ClusterNodesUnhealthyConditions, version, state)
+		return unhealthyNodes, fmt.Errorf("unhealthy node conditions: %s", strings.Join(reasons, ", "))
+	}
+	logger.Info("Prehealth check for node conditions passed")
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.ClusterNodeQueryFailed, version, state)
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.ClusterNodesUnhealthyConditions, version, state)
+	return nil, nil
+}
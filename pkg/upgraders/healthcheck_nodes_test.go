@@ -158,4 +158,63 @@ var _ = Describe("HealthCheck Manually Cordoned node", func() {
 		})
 
 	})
+
+	Context("When checking node conditions", func() {
+		nodes := &corev1.NodeList{
+			Items: []corev1.Node{
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-c"}},
+			},
+		}
+
+		It("Prehealth check will pass when all nodes are healthy", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockMachineryClient.EXPECT().GetNodeConditions(gomock.Any()).Return(&machinery.NodeConditionsResult{IsReady: true}).Times(3),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodeQueryFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodesUnhealthyConditions, gomock.Any(), gomock.Any()),
+			)
+			result, err := NodeConditions(mockMetricsClient, mockMachineryClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result).Should(BeNil())
+		})
+
+		It("Prehealth check will fail and report every unhealthy node", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockMachineryClient.EXPECT().GetNodeConditions(gomock.Any()).Return(&machinery.NodeConditionsResult{IsReady: true, Pressure: []corev1.NodeConditionType{corev1.NodeMemoryPressure}}),
+				mockMachineryClient.EXPECT().GetNodeConditions(gomock.Any()).Return(&machinery.NodeConditionsResult{IsReady: true}),
+				mockMachineryClient.EXPECT().GetNodeConditions(gomock.Any()).Return(&machinery.NodeConditionsResult{IsUnknown: true}),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.ClusterNodesUnhealthyConditions, gomock.Any(), gomock.Any()),
+			)
+			result, err := NodeConditions(mockMetricsClient, mockMachineryClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(Equal("unhealthy node conditions: node-a (MemoryPressure), node-c (kubelet status unknown)"))
+			Expect(result).To(Equal([]string{"node-a", "node-c"}))
+		})
+
+		It("Prehealth check will fail when a node is NotReady", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockMachineryClient.EXPECT().GetNodeConditions(gomock.Any()).Return(&machinery.NodeConditionsResult{}),
+				mockMachineryClient.EXPECT().GetNodeConditions(gomock.Any()).Return(&machinery.NodeConditionsResult{IsReady: true}).Times(2),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.ClusterNodesUnhealthyConditions, gomock.Any(), gomock.Any()),
+			)
+			result, err := NodeConditions(mockMetricsClient, mockMachineryClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("node-a (NotReady)"))
+			Expect(result).To(Equal([]string{"node-a"}))
+		})
+
+		It("Prehealth check will fail when unable to fetch node list", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("Fake cannot fetch nodes")),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.ClusterNodeQueryFailed, gomock.Any(), gomock.Any()),
+			)
+			result, err := NodeConditions(mockMetricsClient, mockMachineryClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(result).Should(BeNil())
+		})
+	})
 })
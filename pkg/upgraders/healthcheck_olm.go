@@ -0,0 +1,164 @@
+package upgraders
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+)
+
+const (
+	// olmPropertiesAnnotation holds the properties an operator declares on its ClusterServiceVersion
+	olmPropertiesAnnotation = "operatorframework.io/properties"
+	// olmMaxOpenShiftVersionProperty is the property declaring the latest OpenShift minor version an operator supports
+	olmMaxOpenShiftVersionProperty = "olm.maxOpenShiftVersion"
+	// olmCopiedFromLabel marks the copies OLM makes of a ClusterServiceVersion in every watched namespace
+	olmCopiedFromLabel = "olm.copiedFrom"
+	// olmUpgradeableCondition is the OperatorCondition an operator sets to block cluster upgrades
+	olmUpgradeableCondition = "Upgradeable"
+)
+
+var (
+	csvListGVK               = schema.GroupVersionKind{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "ClusterServiceVersionList"}
+	operatorConditionListGVK = schema.GroupVersionKind{Group: "operators.coreos.com", Version: "v2", Kind: "OperatorConditionList"}
+)
+
+// olmProperties is the content of the properties annotation of a ClusterServiceVersion
+type olmProperties struct {
+	Properties []struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	} `json:"properties"`
+}
+
+// OperatorCompatibility function will, for y-stream upgrades, report the OLM operators whose
+// ClusterServiceVersion declares a maximum OpenShift version below the desired version or whose
+// OperatorCondition reports them as not upgradeable.
+func OperatorCompatibility(metricsClient metrics.Metrics, c client.Client, ug *upgradev1alpha1.UpgradeConfig, logger logr.Logger, version string) ([]string, error) {
+	// Get current upgrade state
+	history := ug.Status.History.GetHistory(ug.Spec.Desired.Version)
+	state := string(history.Phase)
+
+	if _, _, ok := ocpMinorRange(version, ug.Spec.Desired.Version); !ok {
+		logger.Info("Skipping operator compatibility check as the upgrade is not a y-stream upgrade")
+		return nil, nil
+	}
+	desired, err := semver.ParseTolerant(ug.Spec.Desired.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	csvs := &unstructured.UnstructuredList{}
+	csvs.SetGroupVersionKind(csvListGVK)
+	err = c.List(context.TODO(), csvs)
+	if err != nil {
+		logger.Info("Unable to fetch ClusterServiceVersion list")
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.OLMQueryFailed, version, state)
+		return nil, err
+	}
+
+	operatorConditions := &unstructured.UnstructuredList{}
+	operatorConditions.SetGroupVersionKind(operatorConditionListGVK)
+	err = c.List(context.TODO(), operatorConditions)
+	if err != nil {
+		logger.Info("Unable to fetch OperatorCondition list")
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.OLMQueryFailed, version, state)
+		return nil, err
+	}
+	notUpgradeable := map[string]string{}
+	for _, oc := range operatorConditions.Items {
+		if message, ok := operatorNotUpgradeable(oc); ok {
+			notUpgradeable[oc.GetNamespace()+"/"+oc.GetName()] = message
+		}
+	}
+
+	var operators []string
+	var reasons []string
+	for _, csv := range csvs.Items {
+		if _, copied := csv.GetLabels()[olmCopiedFromLabel]; copied {
+			continue
+		}
+		name := csv.GetNamespace() + "/" + csv.GetName()
+
+		var operatorReasons []string
+		if maxVersion, ok := maxOpenShiftVersion(csv); ok {
+			if desired.Major > maxVersion.Major || (desired.Major == maxVersion.Major && desired.Minor > maxVersion.Minor) {
+				operatorReasons = append(operatorReasons, fmt.Sprintf("supports OpenShift up to %d.%d", maxVersion.Major, maxVersion.Minor))
+			}
+		}
+		if message, ok := notUpgradeable[name]; ok {
+			operatorReasons = append(operatorReasons, fmt.Sprintf("not upgradeable: %s", message))
+		}
+		if len(operatorReasons) > 0 {
+			operators = append(operators, name)
+			reasons = append(reasons, fmt.Sprintf("%s (%s)", name, strings.Join(operatorReasons, ", ")))
+		}
+	}
+
+	if len(operators) > 0 {
+		logger.Info(fmt.Sprintf("Operators are incompatible with the desired version: %s", strings.Join(reasons, "; ")))
+		metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.OLMQueryFailed, version, state)
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.OLMOperatorsIncompatible, version, state)
+		return operators, fmt.Errorf("operators are incompatible with %s: %s", ug.Spec.Desired.Version, strings.Join(reasons, "; "))
+	}
+	logger.Info("Prehealth check for operator compatibility passed")
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.OLMQueryFailed, version, state)
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.OLMOperatorsIncompatible, version, state)
+	return nil, nil
+}
+
+// maxOpenShiftVersion returns the maximum OpenShift version declared in the properties of the ClusterServiceVersion
+func maxOpenShiftVersion(csv unstructured.Unstructured) (semver.Version, bool) {
+	annotation, ok := csv.GetAnnotations()[olmPropertiesAnnotation]
+	if !ok {
+		return semver.Version{}, false
+	}
+	properties := olmProperties{}
+	if err := json.Unmarshal([]byte(annotation), &properties); err != nil {
+		return semver.Version{}, false
+	}
+	for _, p := range properties.Properties {
+		if p.Type != olmMaxOpenShiftVersionProperty {
+			continue
+		}
+		// The version may be declared as either a string or a number
+		maxVersion, err := semver.ParseTolerant(strings.Trim(string(p.Value), `"`))
+		if err != nil {
+			return semver.Version{}, false
+		}
+		return maxVersion, true
+	}
+	return semver.Version{}, false
+}
+
+// operatorNotUpgradeable returns the message of the Upgradeable condition of the OperatorCondition if it is False.
+// Overrides set by the cluster administrator take precedence over the conditions set by the operator.
+func operatorNotUpgradeable(oc unstructured.Unstructured) (string, bool) {
+	for _, path := range [][]string{{"spec", "overrides"}, {"spec", "conditions"}, {"status", "conditions"}} {
+		conditions, _, _ := unstructured.NestedSlice(oc.Object, path...)
+		for _, c := range conditions {
+			condition, ok := c.(map[string]interface{})
+			if !ok || condition["type"] != olmUpgradeableCondition {
+				continue
+			}
+			if condition["status"] != "False" {
+				return "", false
+			}
+			message, _ := condition["message"].(string)
+			if message == "" {
+				message, _ = condition["reason"].(string)
+			}
+			return message, true
+		}
+	}
+	return "", false
+}
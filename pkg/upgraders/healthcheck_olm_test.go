@@ -0,0 +1,166 @@
+package upgraders
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	gomock "go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("HealthCheck Operator Compatibility", func() {
+	var (
+		logger            logr.Logger
+		mockCtrl          *gomock.Controller
+		mockKubeClient    *mocks.MockClient
+		mockMetricsClient *mockMetrics.MockMetrics
+
+		// upgradeconfig to be used during tests
+		upgradeConfigName types.NamespacedName
+		upgradeConfig     *upgradev1alpha1.UpgradeConfig
+
+		version            string
+		csvs               *unstructured.UnstructuredList
+		operatorConditions *unstructured.UnstructuredList
+	)
+
+	newCSV := func(namespace string, name string, properties string) unstructured.Unstructured {
+		csv := unstructured.Unstructured{Object: map[string]interface{}{}}
+		csv.SetNamespace(namespace)
+		csv.SetName(name)
+		if properties != "" {
+			csv.SetAnnotations(map[string]string{olmPropertiesAnnotation: properties})
+		}
+		return csv
+	}
+
+	newOperatorCondition := func(namespace string, name string, status string, message string) unstructured.Unstructured {
+		oc := unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Upgradeable", "status": status, "message": message},
+				},
+			},
+		}}
+		oc.SetNamespace(namespace)
+		oc.SetName(name)
+		return oc
+	}
+
+	BeforeEach(func() {
+		upgradeConfigName = types.NamespacedName{
+			Name:      "test-upgradeconfig",
+			Namespace: "test-namespace",
+		}
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseNew).GetUpgradeConfig()
+		upgradeConfig.Spec.Desired.Version = "4.14.2"
+		upgradeConfig.Status.History[0].Version = "4.14.2"
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+		logger = logf.Log.WithName("cluster upgrader test logger")
+		version = "4.13.10"
+
+		csvs = &unstructured.UnstructuredList{
+			Items: []unstructured.Unstructured{
+				newCSV("openshift-operators", "compatible.v1.0.0", `{"properties":[{"type":"olm.maxOpenShiftVersion","value":"4.14"}]}`),
+				newCSV("openshift-operators", "unrestricted.v2.1.0", ""),
+			},
+		}
+		operatorConditions = &unstructured.UnstructuredList{
+			Items: []unstructured.Unstructured{
+				newOperatorCondition("openshift-operators", "compatible.v1.0.0", "True", ""),
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When the upgrade is not a y-stream upgrade", func() {
+		It("Prehealth check will be skipped", func() {
+			upgradeConfig.Spec.Desired.Version = "4.13.11"
+			upgradeConfig.Status.History[0].Version = "4.13.11"
+			affected, err := OperatorCompatibility(mockMetricsClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(affected).To(BeNil())
+		})
+	})
+
+	Context("When every operator is compatible with the desired version", func() {
+		It("Prehealth check will pass", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *csvs),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *operatorConditions),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.OLMQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.OLMOperatorsIncompatible, version, gomock.Any()),
+			)
+			affected, err := OperatorCompatibility(mockMetricsClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(affected).To(BeNil())
+		})
+	})
+
+	Context("When operators are incompatible with the desired version", func() {
+		It("Prehealth check will fail and report the operators", func() {
+			copied := newCSV("app", "legacy.v0.9.0", `{"properties":[{"type":"olm.maxOpenShiftVersion","value":"4.13"}]}`)
+			copied.SetLabels(map[string]string{olmCopiedFromLabel: "openshift-operators"})
+			csvs.Items = append(csvs.Items,
+				newCSV("openshift-operators", "legacy.v0.9.0", `{"properties":[{"type":"olm.maxOpenShiftVersion","value":4.13}]}`),
+				newCSV("database", "db-operator.v3.2.0", ""),
+				copied,
+			)
+			operatorConditions.Items = append(operatorConditions.Items,
+				newOperatorCondition("database", "db-operator.v3.2.0", "False", "migration in progress"),
+			)
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *csvs),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *operatorConditions),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.OLMQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.OLMOperatorsIncompatible, version, gomock.Any()),
+			)
+			affected, err := OperatorCompatibility(mockMetricsClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(Equal("operators are incompatible with 4.14.2: " +
+				"openshift-operators/legacy.v0.9.0 (supports OpenShift up to 4.13); " +
+				"database/db-operator.v3.2.0 (not upgradeable: migration in progress)"))
+			Expect(affected).To(Equal([]string{"openshift-operators/legacy.v0.9.0", "database/db-operator.v3.2.0"}))
+		})
+	})
+
+	Context("When unable to fetch the ClusterServiceVersions", func() {
+		It("Prehealth check will fail", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake cannot fetch csvs")),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.OLMQueryFailed, version, gomock.Any()),
+			)
+			affected, err := OperatorCompatibility(mockMetricsClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(affected).To(BeNil())
+		})
+	})
+
+	Context("When an administrator overrides the Upgradeable condition", func() {
+		It("uses the override", func() {
+			oc := newOperatorCondition("database", "db-operator.v3.2.0", "False", "migration in progress")
+			Expect(unstructured.SetNestedSlice(oc.Object, []interface{}{
+				map[string]interface{}{"type": "Upgradeable", "status": "True"},
+			}, "spec", "overrides")).To(Succeed())
+			_, notUpgradeable := operatorNotUpgradeable(oc)
+			Expect(notUpgradeable).To(BeFalse())
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package upgraders
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strings"
@@ -9,11 +10,16 @@ import (
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
 	"github.com/openshift/managed-upgrade-operator/pkg/dvo"
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
 	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// dvoPDBChecks are the DVO metric families reporting PodDisruptionBudgets that fail validation
+var dvoPDBChecks = []string{"deployment_validation_operator_pdb_min_available", "deployment_validation_operator_pdb_max_available"}
+
 var namespaceException = []string{"openshift-logging", "openshift-redhat-marketplace", "openshift-operators", "openshift-customer-monitoring", "openshift-cnv", "openshift-route-monitoring-operator", "openshift-user-workload-monitoring", "openshift-pipelines"}
 
 // HealthCheckPDB performs a health check on the PodDisruptionBudget (PDB) metrics.
@@ -26,16 +32,28 @@ func HealthCheckPDB(metricsClient metrics.Metrics, c client.Client, dvo dvo.DvoC
 	state := string(history.Phase)
 
 	pdbDetails, reason, err := checkPodDisruptionBudgets(c, logger)
-	if err != nil {
+	if reason == metrics.PDBQueryFailed {
 		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, reason, version, state)
 		return pdbDetails, false, err
 	}
 
-	reason, err = checkDvoMetrics(c, dvo, logger)
+	// The PDBs flagged by the DVO are merged into those found above so every offender is reported
+	dvoDetails, dvoReason, dvoErr := checkDvoMetrics(c, dvo, logger)
+	if len(dvoDetails) > 0 {
+		pdbDetails = mergePDBDetails(pdbDetails, dvoDetails)
+		dvoErr = pdbOffendersError(pdbDetails)
+	}
 	if err != nil {
+		if len(dvoDetails) > 0 {
+			err = dvoErr
+		}
 		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, reason, version, state)
 		return pdbDetails, false, err
 	}
+	if dvoErr != nil {
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, dvoReason, version, state)
+		return pdbDetails, false, dvoErr
+	}
 	// Health check passed
 	logger.Info("Prehealth check for PodDisruptionBudget passed")
 	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.ClusterInvalidPDB, version, state)
@@ -53,27 +71,95 @@ func checkPodDisruptionBudgets(c client.Client, logger logr.Logger) ([]PDBDetail
 		return pdbDetails, metrics.PDBQueryFailed, err
 	}
 
+	invalidConf := false
 	for _, pdb := range pdbList.Items {
-		if !strings.HasPrefix(pdb.Namespace, "openshift-*") || checkNamespaceExistsInArray(namespaceException, pdb.Namespace) {
-			pdbDetail := PDBDetails{}
-			pdbDetail.Name = pdb.Name
-			pdbDetail.Namespace = pdb.Namespace
+		if !strings.HasPrefix(pdb.Namespace, "openshift-") || checkNamespaceExistsInArray(namespaceException, pdb.Namespace) {
+			var reasons []string
 
 			maxResult, err := validateMaxUnavailable(pdb, logger)
 			if !maxResult {
-				pdbDetails = append(pdbDetails, pdbDetail)
-				return pdbDetails, metrics.ClusterInvalidPDBConf, err
+				invalidConf = true
+				reasons = append(reasons, err.Error())
 			}
 
 			minResult, err := validateMinAvailable(pdb, logger)
 			if !minResult {
-				pdbDetails = append(pdbDetails, pdbDetail)
-				return pdbDetails, metrics.ClusterInvalidPDBConf, err
+				invalidConf = true
+				reasons = append(reasons, err.Error())
+			}
+
+			reasons = append(reasons, validateDisruptionStatus(pdb, logger)...)
+
+			if len(reasons) > 0 {
+				pdbDetails = append(pdbDetails, PDBDetails{
+					Name:      pdb.Name,
+					Namespace: pdb.Namespace,
+					Reason:    strings.Join(reasons, ", "),
+				})
+			}
+		}
+	}
+
+	if len(pdbDetails) == 0 {
+		return pdbDetails, "", nil
+	}
+
+	err = pdbOffendersError(pdbDetails)
+	if invalidConf {
+		return pdbDetails, metrics.ClusterInvalidPDBConf, err
+	}
+	return pdbDetails, metrics.ClusterPDBDisruptionsBlocked, err
+}
+
+// pdbOffendersError returns an error naming every PDB blocking node drains and the reason it does
+func pdbOffendersError(pdbDetails []PDBDetails) error {
+	offenders := []string{}
+	for _, pdb := range pdbDetails {
+		offenders = append(offenders, fmt.Sprintf("%s/%s (%s)", pdb.Namespace, pdb.Name, pdb.Reason))
+	}
+	return fmt.Errorf("found PodDisruptionBudgets blocking node drains: %s", strings.Join(offenders, "; "))
+}
+
+// mergePDBDetails appends the additional findings to the PDB details, combining the
+// reasons of a PDB reported by both
+func mergePDBDetails(pdbDetails []PDBDetails, additional []PDBDetails) []PDBDetails {
+	for _, a := range additional {
+		merged := false
+		for i := range pdbDetails {
+			if pdbDetails[i].Namespace == a.Namespace && pdbDetails[i].Name == a.Name {
+				pdbDetails[i].Reason = strings.Join([]string{pdbDetails[i].Reason, a.Reason}, ", ")
+				merged = true
+				break
 			}
 		}
+		if !merged {
+			pdbDetails = append(pdbDetails, a)
+		}
+	}
+	return pdbDetails
+}
+
+// validateDisruptionStatus function will return the reasons the live status of a PDB
+// would block a node drain. PDBs not yet observed by the disruption controller are skipped.
+func validateDisruptionStatus(p policyv1.PodDisruptionBudget, l logr.Logger) []string {
+	if p.Status.ObservedGeneration == 0 {
+		return nil
+	}
+	if p.Status.ExpectedPods == 0 {
+		l.Info(fmt.Sprintf("PodDisruptionBudget selects no pods: %s/%s", p.Namespace, p.Name))
+		return []string{"selects no pods"}
 	}
 
-	return pdbDetails, "", nil
+	var reasons []string
+	if p.Status.CurrentHealthy < p.Status.ExpectedPods {
+		l.Info(fmt.Sprintf("PodDisruptionBudget has unhealthy pods: %s/%s", p.Namespace, p.Name))
+		reasons = append(reasons, fmt.Sprintf("%d of %d expected pods healthy", p.Status.CurrentHealthy, p.Status.ExpectedPods))
+	}
+	if p.Status.DisruptionsAllowed == 0 {
+		l.Info(fmt.Sprintf("PodDisruptionBudget allows no disruptions: %s/%s", p.Namespace, p.Name))
+		reasons = append(reasons, "no disruptions allowed")
+	}
+	return reasons
 }
 
 func checkNamespaceExistsInArray(namespaceException []string, s string) bool {
@@ -85,32 +171,67 @@ func checkNamespaceExistsInArray(namespaceException []string, s string) bool {
 	return false
 }
 
-func checkDvoMetrics(c client.Client, dvo dvo.DvoClientBuilder, logger logr.Logger) (string, error) {
+// checkDvoMetrics function will return the PDBs the deployment validation operator
+// reports as failing its PDB checks
+func checkDvoMetrics(c client.Client, dvo dvo.DvoClientBuilder, logger logr.Logger) ([]PDBDetails, string, error) {
 	// Create a new DVO client using the builder and the provided metrics client
 	client, err := dvo.New(c)
 	if err != nil {
-		return metrics.DvoClientCreationFailed, err
+		return nil, metrics.DvoClientCreationFailed, err
 	}
 
 	// Get the PDB metrics
 	dvoMetricsResult, err := client.GetMetrics()
 	if err != nil {
 		logger.Info("Error getting DVO metrics")
-		return metrics.DvoMetricsQueryFailed, err
+		return nil, metrics.DvoMetricsQueryFailed, err
 	}
 
-	badPDBExists := false
-	for _, metric := range dvoMetricsResult {
-		if strings.Contains(string(metric), "deployment_validation_operator_pdb_min_available") || strings.Contains(string(metric), "deployment_validation_operator_pdb_max_available") {
-			badPDBExists = true
-			break
-		}
+	pdbDetails, err := parseDvoPDBMetrics(dvoMetricsResult)
+	if err != nil {
+		logger.Info("Error parsing DVO metrics")
+		return nil, metrics.DvoMetricsQueryFailed, err
+	}
+	if len(pdbDetails) > 0 {
+		return pdbDetails, metrics.ClusterInvalidPDB, pdbOffendersError(pdbDetails)
 	}
-	if badPDBExists {
-		return metrics.ClusterInvalidPDB, fmt.Errorf("found a PodDisruptionBudget with incorrect configurations")
+
+	return nil, "", nil
+}
+
+// parseDvoPDBMetrics parses the DVO metrics in the Prometheus text exposition format and
+// returns a PDBDetails for each object with a failing PDB check
+func parseDvoPDBMetrics(b []byte) ([]PDBDetails, error) {
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse DVO metrics: %v", err)
 	}
 
-	return "", nil
+	pdbDetails := []PDBDetails{}
+	for _, check := range dvoPDBChecks {
+		family, ok := families[check]
+		if !ok {
+			continue
+		}
+		reason := fmt.Sprintf("flagged by deployment validation operator (%s)", strings.TrimPrefix(check, "deployment_validation_operator_"))
+		for _, m := range family.GetMetric() {
+			if m.GetGauge().GetValue() == 0 && m.GetUntyped().GetValue() == 0 {
+				continue
+			}
+			details := PDBDetails{Reason: reason}
+			for _, l := range m.GetLabel() {
+				switch l.GetName() {
+				case "namespace":
+					details.Namespace = l.GetValue()
+				case "name":
+					details.Name = l.GetValue()
+				}
+			}
+			pdbDetails = mergePDBDetails(pdbDetails, []PDBDetails{details})
+		}
+	}
+	return pdbDetails, nil
 }
 
 // validateMaxUnavailable function will return false for failures if
@@ -81,6 +81,8 @@ var _ = Describe("checkPodDisruptionBudgets", func() {
 			}
 			gomock.InOrder(
 				mockClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *pdbList),
+				mockdvoclientbulder.EXPECT().New(gomock.Any()).Return(mockdvoclient, nil),
+				mockdvoclient.EXPECT().GetMetrics().Return([]byte{}, nil),
 				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, reason, version, "New"),
 			)
 			pdbDetails, result, err := HealthCheckPDB(mockMetricsClient, mockClient, mockdvoclientbulder, upgradeConfig, logger, version)
@@ -242,7 +244,7 @@ var _ = Describe("checkPodDisruptionBudgets", func() {
 				mockdvoclient.EXPECT().GetMetrics().Return(nil, fmt.Errorf("Fake cannot fetch all metrics ")),
 				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, reason, version, "New"),
 			)
-			result, err := checkDvoMetrics(mockClient, mockdvoclientbulder, logger)
+			_, result, err := checkDvoMetrics(mockClient, mockdvoclientbulder, logger)
 			Expect(err).To(HaveOccurred())
 			Expect(result).To(Equal(metrics.DvoMetricsQueryFailed))
 		})
@@ -271,7 +273,7 @@ var _ = Describe("checkPodDisruptionBudgets", func() {
 				mockdvoclientbulder.EXPECT().New(gomock.Any()).Return(mockdvoclient, nil),
 				mockdvoclient.EXPECT().GetMetrics().Return(nil, fmt.Errorf("failed to get DVO metrics")),
 			)
-			result, err := checkDvoMetrics(mockClient, mockdvoclientbulder, logger)
+			_, result, err := checkDvoMetrics(mockClient, mockdvoclientbulder, logger)
 			Expect(err).To(HaveOccurred())
 			Expect(result).To(Equal(metrics.DvoMetricsQueryFailed))
 		})
@@ -282,9 +284,154 @@ var _ = Describe("checkPodDisruptionBudgets", func() {
 			gomock.InOrder(
 				mockdvoclientbulder.EXPECT().New(mockClient).Return(nil, fmt.Errorf("failed to create DVO client")),
 			)
-			result, err := checkDvoMetrics(mockClient, mockdvoclientbulder, logger)
+			_, result, err := checkDvoMetrics(mockClient, mockdvoclientbulder, logger)
 			Expect(err).To(HaveOccurred())
 			Expect(result).To(Equal(metrics.DvoClientCreationFailed))
 		})
 	})
+
+	Context("When PDBs block node drains at runtime", func() {
+		observedPDB := func(name string, expected, healthy, allowed int32) policyv1.PodDisruptionBudget {
+			return policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: name},
+				Status: policyv1.PodDisruptionBudgetStatus{
+					ObservedGeneration: 1,
+					ExpectedPods:       expected,
+					CurrentHealthy:     healthy,
+					DisruptionsAllowed: allowed,
+				},
+			}
+		}
+
+		It("reports every offending PDB with its reason", func() {
+			pdbList := &policyv1.PodDisruptionBudgetList{
+				Items: []policyv1.PodDisruptionBudget{
+					observedPDB("allows-disruption", 3, 3, 1),
+					observedPDB("no-disruptions", 1, 1, 0),
+					observedPDB("unhealthy", 3, 1, 0),
+					observedPDB("no-pods", 0, 0, 0),
+				},
+			}
+			mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *pdbList)
+			pdbDetails, result, err := checkPodDisruptionBudgets(mockClient, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("found PodDisruptionBudgets blocking node drains: " +
+				"app/no-disruptions (no disruptions allowed); " +
+				"app/unhealthy (1 of 3 expected pods healthy, no disruptions allowed); " +
+				"app/no-pods (selects no pods)"))
+			Expect(result).To(Equal(metrics.ClusterPDBDisruptionsBlocked))
+			Expect(pdbDetails).To(Equal([]PDBDetails{
+				{Namespace: "app", Name: "no-disruptions", Reason: "no disruptions allowed"},
+				{Namespace: "app", Name: "unhealthy", Reason: "1 of 3 expected pods healthy, no disruptions allowed"},
+				{Namespace: "app", Name: "no-pods", Reason: "selects no pods"},
+			}))
+		})
+
+		It("reports invalid configurations alongside runtime offenders", func() {
+			invalid := observedPDB("invalid", 2, 2, 0)
+			invalid.Spec.MaxUnavailable = &intstr.IntOrString{Type: intstr.Int, IntVal: 0}
+			pdbList := &policyv1.PodDisruptionBudgetList{
+				Items: []policyv1.PodDisruptionBudget{invalid, observedPDB("no-disruptions", 1, 1, 0)},
+			}
+			mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *pdbList)
+			pdbDetails, result, err := checkPodDisruptionBudgets(mockClient, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(Equal(metrics.ClusterInvalidPDBConf))
+			Expect(pdbDetails).To(HaveLen(2))
+			Expect(pdbDetails[0].Reason).To(Equal("found a PodDisruptionBudget with MaxUnavailable set to 0, no disruptions allowed"))
+		})
+
+		It("skips the PDBs of openshift namespaces other than the exceptions", func() {
+			platform := observedPDB("platform", 1, 1, 0)
+			platform.Namespace = "openshift-monitoring"
+			excepted := observedPDB("excepted", 1, 1, 0)
+			excepted.Namespace = "openshift-logging"
+			pdbList := &policyv1.PodDisruptionBudgetList{
+				Items: []policyv1.PodDisruptionBudget{platform, excepted},
+			}
+			mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *pdbList)
+			pdbDetails, result, err := checkPodDisruptionBudgets(mockClient, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(Equal(metrics.ClusterPDBDisruptionsBlocked))
+			Expect(pdbDetails).To(Equal([]PDBDetails{
+				{Namespace: "openshift-logging", Name: "excepted", Reason: "no disruptions allowed"},
+			}))
+		})
+
+		It("skips PDBs not yet observed by the disruption controller", func() {
+			pdbList := &policyv1.PodDisruptionBudgetList{
+				Items: []policyv1.PodDisruptionBudget{{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "new"}}},
+			}
+			mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *pdbList)
+			pdbDetails, result, err := checkPodDisruptionBudgets(mockClient, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeEmpty())
+			Expect(pdbDetails).To(BeEmpty())
+		})
+	})
+
+	Context("When the DVO flags PodDisruptionBudgets", func() {
+		dvoMetrics := []byte(`# HELP deployment_validation_operator_pdb_min_available Indicates whether minAvailable is set so that evictions are possible
+# TYPE deployment_validation_operator_pdb_min_available gauge
+deployment_validation_operator_pdb_min_available{kind="PodDisruptionBudget",name="strict",namespace="app",namespace_uid="1",uid="2"} 1
+deployment_validation_operator_pdb_min_available{kind="PodDisruptionBudget",name="relaxed",namespace="app",namespace_uid="1",uid="3"} 0
+# HELP deployment_validation_operator_pdb_max_available Indicates whether maxUnavailable is set so that evictions are possible
+# TYPE deployment_validation_operator_pdb_max_available gauge
+deployment_validation_operator_pdb_max_available{kind="PodDisruptionBudget",name="strict",namespace="app",namespace_uid="1",uid="2"} 1
+deployment_validation_operator_pdb_max_available{kind="PodDisruptionBudget",name="frozen",namespace="db",namespace_uid="4",uid="5"} 1
+# HELP deployment_validation_operator_liveness_probe Indicates whether a liveness probe is set
+# TYPE deployment_validation_operator_liveness_probe gauge
+deployment_validation_operator_liveness_probe{kind="Deployment",name="web",namespace="app",namespace_uid="1",uid="6"} 1
+`)
+
+		It("reports each flagged PDB with the failing checks", func() {
+			gomock.InOrder(
+				mockdvoclientbulder.EXPECT().New(gomock.Any()).Return(mockdvoclient, nil),
+				mockdvoclient.EXPECT().GetMetrics().Return(dvoMetrics, nil),
+			)
+			pdbDetails, result, err := checkDvoMetrics(mockClient, mockdvoclientbulder, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(Equal(metrics.ClusterInvalidPDB))
+			Expect(pdbDetails).To(Equal([]PDBDetails{
+				{Namespace: "app", Name: "strict", Reason: "flagged by deployment validation operator (pdb_min_available), flagged by deployment validation operator (pdb_max_available)"},
+				{Namespace: "db", Name: "frozen", Reason: "flagged by deployment validation operator (pdb_max_available)"},
+			}))
+		})
+
+		It("merges the flagged PDBs into the PDB health check findings", func() {
+			pdbList := &policyv1.PodDisruptionBudgetList{
+				Items: []policyv1.PodDisruptionBudget{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "db", Name: "frozen"},
+						Spec:       policyv1.PodDisruptionBudgetSpec{MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 0}},
+					},
+				},
+			}
+			gomock.InOrder(
+				mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *pdbList),
+				mockdvoclientbulder.EXPECT().New(gomock.Any()).Return(mockdvoclient, nil),
+				mockdvoclient.EXPECT().GetMetrics().Return(dvoMetrics, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.ClusterInvalidPDBConf, version, gomock.Any()),
+			)
+			pdbDetails, result, err := HealthCheckPDB(mockMetricsClient, mockClient, mockdvoclientbulder, upgradeConfig, logger, version)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("app/strict"))
+			Expect(result).To(BeFalse())
+			Expect(pdbDetails).To(Equal([]PDBDetails{
+				{Namespace: "db", Name: "frozen", Reason: "found a PodDisruptionBudget with MaxUnavailable set to 0, flagged by deployment validation operator (pdb_max_available)"},
+				{Namespace: "app", Name: "strict", Reason: "flagged by deployment validation operator (pdb_min_available), flagged by deployment validation operator (pdb_max_available)"},
+			}))
+		})
+
+		It("fails when the metrics cannot be parsed", func() {
+			gomock.InOrder(
+				mockdvoclientbulder.EXPECT().New(gomock.Any()).Return(mockdvoclient, nil),
+				mockdvoclient.EXPECT().GetMetrics().Return([]byte("not { valid"), nil),
+			)
+			pdbDetails, result, err := checkDvoMetrics(mockClient, mockdvoclientbulder, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(Equal(metrics.DvoMetricsQueryFailed))
+			Expect(pdbDetails).To(BeEmpty())
+		})
+	})
 })
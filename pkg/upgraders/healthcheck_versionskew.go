@@ -0,0 +1,108 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	machineconfigv1 "github.com/openshift/api/machineconfiguration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+)
+
+// maxKubeletVersionSkew is the number of minor versions a kubelet may lag behind the control plane
+const maxKubeletVersionSkew = 2
+
+// KubeletVersionSkew function will, for y-stream upgrades, report the nodes whose kubelet would fall
+// outside the supported version skew of the desired control plane version, along with their MachineConfigPools.
+func KubeletVersionSkew(metricsClient metrics.Metrics, c client.Client, ug *upgradev1alpha1.UpgradeConfig, logger logr.Logger, version string) ([]string, error) {
+	// Get current upgrade state
+	history := ug.Status.History.GetHistory(ug.Spec.Desired.Version)
+	state := string(history.Phase)
+
+	_, desiredMinor, ok := kubernetesMinorRange(version, ug.Spec.Desired.Version)
+	if !ok {
+		logger.Info("Skipping kubelet version skew check as the upgrade is not a y-stream upgrade")
+		return nil, nil
+	}
+
+	nodes := &corev1.NodeList{}
+	err := c.List(context.TODO(), nodes)
+	if err != nil {
+		logger.Info("Unable to fetch node list")
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.KubeletVersionQueryFailed, version, state)
+		return nil, err
+	}
+
+	pools := &machineconfigv1.MachineConfigPoolList{}
+	err = c.List(context.TODO(), pools)
+	if err != nil {
+		logger.Info("Unable to fetch MachineConfigPool list")
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.KubeletVersionQueryFailed, version, state)
+		return nil, err
+	}
+
+	var affected []string
+	var reasons []string
+	for _, node := range nodes.Items {
+		kubeletVersion, err := semver.ParseTolerant(node.Status.NodeInfo.KubeletVersion)
+		if err != nil {
+			logger.Info(fmt.Sprintf("Unable to parse kubelet version %q of node %s", node.Status.NodeInfo.KubeletVersion, node.Name))
+			continue
+		}
+		if kubeletVersion.Major == 1 && kubeletVersion.Minor <= desiredMinor && desiredMinor-kubeletVersion.Minor <= maxKubeletVersionSkew {
+			continue
+		}
+
+		affected = append(affected, node.Name)
+		nodeReasons := []string{fmt.Sprintf("kubelet %s", node.Status.NodeInfo.KubeletVersion)}
+		for _, pool := range nodePools(node, pools.Items) {
+			if !slices.Contains(affected, pool.Name) {
+				affected = append(affected, pool.Name)
+			}
+			if pool.Spec.Paused {
+				nodeReasons = append(nodeReasons, fmt.Sprintf("pool %s paused", pool.Name))
+			} else {
+				nodeReasons = append(nodeReasons, fmt.Sprintf("pool %s", pool.Name))
+			}
+		}
+		reasons = append(reasons, fmt.Sprintf("node %s (%s)", node.Name, strings.Join(nodeReasons, ", ")))
+	}
+
+	if len(affected) > 0 {
+		logger.Info(fmt.Sprintf("Kubelet versions are outside the supported skew: %s", strings.Join(reasons, "; ")))
+		metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.KubeletVersionQueryFailed, version, state)
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.KubeletVersionSkewUnsupported, version, state)
+		return affected, fmt.Errorf("kubelet versions are outside the supported skew of Kubernetes 1.%d: %s", desiredMinor, strings.Join(reasons, "; "))
+	}
+	logger.Info("Prehealth check for kubelet version skew passed")
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.KubeletVersionQueryFailed, version, state)
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.KubeletVersionSkewUnsupported, version, state)
+	return nil, nil
+}
+
+// nodePools returns the MachineConfigPools whose node selector matches the node
+func nodePools(node corev1.Node, pools []machineconfigv1.MachineConfigPool) []machineconfigv1.MachineConfigPool {
+	var matching []machineconfigv1.MachineConfigPool
+	for _, pool := range pools {
+		if pool.Spec.NodeSelector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pool.Spec.NodeSelector)
+		if err != nil || selector.Empty() {
+			continue
+		}
+		if selector.Matches(labels.Set(node.Labels)) {
+			matching = append(matching, pool)
+		}
+	}
+	return matching
+}
@@ -0,0 +1,146 @@
+package upgraders
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	machineconfigv1 "github.com/openshift/api/machineconfiguration/v1"
+	gomock "go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("HealthCheck Kubelet Version Skew", func() {
+	var (
+		logger            logr.Logger
+		mockCtrl          *gomock.Controller
+		mockKubeClient    *mocks.MockClient
+		mockMetricsClient *mockMetrics.MockMetrics
+
+		// upgradeconfig to be used during tests
+		upgradeConfigName types.NamespacedName
+		upgradeConfig     *upgradev1alpha1.UpgradeConfig
+
+		version string
+		nodes   *corev1.NodeList
+		pools   *machineconfigv1.MachineConfigPoolList
+	)
+
+	newNode := func(name string, role string, kubeletVersion string) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"node-role.kubernetes.io/" + role: ""}},
+			Status:     corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{KubeletVersion: kubeletVersion}},
+		}
+	}
+
+	newPool := func(name string, role string, paused bool) machineconfigv1.MachineConfigPool {
+		return machineconfigv1.MachineConfigPool{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: machineconfigv1.MachineConfigPoolSpec{
+				NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"node-role.kubernetes.io/" + role: ""}},
+				Paused:       paused,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		upgradeConfigName = types.NamespacedName{
+			Name:      "test-upgradeconfig",
+			Namespace: "test-namespace",
+		}
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseNew).GetUpgradeConfig()
+		upgradeConfig.Spec.Desired.Version = "4.14.2"
+		upgradeConfig.Status.History[0].Version = "4.14.2"
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+		logger = logf.Log.WithName("cluster upgrader test logger")
+		version = "4.13.10"
+
+		nodes = &corev1.NodeList{
+			Items: []corev1.Node{
+				newNode("master-0", "master", "v1.26.7+c7ee51f"),
+				newNode("worker-a", "worker", "v1.25.11+1485cc9"),
+			},
+		}
+		pools = &machineconfigv1.MachineConfigPoolList{
+			Items: []machineconfigv1.MachineConfigPool{
+				newPool("master", "master", false),
+				newPool("worker", "worker", false),
+				newPool("infra", "infra", true),
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When the upgrade is not a y-stream upgrade", func() {
+		It("Prehealth check will be skipped", func() {
+			upgradeConfig.Spec.Desired.Version = "4.13.11"
+			upgradeConfig.Status.History[0].Version = "4.13.11"
+			affected, err := KubeletVersionSkew(mockMetricsClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(affected).To(BeNil())
+		})
+	})
+
+	Context("When every kubelet is within the supported skew", func() {
+		It("Prehealth check will pass", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *pools),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.KubeletVersionQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.KubeletVersionSkewUnsupported, version, gomock.Any()),
+			)
+			affected, err := KubeletVersionSkew(mockMetricsClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(affected).To(BeNil())
+		})
+	})
+
+	Context("When a kubelet falls outside the supported skew", func() {
+		It("Prehealth check will fail and report the nodes and their pools", func() {
+			nodes.Items = append(nodes.Items,
+				newNode("infra-a", "infra", "v1.24.6+5658434"),
+				newNode("infra-b", "infra", "v1.24.6+5658434"),
+			)
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *pools),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.KubeletVersionQueryFailed, version, gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.KubeletVersionSkewUnsupported, version, gomock.Any()),
+			)
+			affected, err := KubeletVersionSkew(mockMetricsClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(Equal("kubelet versions are outside the supported skew of Kubernetes 1.27: " +
+				"node infra-a (kubelet v1.24.6+5658434, pool infra paused); node infra-b (kubelet v1.24.6+5658434, pool infra paused)"))
+			Expect(affected).To(Equal([]string{"infra-a", "infra", "infra-b"}))
+		})
+	})
+
+	Context("When unable to fetch the nodes", func() {
+		It("Prehealth check will fail", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake cannot fetch nodes")),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.KubeletVersionQueryFailed, version, gomock.Any()),
+			)
+			affected, err := KubeletVersionSkew(mockMetricsClient, mockKubeClient, upgradeConfig, logger, version)
+			Expect(err).Should(HaveOccurred())
+			Expect(affected).To(BeNil())
+		})
+	})
+})
@@ -2,9 +2,7 @@ package upgraders
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/go-logr/logr"
 
@@ -12,11 +10,93 @@ import (
 	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
 	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
+	"github.com/openshift/managed-upgrade-operator/pkg/pagerduty"
 )
 
 type PDBDetails struct {
 	Name      string
 	Namespace string
+	// Reason describes why the PDB blocks node drains
+	Reason string
+}
+
+// HealthCheckName identifies a health check run by the pre-upgrade health check step
+type HealthCheckName string
+
+const (
+	// CriticalAlertsHealthCheck checks for firing critical alerts
+	CriticalAlertsHealthCheck HealthCheckName = "CriticalAlerts"
+	// ClusterOperatorsHealthCheck checks for degraded cluster operators
+	ClusterOperatorsHealthCheck HealthCheckName = "ClusterOperators"
+	// CapacityReservationHealthCheck checks that extra capacity can be reserved for the upgrade
+	CapacityReservationHealthCheck HealthCheckName = "CapacityReservation"
+	// ManuallyCordonedNodesHealthCheck checks for worker nodes cordoned outside of an upgrade
+	ManuallyCordonedNodesHealthCheck HealthCheckName = "NodeUnschedulable"
+	// NodeUnschedulableTaintsHealthCheck checks for nodes under memory, disk or PID pressure
+	NodeUnschedulableTaintsHealthCheck HealthCheckName = "NodeUnschedulableTaint"
+	// PDBHealthCheck checks for PodDisruptionBudgets that would block node drains
+	PDBHealthCheck HealthCheckName = "PDB"
+	// NodeConditionsHealthCheck checks for nodes that are NotReady, unreachable or under resource pressure
+	NodeConditionsHealthCheck HealthCheckName = "NodeConditions"
+	// DrainFeasibilityHealthCheck checks that the pods of each worker node can be rescheduled when it is drained
+	DrainFeasibilityHealthCheck HealthCheckName = "DrainFeasibility"
+	// MachineConfigPoolsHealthCheck checks for degraded MachineConfigPools and configuration rollouts in progress
+	MachineConfigPoolsHealthCheck HealthCheckName = "MachineConfigPools"
+	// CertificatesHealthCheck checks for pending kubelet CSRs and platform certificates close to expiry
+	CertificatesHealthCheck HealthCheckName = "Certificates"
+	// KubeletVersionSkewHealthCheck checks for kubelets outside the supported version skew of a y-stream upgrade
+	KubeletVersionSkewHealthCheck HealthCheckName = "KubeletVersionSkew"
+	// DeprecatedAPIsHealthCheck checks for requests to APIs removed by a y-stream upgrade
+	DeprecatedAPIsHealthCheck HealthCheckName = "DeprecatedAPIs"
+	// OperatorCompatibilityHealthCheck checks for OLM operators incompatible with a y-stream upgrade
+	OperatorCompatibilityHealthCheck HealthCheckName = "OperatorCompatibility"
+	// EtcdHealthCheck checks that etcd can keep quorum while the control plane is upgraded
+	EtcdHealthCheck HealthCheckName = "Etcd"
+)
+
+// HealthCheckResult is the outcome of a single health check run
+type HealthCheckResult struct {
+	// Name of the health check
+	Name HealthCheckName
+	// Passed indicates whether the health check passed
+	Passed bool
+	// AffectedObjects lists the objects that caused the health check to fail
+	AffectedObjects []string
+	// Err holds any error encountered by the health check
+	Err error
+	// MonitoringUnavailable indicates the health check was evaluated without the monitoring stack
+	MonitoringUnavailable bool
+}
+
+// report converts the result into the health check report recorded in the UpgradeConfig status
+func (r HealthCheckResult) report() upgradev1alpha1.HealthCheckReport {
+	report := upgradev1alpha1.HealthCheckReport{
+		Name:            string(r.Name),
+		Result:          upgradev1alpha1.HealthCheckPassed,
+		AffectedObjects: r.AffectedObjects,
+	}
+	if !r.Passed {
+		report.Result = upgradev1alpha1.HealthCheckFailed
+	}
+	if r.Err != nil {
+		report.Message = r.Err.Error()
+	}
+	if r.MonitoringUnavailable {
+		report.MonitoringUnavailable = true
+		if report.Message == "" {
+			report.Message = "alerts could not be evaluated as the monitoring stack is unavailable"
+		}
+	}
+	return report
+}
+
+// healthCheckFunc runs a single health check and reports its result
+type healthCheckFunc func(logger logr.Logger, version string) HealthCheckResult
+
+// healthCheckDefinition pairs a health check with the function that runs it
+type healthCheckDefinition struct {
+	name HealthCheckName
+	run  healthCheckFunc
 }
 
 // PreUpgradeHealthCheck performs cluster healthy check
@@ -35,7 +115,7 @@ func (c *clusterUpgrader) PreUpgradeHealthCheck(ctx context.Context, logger logr
 	// Based on the "PreHealthCheck" featuregate, we invoke the legacy healthchecks for clusteroperator and critical alerts
 	// which will not be tied to the notifications but only log the error and set metric.
 	if !c.config.IsFeatureEnabled(string(upgradev1alpha1.PreHealthCheckFeatureGate)) {
-		ok, err := CriticalAlerts(c.metrics, c.config, c.upgradeConfig, logger, version)
+		ok, err := c.criticalAlertsOrFallback(logger, version)
 		if err != nil || !ok {
 			return false, err
 		}
@@ -48,65 +128,36 @@ func (c *clusterUpgrader) PreUpgradeHealthCheck(ctx context.Context, logger logr
 
 	// We invoke and handle the additional healthchecks accordingly with notifications enabled (or disabled via it's own featuregate)
 	if len(c.config.FeatureGate.Enabled) > 0 && c.config.IsFeatureEnabled(string(upgradev1alpha1.PreHealthCheckFeatureGate)) {
-
-		healthCheckFailed := []string{}
-		var pdbDetails []PDBDetails
 		history := c.upgradeConfig.Status.History.GetHistory(c.upgradeConfig.Spec.Desired.Version)
-		state := string(history.Phase)
+		phase := history.Phase
 
-		ok, err := CriticalAlerts(c.metrics, c.config, c.upgradeConfig, logger, version)
-		if err != nil || !ok {
-			logger.Info("upgrade may delay due to firing critical alerts")
-			healthCheckFailed = append(healthCheckFailed, "CriticalAlertsHealthcheckFailed")
-		}
-
-		ok, err = ClusterOperators(c.metrics, c.cvClient, c.upgradeConfig, logger, version)
-		if err != nil || !ok {
-			logger.Info("upgrade may delay due to cluster operators not ready")
-			healthCheckFailed = append(healthCheckFailed, "ClusterOperatorsHealthcheckFailed")
+		monitoringAvailable := true
+		if c.config.HealthCheck.MonitoringFallback {
+			monitoringAvailable = MonitoringAvailable(c.metrics, c.upgradeConfig, logger, version)
 		}
 
-		if c.upgradeConfig.Spec.CapacityReservation {
-			ok, err := c.scaler.CanScale(c.client, logger)
-			if !ok || err != nil {
-				c.metrics.UpdateMetricHealthcheckFailed(c.upgradeConfig.Name, metrics.DefaultWorkerMachinepoolNotFound, version, state)
-				healthCheckFailed = append(healthCheckFailed, "CapacityReservationHealthcheckFailed")
-			} else {
-				logger.Info("Prehealth check for CapacityReservation passed")
-				c.metrics.UpdateMetricHealthcheckSucceeded(c.upgradeConfig.Name, metrics.DefaultWorkerMachinepoolNotFound, version, state)
+		blocked := false
+		for _, hc := range c.healthChecks(monitoringAvailable) {
+			policy := c.config.HealthCheck.GetPolicy(hc.name, phase)
+			if policy == healthCheckPolicyOff {
+				logger.Info(fmt.Sprintf("Skipping health check %s as it is turned off for phase %s", hc.name, phase))
+				history.HealthChecks.RemoveReport(string(hc.name))
+				continue
 			}
-		}
-		var nodes []string
-		nodes, err = ManuallyCordonedNodes(c.metrics, c.machinery, c.client, c.upgradeConfig, logger, version)
-		if err != nil || nodes != nil {
-			logger.Info(fmt.Sprintf("upgrade may delay due to there are manually cordoned nodes: %s", err))
-			nodeNames := strings.Join(nodes, ",")
-			healthCheckFailed = append(healthCheckFailed, fmt.Sprintf("NodeUnschedulableHealthcheckFailed:(%s)", nodeNames))
-		}
-
-		nodes, err = NodeUnschedulableTaints(c.metrics, c.machinery, c.client, c.upgradeConfig, logger, version)
-		if err != nil || nodes != nil {
-			logger.Info(fmt.Sprintf("upgrade delayed due to there are unschedulable taints on nodes: %s", err))
-			nodeNames := strings.Join(nodes, ",")
-			healthCheckFailed = append(healthCheckFailed, fmt.Sprintf("NodeUnschedulableTaintHealthcheckFailed:(%s)", nodeNames))
-		}
 
-		// HealthCheckPDB
-		pdbDetails, ok, err = HealthCheckPDB(c.metrics, c.client, c.dvo, c.upgradeConfig, logger, version)
-		if err != nil || !ok {
-			logger.Info(fmt.Sprintf("upgrade delayed due PDB %s", err))
-			pdbList, err := json.Marshal(&pdbDetails)
-			if err != nil {
-				logger.Info(fmt.Sprintf("upgrade delayed due PDB: Marshal error %s", err))
+			result := hc.run(logger, version)
+			history.HealthChecks.SetReport(result.report())
+			if !result.Passed && policy == healthCheckPolicyEnforce {
+				blocked = true
 			}
-			healthCheckFailed = append(healthCheckFailed, "PDBHealthcheckFailed: "+string(pdbList))
 		}
+		c.upgradeConfig.Status.History.SetHistory(*history)
 
-		if len(healthCheckFailed) > 0 {
-			result := strings.Join(healthCheckFailed, ",")
+		if len(history.HealthChecks.GetFailed()) > 0 {
+			result := history.HealthChecks.FailureSummary()
 			logger.Info(fmt.Sprintf("Upgrade may delay due to following PreHealthCheck failure: %s", result))
 
-			switch history := c.upgradeConfig.Status.History.GetHistory(c.upgradeConfig.Spec.Desired.Version); history.Phase {
+			switch phase {
 			case upgradev1alpha1.UpgradePhaseNew:
 				err := c.notifier.NotifyResult(notifier.MuoStatePreHealthCheckSL, result)
 				if err != nil {
@@ -117,44 +168,243 @@ func (c *clusterUpgrader) PreUpgradeHealthCheck(ctx context.Context, logger logr
 				if err != nil {
 					return false, err
 				}
-
-				// Return false if the healthCheckFailed slice contains "CriticalAlertsHealthcheckFailed" or "ClusterOperatorsHealthcheckFailed"
-				for _, healthcheck := range healthCheckFailed {
-					if healthcheck == "CriticalAlertsHealthcheckFailed" || healthcheck == "ClusterOperatorsHealthcheckFailed" {
-						return false, nil
-					}
-				}
-				return true, nil
-			case " ":
-				logger.Info(fmt.Sprintf("upgradeconfig history doesn't exist for version: %s", c.upgradeConfig.Spec.Desired.Version))
+			default:
+				logger.Info(fmt.Sprintf("Skipping health check notification for upgrade phase %s", phase))
 			}
-			return false, nil
+			return !blocked, nil
 		}
 	}
 	return true, nil
 }
 
+// healthChecks returns the ordered list of health checks run by the pre-upgrade health check.
+// When the monitoring stack is unavailable, the checks relying on it are evaluated through the Kubernetes API.
+func (c *clusterUpgrader) healthChecks(monitoringAvailable bool) []healthCheckDefinition {
+	criticalAlerts, etcd := c.checkCriticalAlerts, c.checkEtcd
+	if !monitoringAvailable {
+		criticalAlerts, etcd = c.checkKubernetesHealth, c.checkEtcdMembers
+	}
+	return []healthCheckDefinition{
+		{name: CriticalAlertsHealthCheck, run: criticalAlerts},
+		{name: ClusterOperatorsHealthCheck, run: c.checkClusterOperators},
+		{name: CapacityReservationHealthCheck, run: c.checkCapacityReservation},
+		{name: ManuallyCordonedNodesHealthCheck, run: c.checkManuallyCordonedNodes},
+		{name: NodeUnschedulableTaintsHealthCheck, run: c.checkNodeUnschedulableTaints},
+		{name: PDBHealthCheck, run: c.checkPDB},
+		{name: NodeConditionsHealthCheck, run: c.checkNodeConditions},
+		{name: DrainFeasibilityHealthCheck, run: c.checkDrainFeasibility},
+		{name: MachineConfigPoolsHealthCheck, run: c.checkMachineConfigPools},
+		{name: CertificatesHealthCheck, run: c.checkCertificates},
+		{name: KubeletVersionSkewHealthCheck, run: c.checkKubeletVersionSkew},
+		{name: DeprecatedAPIsHealthCheck, run: c.checkDeprecatedAPIs},
+		{name: OperatorCompatibilityHealthCheck, run: c.checkOperatorCompatibility},
+		{name: EtcdHealthCheck, run: etcd},
+	}
+}
+
+func (c *clusterUpgrader) checkCriticalAlerts(logger logr.Logger, version string) HealthCheckResult {
+	ok, err := CriticalAlerts(c.metrics, c.config, c.upgradeConfig, logger, version)
+	if err != nil || !ok {
+		logger.Info("upgrade may delay due to firing critical alerts")
+	}
+	return HealthCheckResult{Name: CriticalAlertsHealthCheck, Passed: err == nil && ok, Err: err}
+}
+
+func (c *clusterUpgrader) checkKubernetesHealth(logger logr.Logger, version string) HealthCheckResult {
+	affected, err := KubernetesHealth(c.metrics, c.cvClient, c.machinery, c.client, c.upgradeConfig, logger, version)
+	if err != nil {
+		logger.Info(fmt.Sprintf("upgrade may delay due to an unhealthy cluster: %s", err))
+	}
+	return HealthCheckResult{Name: CriticalAlertsHealthCheck, Passed: err == nil, AffectedObjects: affected, Err: err, MonitoringUnavailable: true}
+}
+
+func (c *clusterUpgrader) checkClusterOperators(logger logr.Logger, version string) HealthCheckResult {
+	ok, err := ClusterOperators(c.metrics, c.cvClient, c.upgradeConfig, logger, version)
+	if err != nil || !ok {
+		logger.Info("upgrade may delay due to cluster operators not ready")
+	}
+	return HealthCheckResult{Name: ClusterOperatorsHealthCheck, Passed: err == nil && ok, Err: err}
+}
+
+func (c *clusterUpgrader) checkCapacityReservation(logger logr.Logger, version string) HealthCheckResult {
+	result := HealthCheckResult{Name: CapacityReservationHealthCheck, Passed: true}
+	if !c.upgradeConfig.Spec.CapacityReservation {
+		return result
+	}
+
+	state := string(c.upgradeConfig.Status.History.GetHistory(c.upgradeConfig.Spec.Desired.Version).Phase)
+	ok, err := c.scaler.CanScale(c.client, logger)
+	if !ok || err != nil {
+		c.metrics.UpdateMetricHealthcheckFailed(c.upgradeConfig.Name, metrics.DefaultWorkerMachinepoolNotFound, version, state)
+		result.Passed = false
+		result.Err = err
+		return result
+	}
+	logger.Info("Prehealth check for CapacityReservation passed")
+	c.metrics.UpdateMetricHealthcheckSucceeded(c.upgradeConfig.Name, metrics.DefaultWorkerMachinepoolNotFound, version, state)
+	return result
+}
+
+func (c *clusterUpgrader) checkManuallyCordonedNodes(logger logr.Logger, version string) HealthCheckResult {
+	nodes, err := ManuallyCordonedNodes(c.metrics, c.machinery, c.client, c.upgradeConfig, logger, version)
+	if err != nil || nodes != nil {
+		logger.Info(fmt.Sprintf("upgrade may delay due to there are manually cordoned nodes: %s", err))
+	}
+	return HealthCheckResult{Name: ManuallyCordonedNodesHealthCheck, Passed: err == nil && nodes == nil, AffectedObjects: nodes, Err: err}
+}
+
+func (c *clusterUpgrader) checkNodeUnschedulableTaints(logger logr.Logger, version string) HealthCheckResult {
+	nodes, err := NodeUnschedulableTaints(c.metrics, c.machinery, c.client, c.upgradeConfig, logger, version)
+	if err != nil || nodes != nil {
+		logger.Info(fmt.Sprintf("upgrade delayed due to there are unschedulable taints on nodes: %s", err))
+	}
+	return HealthCheckResult{Name: NodeUnschedulableTaintsHealthCheck, Passed: err == nil && nodes == nil, AffectedObjects: nodes, Err: err}
+}
+
+func (c *clusterUpgrader) checkPDB(logger logr.Logger, version string) HealthCheckResult {
+	pdbDetails, ok, err := HealthCheckPDB(c.metrics, c.client, c.dvo, c.upgradeConfig, logger, version)
+	if err != nil || !ok {
+		logger.Info(fmt.Sprintf("upgrade delayed due PDB %s", err))
+	}
+	var pdbs []string
+	for _, pdb := range pdbDetails {
+		pdbs = append(pdbs, pdb.Namespace+"/"+pdb.Name)
+	}
+	return HealthCheckResult{Name: PDBHealthCheck, Passed: err == nil && ok, AffectedObjects: pdbs, Err: err}
+}
+
+func (c *clusterUpgrader) checkNodeConditions(logger logr.Logger, version string) HealthCheckResult {
+	nodes, err := NodeConditions(c.metrics, c.machinery, c.client, c.upgradeConfig, logger, version)
+	if err != nil {
+		logger.Info(fmt.Sprintf("upgrade may delay due to unhealthy node conditions: %s", err))
+	}
+	return HealthCheckResult{Name: NodeConditionsHealthCheck, Passed: err == nil, AffectedObjects: nodes, Err: err}
+}
+
+func (c *clusterUpgrader) checkDrainFeasibility(logger logr.Logger, version string) HealthCheckResult {
+	workloads, err := DrainFeasibility(c.metrics, c.client, c.config, c.upgradeConfig, logger, version)
+	if err != nil {
+		logger.Info(fmt.Sprintf("upgrade may delay due to workloads that cannot be rescheduled: %s", err))
+	}
+	return HealthCheckResult{Name: DrainFeasibilityHealthCheck, Passed: err == nil, AffectedObjects: workloads, Err: err}
+}
+
+func (c *clusterUpgrader) checkMachineConfigPools(logger logr.Logger, version string) HealthCheckResult {
+	affected, err := MachineConfigPools(c.metrics, c.machinery, c.client, c.upgradeConfig, logger, version)
+	if err != nil {
+		logger.Info(fmt.Sprintf("upgrade may delay due to MachineConfigPools that are not settled: %s", err))
+	}
+	return HealthCheckResult{Name: MachineConfigPoolsHealthCheck, Passed: err == nil, AffectedObjects: affected, Err: err}
+}
+
+func (c *clusterUpgrader) checkCertificates(logger logr.Logger, version string) HealthCheckResult {
+	affected, err := Certificates(c.metrics, c.client, c.config, c.upgradeConfig, logger, version)
+	if err != nil {
+		logger.Info(fmt.Sprintf("upgrade may delay due to certificates at risk: %s", err))
+	}
+	return HealthCheckResult{Name: CertificatesHealthCheck, Passed: err == nil, AffectedObjects: affected, Err: err}
+}
+
+func (c *clusterUpgrader) checkKubeletVersionSkew(logger logr.Logger, version string) HealthCheckResult {
+	affected, err := KubeletVersionSkew(c.metrics, c.client, c.upgradeConfig, logger, version)
+	if err != nil {
+		logger.Info(fmt.Sprintf("upgrade may delay due to kubelets outside the supported version skew: %s", err))
+	}
+	return HealthCheckResult{Name: KubeletVersionSkewHealthCheck, Passed: err == nil, AffectedObjects: affected, Err: err}
+}
+
+func (c *clusterUpgrader) checkDeprecatedAPIs(logger logr.Logger, version string) HealthCheckResult {
+	apis, err := DeprecatedAPIs(c.metrics, c.client, c.upgradeConfig, logger, version)
+	if err != nil {
+		logger.Info(fmt.Sprintf("upgrade may delay due to removed APIs still in use: %s", err))
+	}
+	result := HealthCheckResult{Name: DeprecatedAPIsHealthCheck, Passed: err == nil, AffectedObjects: apis, Err: err}
+
+	// OpenShift holds y-stream upgrades until the API removals are acknowledged. They are acknowledged
+	// when no removed API is in use, or, if opted in, when the check is not enforced and the upgrade
+	// may go ahead regardless.
+	acknowledge := err == nil && len(apis) == 0
+	if !acknowledge && len(apis) > 0 && c.config.HealthCheck.AcknowledgeAPIRemovalsInUse {
+		phase := c.upgradeConfig.Status.History.GetHistory(c.upgradeConfig.Spec.Desired.Version).Phase
+		acknowledge = c.config.HealthCheck.GetPolicy(DeprecatedAPIsHealthCheck, phase) != healthCheckPolicyEnforce
+	}
+	if acknowledge {
+		if ackErr := AcknowledgeAPIRemovals(c.client, c.upgradeConfig, logger, version); ackErr != nil {
+			logger.Info(fmt.Sprintf("unable to acknowledge API removals: %s", ackErr))
+			result.Passed = false
+			result.Err = ackErr
+		}
+	}
+	return result
+}
+
+func (c *clusterUpgrader) checkOperatorCompatibility(logger logr.Logger, version string) HealthCheckResult {
+	operators, err := OperatorCompatibility(c.metrics, c.client, c.upgradeConfig, logger, version)
+	if err != nil {
+		logger.Info(fmt.Sprintf("upgrade may delay due to operators incompatible with the desired version: %s", err))
+	}
+	return HealthCheckResult{Name: OperatorCompatibilityHealthCheck, Passed: err == nil, AffectedObjects: operators, Err: err}
+}
+
+func (c *clusterUpgrader) checkEtcd(logger logr.Logger, version string) HealthCheckResult {
+	affected, err := EtcdHealth(c.metrics, c.client, c.upgradeConfig, logger, version)
+	if err != nil {
+		logger.Info(fmt.Sprintf("upgrade may delay due to etcd being unhealthy: %s", err))
+	}
+	return HealthCheckResult{Name: EtcdHealthCheck, Passed: err == nil, AffectedObjects: affected, Err: err}
+}
+
+func (c *clusterUpgrader) checkEtcdMembers(logger logr.Logger, version string) HealthCheckResult {
+	affected, err := EtcdMemberHealth(c.metrics, c.client, c.upgradeConfig, logger, version)
+	if err != nil {
+		logger.Info(fmt.Sprintf("upgrade may delay due to etcd being unhealthy: %s", err))
+	}
+	return HealthCheckResult{Name: EtcdHealthCheck, Passed: err == nil, AffectedObjects: affected, Err: err, MonitoringUnavailable: true}
+}
+
 // PostUpgradeHealthCheck performs cluster healthy check
 func (c *clusterUpgrader) PostUpgradeHealthCheck(ctx context.Context, logger logr.Logger) (bool, error) {
 	version := getCurrentVersion(c.cvClient, logger)
-	ok, err := CriticalAlerts(c.metrics, c.config, c.upgradeConfig, logger, version)
+	ok, err := c.criticalAlertsOrFallback(logger, version)
 	if err != nil || !ok {
+		c.triggerIncident(pagerduty.IncidentPostUpgradeHealthCheckFailed, "", postUpgradeHealthCheckSummary(c.upgradeConfig.Spec.Desired.Version, "critical alerts are firing", err), logger)
 		return false, err
 	}
 
 	ok, err = ClusterOperators(c.metrics, c.cvClient, c.upgradeConfig, logger, version)
 	if err != nil || !ok {
+		c.triggerIncident(pagerduty.IncidentPostUpgradeHealthCheckFailed, "", postUpgradeHealthCheckSummary(c.upgradeConfig.Spec.Desired.Version, "cluster operators are degraded", err), logger)
 		return false, err
 	}
+	c.resolveIncident(pagerduty.IncidentPostUpgradeHealthCheckFailed, "", logger)
 
 	// Reset all node drain metrics after successful upgrade to prevent stale alerts
 	// This ensures any metrics from deleted/replaced nodes during upgrade are cleared
 	logger.Info("PostUpgradeHealthCheck passed, resetting all node drain metrics")
 	c.metrics.ResetAllMetricNodeDrainFailed()
+	c.resolveAllIncidents(pagerduty.IncidentNodeDrainFailed, logger)
 
 	return true, nil
 }
 
+// criticalAlertsOrFallback checks the critical alerts, or the cluster health through the Kubernetes API
+// when the monitoring fallback is enabled and the monitoring stack is unavailable
+func (c *clusterUpgrader) criticalAlertsOrFallback(logger logr.Logger, version string) (bool, error) {
+	if c.config.HealthCheck.MonitoringFallback && !MonitoringAvailable(c.metrics, c.upgradeConfig, logger, version) {
+		_, err := KubernetesHealth(c.metrics, c.cvClient, c.machinery, c.client, c.upgradeConfig, logger, version)
+		return err == nil, err
+	}
+	return CriticalAlerts(c.metrics, c.config, c.upgradeConfig, logger, version)
+}
+
+// postUpgradeHealthCheckSummary returns the summary of the incident of a failing post-upgrade health check
+func postUpgradeHealthCheckSummary(version string, failure string, err error) string {
+	if err != nil {
+		return fmt.Sprintf("Post-upgrade health check of %s failed: %v", version, err)
+	}
+	return fmt.Sprintf("Post-upgrade health check of %s failed: %s", version, failure)
+}
+
 func getCurrentVersion(cvClient cv.ClusterVersion, logger logr.Logger) string {
 
 	clusterVersion, err := cvClient.GetClusterVersion()
@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/go-logr/logr"
+	apiserverv1 "github.com/openshift/api/apiserver/v1"
 	v1 "github.com/openshift/api/config/v1"
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
@@ -30,6 +31,7 @@ import (
 	mockMaintenance "github.com/openshift/managed-upgrade-operator/pkg/maintenance/mocks"
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
 	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
 	mockScaler "github.com/openshift/managed-upgrade-operator/pkg/scaler/mocks"
 	"github.com/openshift/managed-upgrade-operator/util/mocks"
 	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
@@ -84,6 +86,18 @@ var _ = Describe("HealthCheck Step", func() {
 		config.HealthCheck = healthCheck{
 			IgnoredCriticals:  []string{"alert1", "alert2"},
 			IgnoredNamespaces: []string{"ns1"},
+			// The node conditions, drain feasibility, MachineConfigPool, certificates, kubelet version skew,
+			// deprecated API, operator compatibility and etcd health checks are covered by their own tests
+			Policies: map[HealthCheckName]healthCheckPhasePolicy{
+				NodeConditionsHealthCheck:        {New: healthCheckPolicyOff, Upgrading: healthCheckPolicyOff},
+				DrainFeasibilityHealthCheck:      {New: healthCheckPolicyOff, Upgrading: healthCheckPolicyOff},
+				MachineConfigPoolsHealthCheck:    {New: healthCheckPolicyOff, Upgrading: healthCheckPolicyOff},
+				CertificatesHealthCheck:          {New: healthCheckPolicyOff, Upgrading: healthCheckPolicyOff},
+				KubeletVersionSkewHealthCheck:    {New: healthCheckPolicyOff, Upgrading: healthCheckPolicyOff},
+				DeprecatedAPIsHealthCheck:        {New: healthCheckPolicyOff, Upgrading: healthCheckPolicyOff},
+				OperatorCompatibilityHealthCheck: {New: healthCheckPolicyOff, Upgrading: healthCheckPolicyOff},
+				EtcdHealthCheck:                  {New: healthCheckPolicyOff, Upgrading: healthCheckPolicyOff},
+			},
 		}
 		upgrader = &clusterUpgrader{
 			client:               mockKubeClient,
@@ -1212,4 +1226,421 @@ var _ = Describe("HealthCheck Step", func() {
 		})
 
 	})
+
+	Context("When health check policies are configured", func() {
+		var alertsResponse *metrics.AlertResponse
+		nodes := &corev1.NodeList{
+			Items: []corev1.Node{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "testNode"},
+				},
+			},
+		}
+		pdb := &policyv1.PodDisruptionBudgetList{}
+		var cordonAddedTime *metav1.Time
+
+		JustBeforeEach(func() {
+			alertsResponse = &metrics.AlertResponse{}
+		})
+
+		It("will notify but satisfy a pre-Upgrade health check when the failing check is set to warn", func() {
+			config.HealthCheck.Policies[ManuallyCordonedNodesHealthCheck] = healthCheckPhasePolicy{New: healthCheckPolicyWarn}
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+				mockCVClient.EXPECT().GetClusterVersion().Return(mockClusterVersion, nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(alertsResponse, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.MetricsQueryFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.CriticalAlertsFiring, gomock.Any(), gomock.Any()),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsStatusFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsDegraded, gomock.Any(), gomock.Any()),
+				mockScalerClient.EXPECT().CanScale(gomock.Any(), logger).Return(true, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DefaultWorkerMachinepoolNotFound, gomock.Any(), gomock.Any()),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: cordonAddedTime}),
+				mockMachineryClient.EXPECT().IsNodeUpgrading(gomock.Any()).Return(false),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.ClusterNodesManuallyCordoned, gomock.Any(), gomock.Any()),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockMachineryClient.EXPECT().HasMemoryPressure(gomock.Any()).Return(false),
+				mockMachineryClient.EXPECT().HasDiskPressure(gomock.Any()).Return(false),
+				mockMachineryClient.EXPECT().HasPidPressure(gomock.Any()).Return(false),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodeQueryFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodesTaintedUnschedulable, gomock.Any(), gomock.Any()),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *pdb),
+				mockdvobuilderclient.EXPECT().New(gomock.Any()).Return(mockdvoclient, nil),
+				mockdvoclient.EXPECT().GetMetrics().Return([]byte{}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterInvalidPDB, gomock.Any(), gomock.Any()),
+				mockEMClient.EXPECT().NotifyResult(notifier.MuoStatePreHealthCheckSL, "NodeUnschedulableHealthcheckFailed:(testNode)").Return(nil),
+			)
+			result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
+			Expect(err).To(BeNil())
+			Expect(result).To(BeTrue())
+
+			history := upgrader.upgradeConfig.Status.History.GetHistory(upgrader.upgradeConfig.Spec.Desired.Version)
+			Expect(history.HealthChecks).To(HaveLen(6))
+			report := history.HealthChecks.GetReport(string(ManuallyCordonedNodesHealthCheck))
+			Expect(report).NotTo(BeNil())
+			Expect(report.Result).To(Equal(upgradev1alpha1.HealthCheckFailed))
+			Expect(report.AffectedObjects).To(Equal([]string{"testNode"}))
+			Expect(report.FirstSeen).NotTo(BeNil())
+			Expect(report.LastSeen).NotTo(BeNil())
+			Expect(history.HealthChecks.GetReport(string(PDBHealthCheck)).Result).To(Equal(upgradev1alpha1.HealthCheckPassed))
+		})
+
+		It("will record the failures the upgrade reminders report on the UpgradeConfig it is given when scheduling the upgrade", func() {
+			config.HealthCheck.Policies[ManuallyCordonedNodesHealthCheck] = healthCheckPhasePolicy{New: healthCheckPolicyWarn}
+			scheduled := testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseNew).GetUpgradeConfig()
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+				mockCVClient.EXPECT().GetClusterVersion().Return(mockClusterVersion, nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(alertsResponse, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.MetricsQueryFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.CriticalAlertsFiring, gomock.Any(), gomock.Any()),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsStatusFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsDegraded, gomock.Any(), gomock.Any()),
+				mockScalerClient.EXPECT().CanScale(gomock.Any(), logger).Return(true, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DefaultWorkerMachinepoolNotFound, gomock.Any(), gomock.Any()),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: cordonAddedTime}),
+				mockMachineryClient.EXPECT().IsNodeUpgrading(gomock.Any()).Return(false),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.ClusterNodesManuallyCordoned, gomock.Any(), gomock.Any()),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockMachineryClient.EXPECT().HasMemoryPressure(gomock.Any()).Return(false),
+				mockMachineryClient.EXPECT().HasDiskPressure(gomock.Any()).Return(false),
+				mockMachineryClient.EXPECT().HasPidPressure(gomock.Any()).Return(false),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodeQueryFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodesTaintedUnschedulable, gomock.Any(), gomock.Any()),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *pdb),
+				mockdvobuilderclient.EXPECT().New(gomock.Any()).Return(mockdvoclient, nil),
+				mockdvoclient.EXPECT().GetMetrics().Return([]byte{}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterInvalidPDB, gomock.Any(), gomock.Any()),
+				mockEMClient.EXPECT().NotifyResult(notifier.MuoStatePreHealthCheckSL, "NodeUnschedulableHealthcheckFailed:(testNode)").Return(nil),
+			)
+			result, err := (&osdUpgrader{clusterUpgrader: upgrader}).HealthCheck(context.TODO(), scheduled, logger)
+			Expect(err).To(BeNil())
+			Expect(result).To(BeTrue())
+
+			history := scheduled.Status.History.GetHistory(scheduled.Spec.Desired.Version)
+			Expect(history.HealthChecks.GetFailed()).To(HaveLen(1))
+			Expect(history.HealthChecks.FailureSummary()).To(Equal("NodeUnschedulableHealthcheckFailed:(testNode)"))
+		})
+
+		It("will skip the checks that are turned off", func() {
+			config.HealthCheck.Policies[ManuallyCordonedNodesHealthCheck] = healthCheckPhasePolicy{New: healthCheckPolicyOff}
+			config.HealthCheck.Policies[NodeUnschedulableTaintsHealthCheck] = healthCheckPhasePolicy{New: healthCheckPolicyOff}
+			config.HealthCheck.Policies[PDBHealthCheck] = healthCheckPhasePolicy{New: healthCheckPolicyOff}
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+				mockCVClient.EXPECT().GetClusterVersion().Return(mockClusterVersion, nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(alertsResponse, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.MetricsQueryFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.CriticalAlertsFiring, gomock.Any(), gomock.Any()),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsStatusFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsDegraded, gomock.Any(), gomock.Any()),
+				mockScalerClient.EXPECT().CanScale(gomock.Any(), logger).Return(true, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DefaultWorkerMachinepoolNotFound, gomock.Any(), gomock.Any()),
+			)
+			result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
+			Expect(err).To(BeNil())
+			Expect(result).To(BeTrue())
+
+			history := upgrader.upgradeConfig.Status.History.GetHistory(upgrader.upgradeConfig.Spec.Desired.Version)
+			Expect(history.HealthChecks).To(HaveLen(3))
+			Expect(history.HealthChecks.GetReport(string(PDBHealthCheck))).To(BeNil())
+		})
+
+		It("will not satisfy a pre-Upgrade health check in the upgrade phase when the failing check is enforced", func() {
+			upgrader.upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseUpgrading).GetUpgradeConfig()
+			config.HealthCheck.Policies[ManuallyCordonedNodesHealthCheck] = healthCheckPhasePolicy{Upgrading: healthCheckPolicyEnforce}
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+				mockCVClient.EXPECT().GetClusterVersion().Return(mockClusterVersion, nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(alertsResponse, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.MetricsQueryFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.CriticalAlertsFiring, gomock.Any(), gomock.Any()),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsStatusFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsDegraded, gomock.Any(), gomock.Any()),
+				mockScalerClient.EXPECT().CanScale(gomock.Any(), logger).Return(true, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DefaultWorkerMachinepoolNotFound, gomock.Any(), gomock.Any()),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: cordonAddedTime}),
+				mockMachineryClient.EXPECT().IsNodeUpgrading(gomock.Any()).Return(false),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.ClusterNodesManuallyCordoned, gomock.Any(), gomock.Any()),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockMachineryClient.EXPECT().HasMemoryPressure(gomock.Any()).Return(false),
+				mockMachineryClient.EXPECT().HasDiskPressure(gomock.Any()).Return(false),
+				mockMachineryClient.EXPECT().HasPidPressure(gomock.Any()).Return(false),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodeQueryFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodesTaintedUnschedulable, gomock.Any(), gomock.Any()),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *pdb),
+				mockdvobuilderclient.EXPECT().New(gomock.Any()).Return(mockdvoclient, nil),
+				mockdvoclient.EXPECT().GetMetrics().Return([]byte{}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterInvalidPDB, gomock.Any(), gomock.Any()),
+				mockEMClient.EXPECT().NotifyResult(notifier.MuoStateHealthCheckSL, gomock.Any()).Return(nil),
+			)
+			result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
+			Expect(err).To(BeNil())
+			Expect(result).To(BeFalse())
+		})
+
+		It("will notify with the unhealthy nodes but satisfy a pre-Upgrade health check in the upgrade phase", func() {
+			upgrader.upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseUpgrading).GetUpgradeConfig()
+			for name := range defaultHealthCheckPolicies {
+				config.HealthCheck.Policies[name] = healthCheckPhasePolicy{Upgrading: healthCheckPolicyOff}
+			}
+			delete(config.HealthCheck.Policies, NodeConditionsHealthCheck)
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+				mockCVClient.EXPECT().GetClusterVersion().Return(mockClusterVersion, nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockMachineryClient.EXPECT().GetNodeConditions(gomock.Any()).Return(&machinery.NodeConditionsResult{IsUnknown: true}),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.ClusterNodesUnhealthyConditions, gomock.Any(), gomock.Any()),
+				mockEMClient.EXPECT().NotifyResult(notifier.MuoStateHealthCheckSL, "NodeConditionsHealthcheckFailed:(testNode)").Return(nil),
+			)
+			result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
+			Expect(err).To(BeNil())
+			Expect(result).To(BeTrue())
+		})
+
+		It("will not satisfy a pre-Upgrade health check in the upgrade phase when etcd quorum is at risk", func() {
+			upgrader.upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseUpgrading).GetUpgradeConfig()
+			for name := range defaultHealthCheckPolicies {
+				config.HealthCheck.Policies[name] = healthCheckPhasePolicy{Upgrading: healthCheckPolicyOff}
+			}
+			delete(config.HealthCheck.Policies, EtcdHealthCheck)
+			etcdPods := &corev1.PodList{
+				Items: []corev1.Pod{
+					{ObjectMeta: metav1.ObjectMeta{Name: "etcd-master-0", Namespace: "openshift-etcd"}, Spec: corev1.PodSpec{NodeName: "master-0"}},
+				},
+			}
+			etcdMasters := &corev1.NodeList{
+				Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "master-0"}}},
+			}
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+				mockCVClient.EXPECT().GetClusterVersion().Return(mockClusterVersion, nil),
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *etcdPods),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *etcdMasters),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(alertsResponse, nil).Times(1+len(etcdSignals)),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.EtcdQueryFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.EtcdUnhealthy, gomock.Any(), gomock.Any()),
+				mockEMClient.EXPECT().NotifyResult(notifier.MuoStateHealthCheckSL, "EtcdHealthcheckFailed:(openshift-etcd/etcd-master-0)").Return(nil),
+			)
+			result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
+			Expect(err).To(BeNil())
+			Expect(result).To(BeFalse())
+
+			history := upgrader.upgradeConfig.Status.History.GetHistory(upgrader.upgradeConfig.Spec.Desired.Version)
+			Expect(history.HealthChecks).To(HaveLen(1))
+			Expect(history.HealthChecks.GetReport(string(EtcdHealthCheck)).IsFailed()).To(BeTrue())
+		})
+
+		It("will evaluate the cluster through the Kubernetes API when the monitoring stack is unavailable", func() {
+			upgrader.upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseUpgrading).GetUpgradeConfig()
+			config.HealthCheck.MonitoringFallback = true
+			for name := range defaultHealthCheckPolicies {
+				config.HealthCheck.Policies[name] = healthCheckPhasePolicy{Upgrading: healthCheckPolicyOff}
+			}
+			delete(config.HealthCheck.Policies, CriticalAlertsHealthCheck)
+			delete(config.HealthCheck.Policies, EtcdHealthCheck)
+			etcdPods := &corev1.PodList{
+				Items: []corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "etcd-master-0", Namespace: "openshift-etcd"},
+						Spec:       corev1.PodSpec{NodeName: "master-0"},
+						Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}},
+					},
+				},
+			}
+			etcdMasters := &corev1.NodeList{
+				Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "master-0"}}},
+			}
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+				mockCVClient.EXPECT().GetClusterVersion().Return(mockClusterVersion, nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(nil, fmt.Errorf("fake prometheus unavailable")),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.MonitoringUnavailable, gomock.Any(), gomock.Any()),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockMachineryClient.EXPECT().GetNodeConditions(gomock.Any()).Return(&machinery.NodeConditionsResult{IsReady: true}),
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *etcdPods),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *etcdMasters),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.FallbackQueryFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.FallbackClusterUnhealthy, gomock.Any(), gomock.Any()),
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *etcdPods),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *etcdMasters),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.EtcdQueryFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.EtcdUnhealthy, gomock.Any(), gomock.Any()),
+			)
+			result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
+			Expect(err).To(BeNil())
+			Expect(result).To(BeTrue())
+
+			history := upgrader.upgradeConfig.Status.History.GetHistory(upgrader.upgradeConfig.Spec.Desired.Version)
+			Expect(history.HealthChecks).To(HaveLen(2))
+			for _, name := range []HealthCheckName{CriticalAlertsHealthCheck, EtcdHealthCheck} {
+				report := history.HealthChecks.GetReport(string(name))
+				Expect(report.Result).To(Equal(upgradev1alpha1.HealthCheckPassed))
+				Expect(report.MonitoringUnavailable).To(BeTrue())
+				Expect(report.Message).To(Equal("alerts could not be evaluated as the monitoring stack is unavailable"))
+			}
+		})
+	})
+
+	Context("When the monitoring stack is unavailable after the upgrade", func() {
+		nodes := &corev1.NodeList{
+			Items: []corev1.Node{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "testNode"},
+				},
+			},
+		}
+		etcdPods := &corev1.PodList{
+			Items: []corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "etcd-master-0", Namespace: "openshift-etcd"},
+					Spec:       corev1.PodSpec{NodeName: "master-0"},
+					Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}},
+				},
+			},
+		}
+		etcdMasters := &corev1.NodeList{
+			Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "master-0"}}},
+		}
+
+		It("will fail a post-upgrade health check without the monitoring fallback", func() {
+			gomock.InOrder(
+				mockCVClient.EXPECT().GetClusterVersion().Return(mockClusterVersion, nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(nil, fmt.Errorf("fake prometheus unavailable")),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.MetricsQueryFailed, gomock.Any(), gomock.Any()),
+			)
+			result, err := upgrader.PostUpgradeHealthCheck(context.TODO(), logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeFalse())
+		})
+
+		It("will satisfy a post-upgrade health check through the Kubernetes API with the monitoring fallback", func() {
+			config.HealthCheck.MonitoringFallback = true
+			gomock.InOrder(
+				mockCVClient.EXPECT().GetClusterVersion().Return(mockClusterVersion, nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(nil, fmt.Errorf("fake prometheus unavailable")),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.MonitoringUnavailable, gomock.Any(), gomock.Any()),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockMachineryClient.EXPECT().GetNodeConditions(gomock.Any()).Return(&machinery.NodeConditionsResult{IsReady: true}),
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *etcdPods),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *etcdMasters),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.FallbackQueryFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.FallbackClusterUnhealthy, gomock.Any(), gomock.Any()),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsStatusFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsDegraded, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().ResetAllMetricNodeDrainFailed(),
+			)
+			result, err := upgrader.PostUpgradeHealthCheck(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+
+		It("will not satisfy a post-upgrade health check when the Kubernetes API reports an unhealthy cluster", func() {
+			config.HealthCheck.MonitoringFallback = true
+			gomock.InOrder(
+				mockCVClient.EXPECT().GetClusterVersion().Return(mockClusterVersion, nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(nil, fmt.Errorf("fake prometheus unavailable")),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.MonitoringUnavailable, gomock.Any(), gomock.Any()),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{"dns"}}, nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockMachineryClient.EXPECT().GetNodeConditions(gomock.Any()).Return(&machinery.NodeConditionsResult{IsReady: true}),
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *etcdPods),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *etcdMasters),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.FallbackQueryFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.FallbackClusterUnhealthy, gomock.Any(), gomock.Any()),
+			)
+			result, err := upgrader.PostUpgradeHealthCheck(context.TODO(), logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeFalse())
+		})
+
+		It("will evaluate a legacy pre-upgrade health check through the Kubernetes API with the monitoring fallback", func() {
+			config.HealthCheck.MonitoringFallback = true
+			config.FeatureGate.Enabled = nil
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+				mockCVClient.EXPECT().GetClusterVersion().Return(mockClusterVersion, nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(nil, fmt.Errorf("fake prometheus unavailable")),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.MonitoringUnavailable, gomock.Any(), gomock.Any()),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockMachineryClient.EXPECT().GetNodeConditions(gomock.Any()).Return(&machinery.NodeConditionsResult{IsReady: true}),
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *etcdPods),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *etcdMasters),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.FallbackQueryFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.FallbackClusterUnhealthy, gomock.Any(), gomock.Any()),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsStatusFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsDegraded, gomock.Any(), gomock.Any()),
+			)
+			result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+	})
+
+	Context("When removed APIs are still in use during the upgrade", func() {
+		var requestCounts *apiserverv1.APIRequestCountList
+
+		BeforeEach(func() {
+			upgradeConfig.Spec.Desired.Version = "4.13.1"
+			upgradeConfig.Status.History[0].Version = "4.13.1"
+			upgradeConfig.Status.History[0].Phase = upgradev1alpha1.UpgradePhaseUpgrading
+			requestCounts = &apiserverv1.APIRequestCountList{
+				Items: []apiserverv1.APIRequestCount{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "horizontalpodautoscalers.v2beta2.autoscaling"},
+						Status: apiserverv1.APIRequestCountStatus{
+							RemovedInRelease: "1.26",
+							RequestCount:     120,
+							Last24h:          []apiserverv1.PerResourceAPIRequestLog{{RequestCount: 120}},
+						},
+					},
+				},
+			}
+		})
+
+		It("will not acknowledge the API removals when the failing check is set to warn", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *requestCounts),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DeprecatedAPIQueryFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.DeprecatedAPIsInUse, gomock.Any(), gomock.Any()),
+			)
+			result := upgrader.checkDeprecatedAPIs(logger, "4.12.20")
+			Expect(result.Passed).To(BeFalse())
+			Expect(result.AffectedObjects).To(Equal([]string{"horizontalpodautoscalers.v2beta2.autoscaling"}))
+		})
+
+		It("will acknowledge the API removals when the failing check is set to warn and acknowledging them is opted in", func() {
+			config.HealthCheck.AcknowledgeAPIRemovalsInUse = true
+			gates := &corev1.ConfigMap{Data: map[string]string{"ack-4.12-kube-1.26-api-removals-in-4.13": "Several beta APIs are removed in 4.13"}}
+			acks := &corev1.ConfigMap{}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *requestCounts),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DeprecatedAPIQueryFailed, gomock.Any(), gomock.Any()),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.DeprecatedAPIsInUse, gomock.Any(), gomock.Any()),
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Namespace: adminGatesNamespace, Name: adminGatesConfigMap}, gomock.Any()).SetArg(2, *gates).Return(nil),
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Namespace: adminAcksNamespace, Name: adminAcksConfigMap}, gomock.Any()).SetArg(2, *acks).Return(nil),
+				mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()),
+			)
+			result := upgrader.checkDeprecatedAPIs(logger, "4.12.20")
+			Expect(result.Passed).To(BeFalse())
+		})
+	})
 })
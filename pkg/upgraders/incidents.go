@@ -0,0 +1,53 @@
+package upgraders
+
+import (
+	"github.com/go-logr/logr"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/pagerduty"
+)
+
+// triggerIncident triggers the PagerDuty incident of the upgrade. Failures are only logged as
+// incidents must not hold the upgrade back.
+func (c *clusterUpgrader) triggerIncident(incident pagerduty.Incident, subject string, summary string, logger logr.Logger) {
+	if c.incidents == nil {
+		return
+	}
+	err := c.incidents.Trigger(incident, c.upgradeConfig.Spec.Desired.Version, subject, summary)
+	if err != nil {
+		logger.Error(err, "Failed to trigger the PagerDuty incident", "incident", incident)
+	}
+}
+
+// resolveIncident resolves the PagerDuty incident of the upgrade if it is open
+func (c *clusterUpgrader) resolveIncident(incident pagerduty.Incident, subject string, logger logr.Logger) {
+	if c.incidents == nil {
+		return
+	}
+	err := c.incidents.Resolve(incident, c.upgradeConfig.Spec.Desired.Version, subject)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the PagerDuty incident", "incident", incident)
+	}
+}
+
+// resolveAllIncidents resolves the open PagerDuty incidents of the kind
+func (c *clusterUpgrader) resolveAllIncidents(incident pagerduty.Incident, logger logr.Logger) {
+	if c.incidents == nil {
+		return
+	}
+	err := c.incidents.ResolveAll(incident)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the PagerDuty incidents", "incident", incident)
+	}
+}
+
+// resolveOtherVersionIncidents resolves the open PagerDuty incidents of the upgrades to other
+// versions, such as the breach of their upgrade window, once the cluster upgrades to a new version
+func (c *clusterUpgrader) resolveOtherVersionIncidents(logger logr.Logger) {
+	if c.incidents == nil {
+		return
+	}
+	err := c.incidents.ResolveOtherVersions(c.upgradeConfig.Spec.Desired.Version)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the PagerDuty incidents of previous upgrades")
+	}
+}
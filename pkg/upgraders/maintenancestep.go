@@ -69,6 +69,7 @@ func (c *clusterUpgrader) CreateWorkerMaintWindow(ctx context.Context, logger lo
 	if err != nil {
 		return false, err
 	}
+	c.workerMaintenanceEnd = &endTime
 
 	return true, nil
 }
@@ -119,6 +119,7 @@ var _ = Describe("MaintenanceStep", func() {
 			result, err := upgrader.CreateWorkerMaintWindow(context.TODO(), logger)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result).To(BeTrue())
+			Expect(upgrader.workerMaintenanceEnd).NotTo(BeNil())
 		})
 		It("Indicates when creating the maintenance window has failed", func() {
 			fakeError := fmt.Errorf("fake error")
@@ -18,6 +18,7 @@ import (
 	metrics "github.com/openshift/managed-upgrade-operator/pkg/metrics"
 	upgraders "github.com/openshift/managed-upgrade-operator/pkg/upgraders"
 	gomock "go.uber.org/mock/gomock"
+	record "k8s.io/client-go/tools/record"
 	client "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -45,16 +46,16 @@ func (m *MockClusterUpgraderBuilder) EXPECT() *MockClusterUpgraderBuilderMockRec
 }
 
 // NewClient mocks base method.
-func (m *MockClusterUpgraderBuilder) NewClient(arg0 client.Client, arg1 configmanager.ConfigManager, arg2 metrics.Metrics, arg3 eventmanager.EventManager, arg4 v1alpha1.UpgradeType) (upgraders.ClusterUpgrader, error) {
+func (m *MockClusterUpgraderBuilder) NewClient(arg0 client.Client, arg1 configmanager.ConfigManager, arg2 metrics.Metrics, arg3 eventmanager.EventManager, arg4 record.EventRecorder, arg5 v1alpha1.UpgradeType) (upgraders.ClusterUpgrader, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "NewClient", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "NewClient", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(upgraders.ClusterUpgrader)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // NewClient indicates an expected call of NewClient.
-func (mr *MockClusterUpgraderBuilderMockRecorder) NewClient(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+func (mr *MockClusterUpgraderBuilderMockRecorder) NewClient(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewClient", reflect.TypeOf((*MockClusterUpgraderBuilder)(nil).NewClient), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewClient", reflect.TypeOf((*MockClusterUpgraderBuilder)(nil).NewClient), arg0, arg1, arg2, arg3, arg4, arg5)
 }
@@ -2,16 +2,19 @@ package upgraders
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
 	ac "github.com/openshift/managed-upgrade-operator/pkg/availabilitychecks"
 	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
 	"github.com/openshift/managed-upgrade-operator/pkg/configmanager"
+	"github.com/openshift/managed-upgrade-operator/pkg/consolebanner"
 	"github.com/openshift/managed-upgrade-operator/pkg/drain"
 	"github.com/openshift/managed-upgrade-operator/pkg/dvo"
 	"github.com/openshift/managed-upgrade-operator/pkg/eventmanager"
@@ -19,6 +22,7 @@ import (
 	"github.com/openshift/managed-upgrade-operator/pkg/maintenance"
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
 	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
+	"github.com/openshift/managed-upgrade-operator/pkg/pagerduty"
 	"github.com/openshift/managed-upgrade-operator/pkg/scaler"
 	"github.com/openshift/managed-upgrade-operator/pkg/upgradesteps"
 )
@@ -30,7 +34,7 @@ type osdUpgrader struct {
 }
 
 // NewOSDUpgrader creates a new instance of an osdUpgrader
-func NewOSDUpgrader(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metrics, notifier eventmanager.EventManager) (*osdUpgrader, error) {
+func NewOSDUpgrader(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metrics, notifier eventmanager.EventManager, recorder record.EventRecorder) (*osdUpgrader, error) {
 	cfg := &upgraderConfig{}
 	err := cfm.Into(cfg)
 	if err != nil {
@@ -53,12 +57,15 @@ func NewOSDUpgrader(c client.Client, cfm configmanager.ConfigManager, mc metrics
 			metrics:              mc,
 			cvClient:             cv.NewCVClient(c),
 			notifier:             notifier,
+			recorder:             recorder,
 			config:               cfg,
 			scaler:               scaler.NewScaler(),
 			drainstrategyBuilder: drain.NewBuilder(),
 			maintenance:          m,
 			machinery:            machinery.NewMachinery(),
 			availabilityCheckers: acs,
+			incidents:            pagerduty.NewLazyIncidentManager(pagerduty.NewBuilder(), c, cfm),
+			consoleBanner:        consolebanner.NewConsoleBanner(c, &cfg.ConsoleBanner),
 			dvo:                  dvo.NewBuilder(),
 		},
 	}
@@ -94,10 +101,16 @@ func NewOSDUpgrader(c client.Client, cfm configmanager.ConfigManager, mc metrics
 // within a given time period.
 func (u *osdUpgrader) UpgradeCluster(ctx context.Context, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (upgradev1alpha1.UpgradePhase, error) {
 	u.upgradeConfig = upgradeConfig
+	u.resolveOtherVersionIncidents(logger)
 
 	// OSD upgrader enforces a 'failure' policy if the upgrade does not commence within a time period
 	if cancelUpgrade, _ := shouldFailUpgrade(u.cvClient, u.config, u.upgradeConfig); cancelUpgrade {
-		return performUpgradeFailure(u.client, u.metrics, u.scaler, u.notifier, u.upgradeConfig, logger)
+		phase, err := performUpgradeFailure(u.client, u.metrics, u.scaler, u.notifier, u.upgradeConfig, logger)
+		if phase == upgradev1alpha1.UpgradePhaseFailed {
+			u.triggerIncident(pagerduty.IncidentUpgradeWindowBreached, "", fmt.Sprintf("Upgrade to %s did not commence within its upgrade window", u.upgradeConfig.Spec.Desired.Version), logger)
+		}
+		u.updateConsoleBanner(phase, logger)
+		return phase, err
 	}
 
 	return u.runSteps(ctx, logger, u.steps)
@@ -7,6 +7,7 @@ import (
 	"github.com/go-logr/logr"
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
 	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
+	"github.com/openshift/managed-upgrade-operator/pkg/pagerduty"
 	"github.com/openshift/managed-upgrade-operator/pkg/scaler"
 )
 
@@ -91,7 +92,7 @@ func (c *clusterUpgrader) RemoveExtraScaledNodes(ctx context.Context, logger log
 		return true, nil
 	}
 
-	nds, err := c.drainstrategyBuilder.NewNodeDrainStrategy(c.client, logger, c.upgradeConfig, &c.config.NodeDrain)
+	nds, err := c.drainstrategyBuilder.NewNodeDrainStrategy(c.client, c.recorder, logger, c.upgradeConfig, &c.config.NodeDrain)
 	if err != nil {
 		return false, err
 	}
@@ -100,6 +101,7 @@ func (c *clusterUpgrader) RemoveExtraScaledNodes(ctx context.Context, logger log
 		dtErr, ok := scaler.IsDrainTimeOutError(err)
 		if ok {
 			c.metrics.UpdateMetricNodeDrainFailed(dtErr.GetNodeName())
+			c.triggerIncident(pagerduty.IncidentNodeDrainFailed, dtErr.GetNodeName(), fmt.Sprintf("Extra upgrade node %s failed to drain in time", dtErr.GetNodeName()), logger)
 		}
 		logger.Error(err, "Extra upgrade node failed to drain in time")
 		return false, err
@@ -107,6 +109,7 @@ func (c *clusterUpgrader) RemoveExtraScaledNodes(ctx context.Context, logger log
 
 	if isScaledDown {
 		c.metrics.ResetAllMetricNodeDrainFailed()
+		c.resolveAllIncidents(pagerduty.IncidentNodeDrainFailed, logger)
 	}
 
 	return isScaledDown, nil
@@ -180,7 +180,7 @@ var _ = Describe("ScalerStep", func() {
 		It("should still attempt to scale down extra nodes when CanScale returns false", func() {
 			gomock.InOrder(
 				mockScalerClient.EXPECT().CanScale(gomock.Any(), gomock.Any()).Return(false, nil),
-				mockDrainStrategyBuilder.EXPECT().NewNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil),
+				mockDrainStrategyBuilder.EXPECT().NewNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil),
 				mockScalerClient.EXPECT().EnsureScaleDownNodes(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil),
 				mockMetricsClient.EXPECT().ResetAllMetricNodeDrainFailed(),
 			)
@@ -2,19 +2,23 @@ package upgraders
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
 	ac "github.com/openshift/managed-upgrade-operator/pkg/availabilitychecks"
 	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	"github.com/openshift/managed-upgrade-operator/pkg/consolebanner"
 	"github.com/openshift/managed-upgrade-operator/pkg/drain"
 	"github.com/openshift/managed-upgrade-operator/pkg/dvo"
 	"github.com/openshift/managed-upgrade-operator/pkg/eventmanager"
 	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
 	"github.com/openshift/managed-upgrade-operator/pkg/maintenance"
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/pkg/pagerduty"
 	"github.com/openshift/managed-upgrade-operator/pkg/scaler"
 	"github.com/openshift/managed-upgrade-operator/pkg/upgradesteps"
 )
@@ -36,6 +40,9 @@ type clusterUpgrader struct {
 	// EventManager client used for publishing upgrade events to a recipient
 	notifier eventmanager.EventManager
 
+	// Recorder of the Kubernetes Events published about the upgrade
+	recorder record.EventRecorder
+
 	// Scaler used for performing upgrade-related capacity scaling
 	scaler scaler.Scaler
 
@@ -58,12 +65,22 @@ type clusterUpgrader struct {
 	config *upgraderConfig
 
 	dvo dvo.DvoClientBuilder
+
+	// Client triggering PagerDuty incidents for the upgrade conditions that need attention
+	incidents pagerduty.IncidentManager
+
+	// Banner shown to the cluster users in the web console while upgrading
+	consoleBanner consolebanner.ConsoleBanner
+
+	// End of the worker maintenance window created by this upgrader, if any
+	workerMaintenanceEnd *time.Time
 }
 
 // runSteps runs the upgrader's upgrade steps and returns the last-executed
 // upgrade phase and any associated error
 func (c *clusterUpgrader) runSteps(ctx context.Context, logger logr.Logger, s []upgradesteps.UpgradeStep) (upgradev1alpha1.UpgradePhase, error) {
-	phase, err := upgradesteps.Run(ctx, c.upgradeConfig, logger, s)
+	phase, err := upgradesteps.Run(ctx, c.recorder, c.upgradeConfig, logger, s)
+	c.updateConsoleBanner(phase, logger)
 	return phase, err
 }
 
@@ -71,5 +88,6 @@ func (c *clusterUpgrader) runSteps(ctx context.Context, logger logr.Logger, s []
 // last-executed upgrade phase and any error associated with the phase execution.
 func (c *clusterUpgrader) UpgradeCluster(ctx context.Context, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (upgradev1alpha1.UpgradePhase, error) {
 	c.upgradeConfig = upgradeConfig
+	c.resolveOtherVersionIncidents(logger)
 	return c.runSteps(ctx, logger, c.steps)
 }
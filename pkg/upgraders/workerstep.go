@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
+	"github.com/openshift/managed-upgrade-operator/pkg/pagerduty"
 )
 
 // AllWorkersUpgraded checks whether all the worker nodes are ready with new config
@@ -26,9 +27,11 @@ func (c *clusterUpgrader) AllWorkersUpgraded(ctx context.Context, logger logr.Lo
 		if !silenceActive {
 			logger.Info("Workers upgrading and no maintenance window active. Setting worker upgrade timeout metric.")
 			c.metrics.UpdateMetricUpgradeWorkerTimeout(c.upgradeConfig.Name, c.upgradeConfig.Spec.Desired.Version)
+			c.triggerIncident(pagerduty.IncidentWorkerTimeout, "", fmt.Sprintf("Worker nodes are still upgrading to %s after the worker maintenance window ended (%v of %v upgraded)", c.upgradeConfig.Spec.Desired.Version, upgradingResult.UpdatedCount, upgradingResult.MachineCount), logger)
 		} else {
 			logger.Info("Workers upgrading and maintenance window active. Resetting worker timeout metric.")
 			c.metrics.ResetMetricUpgradeWorkerTimeout(c.upgradeConfig.Name, c.upgradeConfig.Spec.Desired.Version)
+			c.resolveIncident(pagerduty.IncidentWorkerTimeout, "", logger)
 		}
 		return false, nil
 	}
@@ -42,5 +45,6 @@ func (c *clusterUpgrader) AllWorkersUpgraded(ctx context.Context, logger logr.Lo
 	c.metrics.UpdateMetricWorkernodeUpgradeCompletedTimestamp(clusterid, c.upgradeConfig.Name, c.upgradeConfig.Spec.Desired.Version, time.Now())
 
 	c.metrics.ResetMetricUpgradeWorkerTimeout(c.upgradeConfig.Name, c.upgradeConfig.Spec.Desired.Version)
+	c.resolveIncident(pagerduty.IncidentWorkerTimeout, "", logger)
 	return true, nil
 }
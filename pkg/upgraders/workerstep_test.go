@@ -21,6 +21,8 @@ import (
 	mockMachinery "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
 	mockMaintenance "github.com/openshift/managed-upgrade-operator/pkg/maintenance/mocks"
 	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/pagerduty"
+	mockPagerDuty "github.com/openshift/managed-upgrade-operator/pkg/pagerduty/mocks"
 	mockScaler "github.com/openshift/managed-upgrade-operator/pkg/scaler/mocks"
 	"github.com/openshift/managed-upgrade-operator/util/mocks"
 	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
@@ -39,6 +41,7 @@ var _ = Describe("WorkerStep", func() {
 		mockCVClient             *cvMocks.MockClusterVersion
 		mockDrainStrategyBuilder *mockDrain.MockNodeDrainStrategyBuilder
 		mockEMClient             *emMocks.MockEventManager
+		mockIncidents            *mockPagerDuty.MockIncidentManager
 		// upgradeconfig to be used during tests
 		upgradeConfigName types.NamespacedName
 		upgradeConfig     *upgradev1alpha1.UpgradeConfig
@@ -63,6 +66,7 @@ var _ = Describe("WorkerStep", func() {
 		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
 		mockDrainStrategyBuilder = mockDrain.NewMockNodeDrainStrategyBuilder(mockCtrl)
 		mockEMClient = emMocks.NewMockEventManager(mockCtrl)
+		mockIncidents = mockPagerDuty.NewMockIncidentManager(mockCtrl)
 		logger = logf.Log.WithName("cluster upgrader test logger")
 		config = &upgraderConfig{
 			Maintenance: maintenanceConfig{
@@ -84,6 +88,7 @@ var _ = Describe("WorkerStep", func() {
 			metrics:              mockMetricsClient,
 			cvClient:             mockCVClient,
 			notifier:             mockEMClient,
+			incidents:            mockIncidents,
 			config:               config,
 			scaler:               mockScalerClient,
 			drainstrategyBuilder: mockDrainStrategyBuilder,
@@ -130,6 +135,7 @@ var _ = Describe("WorkerStep", func() {
 					mockCVClient.EXPECT().GetClusterId(),
 					mockMetricsClient.EXPECT().UpdateMetricWorkernodeUpgradeCompletedTimestamp(gomock.Any(), upgradeConfig.Name, upgradeConfig.Spec.Desired.Version, gomock.Any()),
 					mockMetricsClient.EXPECT().ResetMetricUpgradeWorkerTimeout(upgradeConfig.Name, upgradeConfig.Spec.Desired.Version),
+					mockIncidents.EXPECT().Resolve(pagerduty.IncidentWorkerTimeout, upgradeConfig.Spec.Desired.Version, ""),
 				)
 				result, err := upgrader.AllWorkersUpgraded(context.TODO(), logger)
 				Expect(err).NotTo(HaveOccurred())
@@ -142,6 +148,7 @@ var _ = Describe("WorkerStep", func() {
 					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
 					mockMaintClient.EXPECT().IsActive().Return(true, nil),
 					mockMetricsClient.EXPECT().ResetMetricUpgradeWorkerTimeout(upgradeConfig.Name, upgradeConfig.Spec.Desired.Version),
+					mockIncidents.EXPECT().Resolve(pagerduty.IncidentWorkerTimeout, upgradeConfig.Spec.Desired.Version, ""),
 				)
 				result, err := upgrader.AllWorkersUpgraded(context.TODO(), logger)
 				Expect(err).NotTo(HaveOccurred())
@@ -154,6 +161,7 @@ var _ = Describe("WorkerStep", func() {
 					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
 					mockMaintClient.EXPECT().IsActive(),
 					mockMetricsClient.EXPECT().UpdateMetricUpgradeWorkerTimeout(upgradeConfig.Name, upgradeConfig.Spec.Desired.Version),
+					mockIncidents.EXPECT().Trigger(pagerduty.IncidentWorkerTimeout, upgradeConfig.Spec.Desired.Version, "", gomock.Any()),
 				)
 				result, err := upgrader.AllWorkersUpgraded(context.TODO(), logger)
 				Expect(err).NotTo(HaveOccurred())
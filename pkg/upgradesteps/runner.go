@@ -9,6 +9,14 @@ import (
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// Reasons of the Events recorded on the UpgradeConfig as the upgrade steps run
+const (
+	EventReasonStepStarted   = "UpgradeStepStarted"
+	EventReasonStepCompleted = "UpgradeStepCompleted"
+	EventReasonStepFailed    = "UpgradeStepFailed"
 )
 
 // UpgradeStep is the interface for steps that the upgrade runner
@@ -20,16 +28,20 @@ type UpgradeStep interface {
 
 // Run executes the provided steps in order until one fails or all steps
 // are completed. The function returns an indication of the last-completed
-// UpgradePhase any associated error.
-func Run(ctx context.Context, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger, steps []UpgradeStep) (upgradev1alpha1.UpgradePhase, error) {
+// UpgradePhase any associated error. The start, completion and errors of
+// the steps are recorded as Events on the UpgradeConfig.
+func Run(ctx context.Context, recorder record.EventRecorder, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger, steps []UpgradeStep) (upgradev1alpha1.UpgradePhase, error) {
 	for _, step := range steps {
 		logger.Info(fmt.Sprintf("running step %s", step))
-		setConditionStart(step, upgradeConfig)
+		if setConditionStart(step, upgradeConfig) {
+			recorder.Eventf(upgradeConfig, corev1.EventTypeNormal, EventReasonStepStarted, "%s has started", step)
+		}
 		result, err := step.run(ctx, logger)
 
 		if err != nil {
 			logger.Error(err, fmt.Sprintf("error when %s", step.String()))
 			setConditionInProgress(step, err.Error(), upgradeConfig)
+			recorder.Eventf(upgradeConfig, corev1.EventTypeWarning, EventReasonStepFailed, "%s failed: %v", step, err)
 			return upgradev1alpha1.UpgradePhaseUpgrading, err
 		}
 
@@ -39,7 +51,9 @@ func Run(ctx context.Context, upgradeConfig *upgradev1alpha1.UpgradeConfig, logg
 			return upgradev1alpha1.UpgradePhaseUpgrading, nil
 		}
 
-		setConditionComplete(step, upgradeConfig)
+		if setConditionComplete(step, upgradeConfig) {
+			recorder.Eventf(upgradeConfig, corev1.EventTypeNormal, EventReasonStepCompleted, "%s is completed", step)
+		}
 	}
 	return upgradev1alpha1.UpgradePhaseUpgraded, nil
 }
@@ -57,8 +71,9 @@ func newUpgradeCondition(reason, msg string, conditionType upgradev1alpha1.Upgra
 
 // setConditionStart adds an UpgradeCondition to the UpgradeConfig indicating
 // that a given step has commenced execution.
-// If the UpgradeCondition already exists, no action is taken.
-func setConditionStart(step UpgradeStep, upgradeConfig *upgradev1alpha1.UpgradeConfig) {
+// If the UpgradeCondition already exists, no action is taken. It returns true if the
+// condition was added.
+func setConditionStart(step UpgradeStep, upgradeConfig *upgradev1alpha1.UpgradeConfig) bool {
 	history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
 	c := history.Conditions.GetCondition(upgradev1alpha1.UpgradeConditionType(step.String()))
 	// Only set the condition if it doesn't already exist - the start time should already appear
@@ -70,7 +85,9 @@ func setConditionStart(step UpgradeStep, upgradeConfig *upgradev1alpha1.UpgradeC
 		condition.StartTime = &metav1.Time{Time: time.Now()}
 		history.Conditions.SetCondition(*condition)
 		upgradeConfig.Status.History.SetHistory(*history)
+		return true
 	}
+	return false
 }
 
 // setConditionInProgress adds or updates an UpgradeCondition in the UpgradeConfig indicating
@@ -89,11 +106,12 @@ func setConditionInProgress(step UpgradeStep, message string, upgradeConfig *upg
 }
 
 // setConditionComplete adds or updates an UpgradeCondition in the UpgradeConfig indicating
-// that a given step has completed.
-func setConditionComplete(step UpgradeStep, upgradeConfig *upgradev1alpha1.UpgradeConfig) {
+// that a given step has completed. It returns true if the step was not already completed.
+func setConditionComplete(step UpgradeStep, upgradeConfig *upgradev1alpha1.UpgradeConfig) bool {
 	history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
 	c := history.Conditions.GetCondition(upgradev1alpha1.UpgradeConditionType(step.String()))
 	if c != nil {
+		completed := c.Status != corev1.ConditionTrue
 		c.Reason = fmt.Sprintf("%s done", step.String())
 		c.Message = fmt.Sprintf("%s is completed", step.String())
 		c.Status = corev1.ConditionTrue
@@ -103,5 +121,7 @@ func setConditionComplete(step UpgradeStep, upgradeConfig *upgradev1alpha1.Upgra
 		}
 		history.Conditions.SetCondition(*c)
 		upgradeConfig.Status.History.SetHistory(*history)
+		return completed
 	}
+	return false
 }
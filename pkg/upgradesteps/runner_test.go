@@ -6,6 +6,7 @@ import (
 	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -32,10 +33,12 @@ var _ = Describe("HealthCheckStep", func() {
 		}
 		upgradeConfigName types.NamespacedName
 		upgradeConfig     *upgradev1alpha1.UpgradeConfig
+		recorder          *record.FakeRecorder
 	)
 
 	BeforeEach(func() {
 		logger = logf.Log.WithName("step runner test logger")
+		recorder = record.NewFakeRecorder(10)
 		upgradeConfigName = types.NamespacedName{
 			Name:      "test-upgradeconfig",
 			Namespace: "test-namespace",
@@ -55,12 +58,12 @@ var _ = Describe("HealthCheckStep", func() {
 			Action(finalStepName, successfulStep),
 		}
 		It("should return an upgrade completed phase", func() {
-			phase, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			phase, err := Run(context.TODO(), recorder, upgradeConfig, logger, steps)
 			Expect(err).To(BeNil())
 			Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgraded))
 		})
 		It("should have a successful condition for each step", func() {
-			_, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			_, err := Run(context.TODO(), recorder, upgradeConfig, logger, steps)
 			Expect(err).To(BeNil())
 			history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
 			Expect(history).ToNot(BeNil())
@@ -71,6 +74,17 @@ var _ = Describe("HealthCheckStep", func() {
 				Expect(condition.CompleteTime).ToNot(BeNil())
 			}
 		})
+		It("should record the start and completion of each step once", func() {
+			_, err := Run(context.TODO(), recorder, upgradeConfig, logger, steps)
+			Expect(err).To(BeNil())
+			_, err = Run(context.TODO(), recorder, upgradeConfig, logger, steps)
+			Expect(err).To(BeNil())
+			Expect(recorder.Events).To(HaveLen(2 * len(steps)))
+			for _, step := range steps {
+				Expect(<-recorder.Events).To(Equal(fmt.Sprintf("Normal %s %s has started", EventReasonStepStarted, step)))
+				Expect(<-recorder.Events).To(Equal(fmt.Sprintf("Normal %s %s is completed", EventReasonStepCompleted, step)))
+			}
+		})
 	})
 
 	Context("When a step is unsuccessful", func() {
@@ -84,13 +98,13 @@ var _ = Describe("HealthCheckStep", func() {
 		}
 
 		It("should indicate the upgrade is still ongoing", func() {
-			phase, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			phase, err := Run(context.TODO(), recorder, upgradeConfig, logger, steps)
 			Expect(err).To(BeNil())
 			Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgrading))
 		})
 
 		It("should correctly indicate condition states", func() {
-			_, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			_, err := Run(context.TODO(), recorder, upgradeConfig, logger, steps)
 			Expect(err).To(BeNil())
 			history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
 			Expect(history).ToNot(BeNil())
@@ -120,15 +134,15 @@ var _ = Describe("HealthCheckStep", func() {
 		}
 
 		It("should indicate the upgrade is still ongoing", func() {
-			phase, _ := Run(context.TODO(), upgradeConfig, logger, steps)
+			phase, _ := Run(context.TODO(), recorder, upgradeConfig, logger, steps)
 			Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgrading))
 		})
 		It("should indicate the error associated with the failed step", func() {
-			_, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			_, err := Run(context.TODO(), recorder, upgradeConfig, logger, steps)
 			Expect(err).To(Equal(err))
 		})
 		It("should correctly indicate condition states", func() {
-			_, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			_, err := Run(context.TODO(), recorder, upgradeConfig, logger, steps)
 			Expect(err).To(Equal(err))
 			history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
 			Expect(history).ToNot(BeNil())
@@ -145,5 +159,14 @@ var _ = Describe("HealthCheckStep", func() {
 			Expect(erroredStepCondition.StartTime).ToNot(BeNil())
 			Expect(erroredStepCondition.CompleteTime).To(BeNil())
 		})
+		It("should record a warning event with the error of the failed step", func() {
+			_, err := Run(context.TODO(), recorder, upgradeConfig, logger, steps)
+			Expect(err).To(HaveOccurred())
+			Expect(recorder.Events).To(HaveLen(4))
+			Expect(<-recorder.Events).To(HavePrefix("Normal " + EventReasonStepStarted))
+			Expect(<-recorder.Events).To(HavePrefix("Normal " + EventReasonStepCompleted))
+			Expect(<-recorder.Events).To(HavePrefix("Normal " + EventReasonStepStarted))
+			Expect(<-recorder.Events).To(Equal(fmt.Sprintf("Warning %s %s failed: a bad time", EventReasonStepFailed, erroredStepName)))
+		})
 	})
 })